@@ -0,0 +1,377 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func attendanceErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "volunteer already checked in":
+		c.JSON(http.StatusConflict, gin.H{"error": "Relawan masih check-in, lakukan check-out terlebih dahulu"})
+	case "volunteer not checked in":
+		c.JSON(http.StatusConflict, gin.H{"error": "Relawan belum check-in"})
+	case "volunteer completed":
+		c.JSON(http.StatusConflict, gin.H{"error": "Relawan sudah berstatus completed dan tidak bisa check-in"})
+	case "shift not active":
+		c.JSON(http.StatusConflict, gin.H{"error": "Check-in shift hanya bisa dilakukan mulai satu jam sebelum shift dimulai sampai shift berakhir"})
+	case "invalid check-out time":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Waktu check-out harus di antara waktu check-in dan sekarang"})
+	case "invalid coordinates":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan"})
+	default:
+		shiftErrorResponse(c, err, fallback)
+	}
+}
+
+func formatHours(hours float64) string {
+	return strings.Replace(strconv.FormatFloat(hours, 'f', 2, 64), ".", ",", 1)
+}
+
+func renderServiceCertificate(summary structs.VolunteerServiceSummary) []byte {
+	orgName, orgAddress, orgContact := receiptOrganization()
+
+	var firstCheckIn, lastCheckOut *time.Time
+	for _, hours := range summary.Disasters {
+		if hours.FirstCheckIn != nil && (firstCheckIn == nil || hours.FirstCheckIn.Before(*firstCheckIn)) {
+			firstCheckIn = hours.FirstCheckIn
+		}
+		if hours.LastCheckOut != nil && (lastCheckOut == nil || hours.LastCheckOut.After(*lastCheckOut)) {
+			lastCheckOut = hours.LastCheckOut
+		}
+	}
+	period := "-"
+	if firstCheckIn != nil && lastCheckOut != nil {
+		period = firstCheckIn.Format("02/01/2006") + " - " + lastCheckOut.Format("02/01/2006")
+	}
+
+	texts := []pdfText{
+		{50, 790, 18, true, orgName},
+		{50, 772, 10, false, orgAddress},
+		{50, 758, 10, false, orgContact},
+		{50, 700, 20, true, "SERTIFIKAT PENGABDIAN RELAWAN"},
+		{50, 660, 12, false, "Diberikan kepada"},
+		{50, 635, 18, true, summary.VolunteerName},
+		{50, 605, 11, false, fmt.Sprintf("atas pengabdiannya sebagai relawan selama %s jam dalam %d sesi layanan,", formatHours(summary.TotalHours), summary.Sessions)},
+		{50, 589, 11, false, "periode " + period + "."},
+		{50, 545, 11, true, "Bencana"},
+		{380, 545, 11, true, "Sesi"},
+		{450, 545, 11, true, "Jam"},
+	}
+
+	// Satu halaman memuat 18 baris bencana, sisanya diringkas
+	y := 520.0
+	for i, hours := range summary.Disasters {
+		if i == 18 {
+			texts = append(texts, pdfText{50, y, 10, false, fmt.Sprintf("... dan %d bencana lainnya", len(summary.Disasters)-i)})
+			y -= 20
+			break
+		}
+		name := hours.DisasterName
+		if name == "" {
+			name = "Tanpa bencana"
+		}
+		texts = append(texts,
+			pdfText{50, y, 10, false, name},
+			pdfText{380, y, 10, false, strconv.Itoa(hours.Sessions)},
+			pdfText{450, y, 10, false, formatHours(hours.Hours)},
+		)
+		y -= 20
+	}
+
+	texts = append(texts,
+		pdfText{380, y - 4, 11, true, strconv.Itoa(summary.Sessions)},
+		pdfText{450, y - 4, 11, true, formatHours(summary.TotalHours)},
+		pdfText{50, y - 4, 11, true, "Total"},
+		pdfText{50, 110, 10, false, "Diterbitkan pada " + time.Now().Format("02/01/2006 15:04")},
+		pdfText{50, 80, 9, false, "Jam layanan dihitung dari catatan check-in dan check-out relawan. Sertifikat ini dibuat secara elektronik."},
+	)
+
+	return renderPDF(texts, []float64{745, 538, y + 10})
+}
+
+// CheckInVolunteer godoc
+// @Summary Check in a volunteer
+// @Description Mencatat check-in relawan di lokasi, opsional untuk shift tertentu. Status relawan menjadi on_mission sampai check-out. Lokasi default dari shift atau lokasi relawan
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param input body structs.CheckInInput true "Data check-in"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/check-in [post]
+func CheckInVolunteer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.CheckInInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	attendance, err := repository.CheckInVolunteer(database.DbConnection, id, input)
+	if err != nil {
+		attendanceErrorResponse(c, err, "Gagal mencatat check-in")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Check-in berhasil dicatat",
+		"result":  attendance,
+	})
+}
+
+// CheckOutVolunteer godoc
+// @Summary Check out a volunteer
+// @Description Menutup check-in relawan dan mengembalikan status relawan seperti sebelum check-in. Admin dapat mengisi checked_out_at (DD/MM/YYYY HH:mm) untuk check-in yang terlupa ditutup
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param input body structs.CheckOutInput true "Data check-out"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/check-out [post]
+func CheckOutVolunteer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.CheckOutInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	if input.CheckedOutAt != "" && c.GetString("role") != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Hanya admin yang bisa mengisi waktu check-out",
+		})
+		return
+	}
+	checkedOutAt, ok := parseDateTimeField(c, input.CheckedOutAt, "checked_out_at")
+	if !ok {
+		return
+	}
+
+	attendance, err := repository.CheckOutVolunteer(database.DbConnection, id, input, checkedOutAt)
+	if err != nil {
+		attendanceErrorResponse(c, err, "Gagal mencatat check-out")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Check-out berhasil dicatat",
+		"result":  attendance,
+	})
+}
+
+// GetVolunteerAttendances godoc
+// @Summary Get volunteer attendance history
+// @Description Riwayat check-in dan check-out relawan, terbaru lebih dulu
+// @Tags Attendance
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/attendances [get]
+func GetVolunteerAttendances(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	attendances, err := repository.GetVolunteerAttendances(database.DbConnection, id, disasterID)
+	if err != nil {
+		attendanceErrorResponse(c, err, "Gagal mendapatkan riwayat kehadiran")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": attendances,
+	})
+}
+
+// GetOnSiteVolunteers godoc
+// @Summary Get volunteers currently on site
+// @Description Daftar relawan yang sedang check-in dan belum check-out
+// @Tags Attendance
+// @Produce json
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/on-site [get]
+func GetOnSiteVolunteers(c *gin.Context) {
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	attendances, err := repository.GetOnSiteVolunteers(database.DbConnection, disasterID)
+	if err != nil {
+		attendanceErrorResponse(c, err, "Gagal mendapatkan relawan di lokasi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": attendances,
+	})
+}
+
+// GetVolunteerServiceHours godoc
+// @Summary Get volunteer service hours
+// @Description Total jam layanan relawan dari sesi yang sudah check-out, dirinci per bencana
+// @Tags Attendance
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/hours [get]
+func GetVolunteerServiceHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	summary, err := repository.GetVolunteerServiceHours(database.DbConnection, id, disasterID)
+	if err != nil {
+		attendanceErrorResponse(c, err, "Gagal mendapatkan jam layanan relawan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": summary,
+	})
+}
+
+// GetDisasterServiceHours godoc
+// @Summary Get disaster service hours
+// @Description Total jam layanan relawan untuk bencana, dirinci per relawan, beserta jumlah relawan yang sedang di lokasi
+// @Tags Attendance
+// @Produce json
+// @Param id path int true "Disaster ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disasters/{id}/hours [get]
+func GetDisasterServiceHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID bencana tidak valid",
+		})
+		return
+	}
+
+	summary, err := repository.GetDisasterServiceHours(database.DbConnection, id)
+	if err != nil {
+		attendanceErrorResponse(c, err, "Gagal mendapatkan jam layanan bencana")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": summary,
+	})
+}
+
+// GetServiceCertificate godoc
+// @Summary Download volunteer service certificate
+// @Description Mengunduh sertifikat pengabdian relawan (PDF) berisi total jam layanan dan rincian per bencana
+// @Tags Attendance
+// @Produce application/pdf
+// @Param id path int true "Volunteer ID"
+// @Param disaster_id query int false "Sertifikat untuk satu bencana"
+// @Success 200 {file} file
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/certificate [get]
+func GetServiceCertificate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	summary, err := repository.GetVolunteerServiceHours(database.DbConnection, id, disasterID)
+	if err != nil {
+		attendanceErrorResponse(c, err, "Gagal membuat sertifikat relawan")
+		return
+	}
+	if summary.Sessions == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Relawan belum memiliki jam layanan yang tercatat",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="sertifikat-relawan-%d.pdf"`, id))
+	c.Data(http.StatusOK, "application/pdf", renderServiceCertificate(summary))
+}
@@ -0,0 +1,380 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxCertificationDocumentSize = 5 << 20
+
+func certificationErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "certification not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sertifikasi tidak ditemukan"})
+	case "certification document not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sertifikasi tidak memiliki dokumen"})
+	case "certification type required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jenis sertifikasi wajib diisi"})
+	case "certification already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": "Sertifikasi dengan jenis, penerbit, dan nomor yang sama sudah terdaftar"})
+	case "invalid certification dates":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tanggal kedaluwarsa tidak boleh sebelum tanggal terbit"})
+	case "invalid certification status":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status sertifikasi tidak valid, hanya bisa 'verified' atau 'rejected'"})
+	case "certification expired":
+		c.JSON(http.StatusConflict, gin.H{"error": "Sertifikasi sudah kedaluwarsa dan tidak bisa diverifikasi"})
+	default:
+		shiftErrorResponse(c, err, fallback)
+	}
+}
+
+func parseDateField(c *gin.Context, value, field string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := time.Parse("02/01/2006", value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Format " + field + " tidak valid, gunakan DD/MM/YYYY",
+		})
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// Dokumen sertifikasi berupa gambar atau PDF dengan ukuran maksimal 5MB
+func readCertificationDocument(c *gin.Context, certification *structs.Certification) bool {
+	fileHeader, err := c.FormFile("document")
+	if err != nil {
+		return true
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if fileHeader.Size > maxCertificationDocumentSize || !(strings.HasPrefix(contentType, "image/") || contentType == "application/pdf") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dokumen sertifikasi harus berupa gambar atau PDF dengan ukuran maksimal 5MB",
+		})
+		return false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Gagal membaca dokumen sertifikasi",
+		})
+		return false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxCertificationDocumentSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Gagal membaca dokumen sertifikasi",
+		})
+		return false
+	}
+
+	certification.DocumentName = fileHeader.Filename
+	certification.DocumentContentType = contentType
+	certification.Document = data
+	return true
+}
+
+// CreateCertification godoc
+// @Summary Add a volunteer certification
+// @Description Menambahkan sertifikasi atau lisensi relawan beserta dokumen pendukung (gambar atau PDF maksimal 5MB). Sertifikasi berstatus pending sampai diverifikasi admin. Tanggal memakai format DD/MM/YYYY
+// @Tags Certification
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param certification_type formData string true "Jenis sertifikasi, misal medical_license atau sar_certificate"
+// @Param issuer formData string true "Penerbit sertifikasi"
+// @Param certificate_number formData string true "Nomor sertifikasi"
+// @Param issued_at formData string false "Tanggal terbit (DD/MM/YYYY)"
+// @Param expires_at formData string false "Tanggal kedaluwarsa (DD/MM/YYYY)"
+// @Param document formData file false "Dokumen sertifikasi"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/certifications [post]
+func CreateCertification(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.CertificationInput
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	issuedAt, ok := parseDateField(c, input.IssuedAt, "issued_at")
+	if !ok {
+		return
+	}
+	expiresAt, ok := parseDateField(c, input.ExpiresAt, "expires_at")
+	if !ok {
+		return
+	}
+
+	certification := structs.Certification{
+		VolunteerID:       id,
+		CertificationType: input.CertificationType,
+		Issuer:            input.Issuer,
+		CertificateNumber: input.CertificateNumber,
+		IssuedAt:          issuedAt,
+		ExpiresAt:         expiresAt,
+	}
+	if !readCertificationDocument(c, &certification) {
+		return
+	}
+
+	if err := repository.CreateCertification(database.DbConnection, &certification); err != nil {
+		certificationErrorResponse(c, err, "Gagal menambahkan sertifikasi")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Sertifikasi berhasil ditambahkan dan menunggu verifikasi admin",
+		"result":  certification,
+	})
+}
+
+// GetVolunteerCertifications godoc
+// @Summary Get volunteer certifications
+// @Description Daftar sertifikasi relawan beserta status verifikasi, sisa hari sebelum kedaluwarsa, dan tanda expiring_soon bila kedaluwarsa dalam CERTIFICATION_WARNING_DAYS hari (default 30)
+// @Tags Certification
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/certifications [get]
+func GetVolunteerCertifications(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	certifications, err := repository.GetVolunteerCertifications(database.DbConnection, id)
+	if err != nil {
+		certificationErrorResponse(c, err, "Gagal mendapatkan sertifikasi relawan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": certifications,
+	})
+}
+
+// GetCertificationDocument godoc
+// @Summary Download certification document
+// @Description Mengunduh dokumen pendukung sertifikasi relawan
+// @Tags Certification
+// @Produce octet-stream
+// @Param id path int true "Volunteer ID"
+// @Param certification_id path int true "Certification ID"
+// @Success 200 {file} file
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/certifications/{certification_id}/document [get]
+func GetCertificationDocument(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	certificationID, err := strconv.Atoi(c.Param("certification_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID sertifikasi tidak valid",
+		})
+		return
+	}
+
+	certification, err := repository.GetCertificationDocument(database.DbConnection, id, certificationID)
+	if err != nil {
+		certificationErrorResponse(c, err, "Gagal mengunduh dokumen sertifikasi")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="certification-%d-%s"`, certification.ID, certification.DocumentName))
+	c.Data(http.StatusOK, certification.DocumentContentType, certification.Document)
+}
+
+// DeleteCertification godoc
+// @Summary Delete a volunteer certification
+// @Description Menghapus sertifikasi relawan
+// @Tags Certification
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param certification_id path int true "Certification ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/certifications/{certification_id} [delete]
+func DeleteCertification(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	certificationID, err := strconv.Atoi(c.Param("certification_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID sertifikasi tidak valid",
+		})
+		return
+	}
+
+	if err := repository.DeleteCertification(database.DbConnection, id, certificationID); err != nil {
+		certificationErrorResponse(c, err, "Gagal menghapus sertifikasi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sertifikasi berhasil dihapus",
+	})
+}
+
+// GetCertifications godoc
+// @Summary Get certifications for review
+// @Description Daftar sertifikasi semua relawan, dapat difilter berdasarkan status (pending, verified, rejected) untuk antrean verifikasi
+// @Tags Certification
+// @Produce json
+// @Param status query string false "Filter status sertifikasi"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /certifications [get]
+func GetCertifications(c *gin.Context) {
+	certifications, err := repository.GetCertifications(database.DbConnection, c.Query("status"))
+	if err != nil {
+		if err.Error() == "invalid certification status" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Status sertifikasi tidak valid, hanya bisa 'pending', 'verified', atau 'rejected'",
+			})
+			return
+		}
+		certificationErrorResponse(c, err, "Gagal mendapatkan daftar sertifikasi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": certifications,
+	})
+}
+
+// GetExpiringCertifications godoc
+// @Summary Get expiring certifications
+// @Description Sertifikasi terverifikasi yang akan kedaluwarsa dalam jumlah hari tertentu, diurutkan dari yang paling dekat
+// @Tags Certification
+// @Produce json
+// @Param days query int false "Jumlah hari ke depan (default CERTIFICATION_WARNING_DAYS, 30)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /certifications/expiring [get]
+func GetExpiringCertifications(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(repository.CertificationWarningDays())))
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parameter days harus berupa bilangan bulat positif",
+		})
+		return
+	}
+
+	certifications, err := repository.GetExpiringCertifications(database.DbConnection, days)
+	if err != nil {
+		certificationErrorResponse(c, err, "Gagal mendapatkan sertifikasi yang akan kedaluwarsa")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": certifications,
+	})
+}
+
+// ReviewCertification godoc
+// @Summary Verify or reject a certification
+// @Description Admin memverifikasi atau menolak sertifikasi relawan. Hanya sertifikasi terverifikasi yang belum kedaluwarsa yang memenuhi syarat penugasan shift dan tugas
+// @Tags Certification
+// @Accept json
+// @Produce json
+// @Param id path int true "Certification ID"
+// @Param input body structs.CertificationReviewInput true "Hasil verifikasi"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /certifications/{id}/review [put]
+func ReviewCertification(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.CertificationReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	certification, err := repository.ReviewCertification(database.DbConnection, id, input.Status, input.Note, currentUser.ID)
+	if err != nil {
+		certificationErrorResponse(c, err, "Gagal memverifikasi sertifikasi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sertifikasi berhasil ditandai " + certification.Status,
+		"result":  certification,
+	})
+}
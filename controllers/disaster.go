@@ -15,12 +15,29 @@ import (
 // @Description Mendapatkan semua laporan bencana
 // @Tags Disaster
 // @Produce json
+// @Param near query string false "Titik pusat pencarian dalam format lat,lon"
+// @Param radius_km query number false "Radius pencarian dalam kilometer (wajib bersama near)"
+// @Param bbox query string false "Bounding box dalam format min_lat,min_lon,max_lat,max_lon"
 // @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /disasters [get]
 func GetAllDisasters(c *gin.Context) {
-	disasters, err := repository.GetAllDisasters(database.DbConnection)
+	filter, err := parseGeoFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parameter lokasi tidak valid, gunakan near=lat,lon&radius_km=... atau bbox=min_lat,min_lon,max_lat,max_lon",
+		})
+		return
+	}
+
+	var disasters []structs.Disaster
+	if filter != nil {
+		disasters, err = repository.GetDisastersByArea(database.DbConnection, *filter)
+	} else {
+		disasters, err = repository.GetAllDisasters(database.DbConnection)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mendapatkan laporan bencana",
@@ -28,7 +45,11 @@ func GetAllDisasters(c *gin.Context) {
 		return
 	}
 
-	if len(disasters) == 0 {
+	// Pencarian berdasarkan lokasi mengembalikan daftar kosong bila tidak ada bencana di area tersebut
+	if filter != nil && disasters == nil {
+		disasters = []structs.Disaster{}
+	}
+	if len(disasters) == 0 && filter == nil {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Tidak ada laporan bencana",
 		})
@@ -98,11 +119,14 @@ func CreateDisaster(c *gin.Context) {
 	}
 
 	disaster := &structs.Disaster{
-		Type:        input.Type,
-		Location:    input.Location,
-		Description: input.Description,
-		Status:      input.Status,
-		ReportedBy:  input.ReportedBy,
+		Type:             input.Type,
+		Location:         input.Location,
+		Description:      input.Description,
+		Status:           input.Status,
+		ReportedBy:       input.ReportedBy,
+		Latitude:         input.Latitude,
+		Longitude:        input.Longitude,
+		LocationAccuracy: input.LocationAccuracy,
 	}
 
 	err := repository.CreateDisaster(database.DbConnection, disaster)
@@ -121,6 +145,13 @@ func CreateDisaster(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal membuat laporan bencana",
 		})
@@ -167,12 +198,15 @@ func UpdateDisaster(c *gin.Context) {
 	}
 
 	disaster := structs.Disaster{
-		ID:          id,
-		Type:        input.Type,
-		Location:    input.Location,
-		Description: input.Description,
-		Status:      input.Status,
-		ReportedBy:  input.ReportedBy,
+		ID:               id,
+		Type:             input.Type,
+		Location:         input.Location,
+		Description:      input.Description,
+		Status:           input.Status,
+		ReportedBy:       input.ReportedBy,
+		Latitude:         input.Latitude,
+		Longitude:        input.Longitude,
+		LocationAccuracy: input.LocationAccuracy,
 	}
 
 	err = repository.UpdateDisaster(database.DbConnection, disaster)
@@ -184,6 +218,13 @@ func UpdateDisaster(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mengupdate laporan bencana",
 		})
@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxProofSize = 5 << 20
+
+func deliveryErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "distribution log not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Log distribusi tidak ditemukan"})
+	case "invalid status transition":
+		c.JSON(http.StatusConflict, gin.H{"error": "Perubahan status distribusi tidak diizinkan dari status saat ini"})
+	case "confirmation requires proof":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status confirmed hanya dapat diisi melalui konfirmasi penerimaan beserta bukti serah terima"})
+	case "invalid confirmation code":
+		c.JSON(http.StatusForbidden, gin.H{"error": "Kode konfirmasi penerima tidak sesuai"})
+	case "invalid quantity received":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jumlah diterima tidak boleh negatif atau melebihi jumlah yang dikirim"})
+	case "discrepancy reason required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Alasan selisih wajib diisi jika jumlah diterima berbeda dengan jumlah dikirim"})
+	case "proof of delivery required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bukti serah terima (tanda tangan atau foto) wajib dilampirkan"})
+	case "proof not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bukti serah terima tidak ditemukan"})
+	case "invalid distribution status":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status awal distribusi hanya boleh planned atau dispatched"})
+	case "distribution locked":
+		c.JSON(http.StatusConflict, gin.H{"error": "Logistik dan jumlah distribusi tidak dapat diubah setelah barang dalam perjalanan"})
+	default:
+		stockErrorResponse(c, err, fallback)
+	}
+}
+
+func readDeliveryProof(c *gin.Context, field string) (*structs.DistributionProof, bool) {
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		return nil, true
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if fileHeader.Size > maxProofSize || !strings.HasPrefix(contentType, "image/") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Bukti serah terima harus berupa gambar dengan ukuran maksimal 5MB",
+		})
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Gagal membaca file bukti serah terima",
+		})
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxProofSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Gagal membaca file bukti serah terima",
+		})
+		return nil, false
+	}
+
+	return &structs.DistributionProof{
+		Kind:        field,
+		FileName:    fileHeader.Filename,
+		ContentType: contentType,
+		Data:        data,
+	}, true
+}
+
+// UpdateDistributionStatus godoc
+// @Summary Update distribution delivery status
+// @Description Memperbarui status pengiriman distribusi mengikuti alur planned → dispatched → in_transit → delivered → confirmed, atau failed/returned. Distribusi yang gagal atau dikembalikan mengembalikan stok ke batch asalnya. Status confirmed hanya bisa diisi melalui endpoint konfirmasi penerimaan
+// @Tags DistributionLog
+// @Accept json
+// @Produce json
+// @Param id path int true "Distribution Log ID"
+// @Param input body structs.DistributionStatusInput true "Status pengiriman baru"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /distribution_logs/{id}/status [put]
+func UpdateDistributionStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.DistributionStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.UpdateDistributionStatus(database.DbConnection, id, input.Status, input.Note, currentUser.ID)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		deliveryErrorResponse(c, err, "Gagal memperbarui status distribusi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Status distribusi berhasil diperbarui menjadi " + input.Status,
+	})
+}
+
+// ConfirmDelivery godoc
+// @Summary Confirm aid delivery
+// @Description Konfirmasi penerimaan bantuan oleh penerima menggunakan kode konfirmasi, jumlah yang diterima, alasan selisih (wajib jika jumlah berbeda), serta lampiran tanda tangan dan/atau foto (gambar maksimal 5MB)
+// @Tags DistributionLog
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Distribution Log ID"
+// @Param confirmation_code formData string true "Kode konfirmasi penerima"
+// @Param quantity_received formData int true "Jumlah yang diterima"
+// @Param discrepancy_reason formData string false "Alasan selisih jumlah"
+// @Param signature formData file false "Foto tanda tangan penerima"
+// @Param photo formData file false "Foto serah terima"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /distribution_logs/{id}/confirm [post]
+func ConfirmDelivery(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.DeliveryConfirmationInput
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	var proofs []structs.DistributionProof
+	for _, field := range []string{"signature", "photo"} {
+		proof, ok := readDeliveryProof(c, field)
+		if !ok {
+			return
+		}
+		if proof != nil {
+			proofs = append(proofs, *proof)
+		}
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.ConfirmDelivery(database.DbConnection, id, input, proofs, currentUser.ID)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		deliveryErrorResponse(c, err, "Gagal mengonfirmasi penerimaan bantuan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Penerimaan bantuan berhasil dikonfirmasi",
+	})
+}
+
+// GetDistributionDelivery godoc
+// @Summary Get distribution delivery detail
+// @Description Mendapatkan status pengiriman distribusi beserta riwayat perubahan status dan daftar bukti serah terima
+// @Tags DistributionLog
+// @Accept json
+// @Produce json
+// @Param id path int true "Distribution Log ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /distribution_logs/{id}/delivery [get]
+func GetDistributionDelivery(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	delivery, err := repository.GetDistributionDelivery(database.DbConnection, id)
+	if err != nil {
+		deliveryErrorResponse(c, err, "Gagal mendapatkan detail pengiriman distribusi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": delivery,
+	})
+}
+
+// GetDistributionProof godoc
+// @Summary Download proof of delivery
+// @Description Mengunduh file bukti serah terima (tanda tangan atau foto) sebuah distribusi
+// @Tags DistributionLog
+// @Produce octet-stream
+// @Param id path int true "Distribution Log ID"
+// @Param proof_id path int true "Proof ID"
+// @Success 200 {file} file
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /distribution_logs/{id}/proofs/{proof_id} [get]
+func GetDistributionProof(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	proofID, err := strconv.Atoi(c.Param("proof_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID bukti tidak valid",
+		})
+		return
+	}
+
+	proof, err := repository.GetDistributionProof(database.DbConnection, id, proofID)
+	if err != nil {
+		deliveryErrorResponse(c, err, "Gagal mengunduh bukti serah terima")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="distribution-%d-%s-%d"`, id, proof.Kind, proof.ID))
+	c.Data(http.StatusOK, proof.ContentType, proof.Data)
+}
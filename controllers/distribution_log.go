@@ -14,13 +14,15 @@ import (
 
 // CreateDistributionLog godoc
 // @Summary Create a distribution log
-// @Description Mencatat distribusi bantuan
+// @Description Mencatat distribusi bantuan dan mengurangi stok logistik secara atomik. Distribusi yang melebihi stok tersedia ditolak. Status awal boleh planned atau dispatched (default), dan kode konfirmasi untuk penerima hanya ditampilkan pada respons ini
 // @Tags DistributionLog
 // @Accept json
 // @Produce json
 // @Param input body structs.DistributionLogInput true "Data distribusi bantuan"
 // @Success 201 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /distribution_logs [post]
@@ -42,6 +44,11 @@ func CreateDistributionLog(c *gin.Context) {
 		return
 	}
 
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
 	distributionLog := &structs.DistributionLog{
 		LogisticID:    input.LogisticID,
 		Origin:        input.Origin,
@@ -50,15 +57,14 @@ func CreateDistributionLog(c *gin.Context) {
 		SenderName:    input.SenderName,
 		RecipientName: input.RecipientName,
 		QuantitySent:  input.QuantitySent,
+		Status:        input.Status,
 		SentAt:        parsedSentAt,
 	}
 
-	err = repository.CreateDistributionLog(database.DbConnection, distributionLog)
+	err = repository.CreateDistributionLog(database.DbConnection, distributionLog, currentUser.ID)
 	if err != nil {
 		fmt.Println("Error Query:", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal mencatat distribusi bantuan",
-		})
+		deliveryErrorResponse(c, err, "Gagal mencatat distribusi bantuan")
 		return
 	}
 
@@ -67,14 +73,17 @@ func CreateDistributionLog(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Distribusi bantuan berhasil dicatat",
 		"result": gin.H{
-			"logistic_id":    distributionLog.LogisticID,
-			"origin":         distributionLog.Origin,
-			"destination":    distributionLog.Destination,
-			"distance":       distributionLog.Distance,
-			"sender_name":    distributionLog.SenderName,
-			"recipient_name": distributionLog.RecipientName,
-			"quantity_sent":  distributionLog.QuantitySent,
-			"sent_at":        responseSentAt,
+			"id":                distributionLog.ID,
+			"logistic_id":       distributionLog.LogisticID,
+			"origin":            distributionLog.Origin,
+			"destination":       distributionLog.Destination,
+			"distance":          distributionLog.Distance,
+			"sender_name":       distributionLog.SenderName,
+			"recipient_name":    distributionLog.RecipientName,
+			"quantity_sent":     distributionLog.QuantitySent,
+			"status":            distributionLog.Status,
+			"confirmation_code": distributionLog.ConfirmationCode,
+			"sent_at":           responseSentAt,
 		},
 	})
 }
@@ -155,6 +164,8 @@ func GetDistributionLogByID(c *gin.Context) {
 // @Param input body structs.DistributionLogInput true "Data distribusi bantuan"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /distribution_logs/{id} [put]
@@ -202,18 +213,14 @@ func UpdateDistributionLog(c *gin.Context) {
 		distributionLog.SentAt = parsedSentAt
 	}
 
-	err = repository.UpdateDistributionLog(database.DbConnection, *distributionLog)
-	if err != nil {
-		if err.Error() == "distribution log not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Log distribusi tidak ditemukan",
-			})
-			return
-		}
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal mengupdate distribusi bantuan",
-		})
+	err = repository.UpdateDistributionLog(database.DbConnection, *distributionLog, currentUser.ID)
+	if err != nil {
+		deliveryErrorResponse(c, err, "Gagal mengupdate distribusi bantuan")
 		return
 	}
 
@@ -224,13 +231,15 @@ func UpdateDistributionLog(c *gin.Context) {
 
 // DeleteDistributionLog godoc
 // @Summary Delete a distribution log
-// @Description Menghapus distribusi bantuan
+// @Description Menghapus distribusi bantuan berstatus planned atau dispatched yang belum tercatat di buku stok. Distribusi yang sudah mengurangi stok dibatalkan lewat status failed
 // @Tags DistributionLog
 // @Accept json
 // @Produce json
 // @Param id path int true "Distribution Log ID"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /distribution_logs/{id} [delete]
@@ -245,6 +254,25 @@ func DeleteDistributionLog(c *gin.Context) {
 
 	err = repository.DeleteDistributionLog(database.DbConnection, id)
 	if err != nil {
+		if err.Error() == "distribution log not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Log distribusi tidak ditemukan",
+			})
+			return
+		}
+		if err.Error() == "distribution locked" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Distribusi yang sudah dalam perjalanan atau diterima tidak dapat dihapus",
+			})
+			return
+		}
+		if err.Error() == "distribution has stock movements" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Distribusi sudah tercatat di buku stok dan tidak bisa dihapus, batalkan dengan mengubah status menjadi failed",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal menghapus distribusi bantuan",
 		})
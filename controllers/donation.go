@@ -11,15 +11,76 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+func donationErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "invalid donation status":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status donasi tidak valid, hanya bisa 'pending', 'confirmed', atau 'rejected'"})
+	case "donation not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Donasi tidak ditemukan"})
+	case "donation already confirmed":
+		c.JSON(http.StatusConflict, gin.H{"error": "Donasi yang sudah dikonfirmasi tercatat di buku besar publik dan tidak dapat diubah lagi"})
+	case "donation disaster required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Donasi barang harus terhubung ke bencana sebelum dikonfirmasi"})
+	case "invalid donation quantity":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jumlah donasi barang (amount) harus bilangan bulat positif"})
+	case "donation not received":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Donasi ini belum diterima sebagai stok logistik"})
+	case "donation confirmation requires payment":
+		c.JSON(http.StatusForbidden, gin.H{"error": "Donasi uang hanya dapat dikonfirmasi otomatis setelah pembayaran diverifikasi payment gateway"})
+	case "donation kind locked":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jenis donasi tidak dapat diubah antara donasi uang dan donasi barang"})
+	case "donation payment in progress":
+		c.JSON(http.StatusConflict, gin.H{"error": "Donasi sedang dalam proses pembayaran, nominal dan status tidak dapat diubah"})
+	case "donation not monetary":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Pembayaran hanya berlaku untuk donasi uang"})
+	case "donation not pending":
+		c.JSON(http.StatusConflict, gin.H{"error": "Donasi tidak lagi menunggu pembayaran"})
+	case "invalid donation amount":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nominal donasi harus lebih dari 0"})
+	case "payment not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tagihan pembayaran tidak ditemukan"})
+	case "invalid currency":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Kode mata uang tidak valid, gunakan kode ISO 4217 seperti IDR atau USD"})
+	case "exchange rate not found":
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Kurs mata uang ini terhadap mata uang laporan belum tersedia"})
+	case "disaster not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bencana tidak ditemukan"})
+	case "donation recorded in ledger":
+		c.JSON(http.StatusConflict, gin.H{"error": "Donasi yang sudah dikonfirmasi tercatat di buku besar publik dan tidak dapat dihapus"})
+	case "payment provider not configured":
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Payment gateway belum dikonfigurasi"})
+	default:
+		stockErrorResponse(c, err, fallback)
+	}
+}
+
+// Penerimaan donasi barang menambah stok, jadi hanya admin dan relawan yang boleh mengonfirmasinya
+func canReceiveDonation(c *gin.Context, user structs.User) bool {
+	if user.Role == "admin" {
+		return true
+	}
+
+	isVolunteer, err := repository.IsUserVolunteer(database.DbConnection, user.ID)
+	if err != nil || !isVolunteer {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Akses ditolak, hanya admin dan relawan yang bisa mengonfirmasi penerimaan donasi barang",
+		})
+		return false
+	}
+	return true
+}
+
 // CreateDonation godoc
 // @Summary Create a donation
-// @Description Mencatat donasi
+// @Description Mencatat donasi. Donasi uang memakai currency ISO 4217 (default IDR) dan dikonversi ke mata uang laporan dengan kurs tersimpan. Donasi barang (item_name atau item_id) memakai amount sebagai jumlah barang dalam satuan unit, dan saat dikonfirmasi langsung menambah stok logistik bencana dalam batch DONASI-<id>
 // @Tags Donation
 // @Accept json
 // @Produce json
 // @Param input body structs.DonationInput true "Data donasi"
 // @Success 201 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /donations [post]
@@ -32,27 +93,36 @@ func CreateDonation(c *gin.Context) {
 		return
 	}
 
+	expiryDate, ok := parseExpiryDate(c, input.ExpiryDate)
+	if !ok {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
 	donation := &structs.Donation{
 		DonorID:    input.DonorID,
 		DisasterID: input.DisasterID,
 		Amount:     input.Amount,
+		Currency:   input.Currency,
 		ItemName:   input.ItemName,
+		ItemID:     input.ItemID,
+		Unit:       input.Unit,
+		ExpiryDate: expiryDate,
 		Status:     input.Status,
 	}
 
-	err := repository.CreateDonation(database.DbConnection, donation)
+	if donation.Status == "confirmed" && repository.IsInKindDonation(*donation) && !canReceiveDonation(c, currentUser) {
+		return
+	}
+
+	err := repository.CreateDonation(database.DbConnection, donation, currentUser.ID)
 	if err != nil {
 		fmt.Println("Error Query:", err)
-		if err.Error() == "invalid donation status" {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Status donasi tidak valid, hanya bisa 'pending', 'confirmed', atau 'rejected'",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal mencatat donasi",
-		})
+		donationErrorResponse(c, err, "Gagal mencatat donasi")
 		return
 	}
 
@@ -123,7 +193,7 @@ func GetDonationByID(c *gin.Context) {
 
 // UpdateDonation godoc
 // @Summary Update a donation
-// @Description Memperbarui donasi
+// @Description Memperbarui donasi. Mengubah status donasi barang menjadi confirmed (khusus admin dan relawan) menambah stok logistik bencana, setelah itu donasi tidak dapat diubah lagi
 // @Tags Donation
 // @Accept json
 // @Produce json
@@ -131,6 +201,9 @@ func GetDonationByID(c *gin.Context) {
 // @Param input body structs.DonationInput true "Data donasi"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /donations/{id} [put]
@@ -151,27 +224,44 @@ func UpdateDonation(c *gin.Context) {
 		return
 	}
 	
+	expiryDate, ok := parseExpiryDate(c, input.ExpiryDate)
+	if !ok {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
 	donation := structs.Donation{
 		ID:         id,
 		DonorID:    input.DonorID,
 		DisasterID: input.DisasterID,
 		Amount:     input.Amount,
+		Currency:   input.Currency,
 		ItemName:   input.ItemName,
+		ItemID:     input.ItemID,
+		Unit:       input.Unit,
+		ExpiryDate: expiryDate,
 		Status:     input.Status,
 	}
 
-	err = repository.UpdateDonation(database.DbConnection, donation)
-	if err != nil {
-		if err.Error() == "invalid donation status" {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Status donasi tidak valid, hanya bisa 'pending', 'confirmed', atau 'rejected'",
-			})
+	if donation.Status == "confirmed" {
+		existing, err := repository.GetDonationByID(database.DbConnection, id)
+		if err != nil {
+			donationErrorResponse(c, err, "Gagal mengupdate donasi")
+			return
+		}
+		if (repository.IsInKindDonation(existing) || repository.IsInKindDonation(donation)) && !canReceiveDonation(c, currentUser) {
 			return
 		}
+	}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal mengupdate donasi",
-		})
+	err = repository.UpdateDonation(database.DbConnection, donation, currentUser.ID)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		donationErrorResponse(c, err, "Gagal mengupdate donasi")
 		return
 	}
 
@@ -182,13 +272,15 @@ func UpdateDonation(c *gin.Context) {
 
 // DeleteDonation godoc
 // @Summary Delete a donation
-// @Description Menghapus donasi
+// @Description Menghapus donasi. Donasi yang sudah dikonfirmasi tercatat di buku besar publik dan tidak dapat dihapus
 // @Tags Donation
 // @Accept json
 // @Produce json
 // @Param id path int true "Donation ID"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /donations/{id} [delete]
@@ -203,9 +295,7 @@ func DeleteDonation(c *gin.Context) {
 
 	err = repository.DeleteDonation(database.DbConnection, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal menghapus donasi",
-		})
+		donationErrorResponse(c, err, "Gagal menghapus donasi")
 		return
 	}
 
@@ -213,3 +303,36 @@ func DeleteDonation(c *gin.Context) {
 		"message": "Donasi berhasil dihapus",
 	})
 }
+
+// GetDonationTrace godoc
+// @Summary Trace donated goods
+// @Description Menelusuri donasi barang yang sudah diterima: stok logistik tujuan, jumlah diterima, sisa stok dari batch donasi, dan log distribusi yang memakai barang donasi tersebut
+// @Tags Donation
+// @Accept json
+// @Produce json
+// @Param id path int true "Donation ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /donations/{id}/trace [get]
+func GetDonationTrace(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	trace, err := repository.GetDonationTrace(database.DbConnection, id)
+	if err != nil {
+		donationErrorResponse(c, err, "Gagal menelusuri donasi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": trace,
+	})
+}
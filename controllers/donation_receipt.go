@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type pdfText struct {
+	x, y float64
+	size float64
+	bold bool
+	text string
+}
+
+func receiptOrganization() (string, string, string) {
+	name := os.Getenv("RECEIPT_ORG_NAME")
+	if name == "" {
+		name = "RescueHub"
+	}
+	return name, os.Getenv("RECEIPT_ORG_ADDRESS"), os.Getenv("RECEIPT_ORG_CONTACT")
+}
+
+func formatMoney(amount float64, currency string) string {
+	cents := int64(math.Round(amount * 100))
+	whole := strconv.FormatInt(cents/100, 10)
+
+	var grouped []string
+	for len(whole) > 3 {
+		grouped = append([]string{whole[len(whole)-3:]}, grouped...)
+		whole = whole[:len(whole)-3]
+	}
+	grouped = append([]string{whole}, grouped...)
+	symbol := currency
+	if currency == "" || currency == "IDR" {
+		symbol = "Rp"
+	}
+	return fmt.Sprintf("%s %s,%02d", symbol, strings.Join(grouped, "."), cents%100)
+}
+
+func maskDonorName(name string) string {
+	var words []string
+	for _, word := range strings.Fields(name) {
+		runes := []rune(word)
+		words = append(words, string(runes[0])+strings.Repeat("*", len(runes)-1))
+	}
+	return strings.Join(words, " ")
+}
+
+// Teks PDF memakai WinAnsiEncoding, karakter di luar Latin-1 diganti tanda tanya
+func pdfString(text string) string {
+	var buffer bytes.Buffer
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buffer.WriteByte('\\')
+			buffer.WriteByte(byte(r))
+		case r < 32:
+			buffer.WriteByte(' ')
+		case r < 256:
+			buffer.WriteByte(byte(r))
+		default:
+			buffer.WriteByte('?')
+		}
+	}
+	return buffer.String()
+}
+
+func renderPDF(texts []pdfText, rules []float64) []byte {
+	var content bytes.Buffer
+	for _, y := range rules {
+		fmt.Fprintf(&content, "0.5 w 50 %.2f m 545 %.2f l S\n", y, y)
+	}
+	for _, text := range texts {
+		font := "F1"
+		if text.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, text.size, text.x, text.y, pdfString(text.text))
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buffer.Len()
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buffer.Bytes()
+}
+
+func renderDonationReceipt(receipt structs.DonationReceipt) []byte {
+	orgName, orgAddress, orgContact := receiptOrganization()
+
+	texts := []pdfText{
+		{50, 790, 18, true, orgName},
+		{50, 772, 10, false, orgAddress},
+		{50, 758, 10, false, orgContact},
+		{50, 720, 16, true, "KUITANSI DONASI"},
+		{50, 702, 11, false, "Nomor: " + receipt.ReceiptNumber},
+	}
+
+	donationType, amount := "Uang", formatMoney(receipt.Amount, receipt.Currency)
+	if receipt.ItemName != "" {
+		donationType = "Barang"
+		amount = strings.TrimSpace(strconv.FormatFloat(receipt.Amount, 'f', -1, 64) + " " + receipt.Unit + " " + receipt.ItemName)
+	}
+	disasterName := receipt.DisasterName
+	if disasterName == "" {
+		disasterName = "-"
+	}
+
+	rows := [][2]string{
+		{"Diterima dari", receipt.DonorName},
+		{"Untuk bencana", disasterName},
+		{"Jenis donasi", donationType},
+		{"Jumlah", amount},
+		{"Tanggal donasi", receipt.DonatedAt.Format("02/01/2006")},
+		{"Tanggal terbit", receipt.IssuedAt.Format("02/01/2006 15:04")},
+	}
+	y := 660.0
+	for _, row := range rows {
+		texts = append(texts, pdfText{50, y, 11, false, row[0]}, pdfText{170, y, 11, true, ": " + row[1]})
+		y -= 22
+	}
+
+	texts = append(texts,
+		pdfText{50, y - 20, 11, false, "Kode verifikasi: " + receipt.VerificationCode},
+		pdfText{50, y - 36, 9, false, "Keaslian kuitansi dapat diperiksa melalui GET /api/receipts/verify/" + receipt.VerificationCode},
+		pdfText{50, 80, 9, false, "Terima kasih atas kepedulian Anda. Kuitansi ini dibuat secara elektronik dan sah tanpa tanda tangan."},
+	)
+
+	return renderPDF(texts, []float64{745, y - 4})
+}
+
+// GetDonationReceipt godoc
+// @Summary Download donation receipt
+// @Description Mengunduh kuitansi resmi (PDF) bernomor untuk donasi yang sudah dikonfirmasi, berisi data donatur, bencana, jumlah/barang, tanggal, data organisasi, dan kode verifikasi
+// @Tags Donation
+// @Produce application/pdf
+// @Param id path int true "Donation ID"
+// @Success 200 {file} file
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /donations/{id}/receipt [get]
+func GetDonationReceipt(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	receipt, err := repository.GetDonationReceipt(database.DbConnection, id)
+	if err != nil {
+		if err.Error() == "receipt not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Kuitansi belum tersedia, donasi belum dikonfirmasi",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal membuat kuitansi donasi",
+		})
+		return
+	}
+
+	if receipt.Status != "confirmed" {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Kuitansi tidak berlaku karena donasi tidak lagi berstatus confirmed",
+		})
+		return
+	}
+
+	fileName := strings.ReplaceAll(receipt.ReceiptNumber, "/", "-")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="kuitansi-%s.pdf"`, fileName))
+	c.Data(http.StatusOK, "application/pdf", renderDonationReceipt(receipt))
+}
+
+// VerifyDonationReceipt godoc
+// @Summary Verify a donation receipt
+// @Description Memeriksa keaslian kuitansi donasi berdasarkan kode verifikasi tanpa login. Nama donatur disamarkan
+// @Tags Donation
+// @Produce json
+// @Param code path string true "Kode verifikasi kuitansi"
+// @Success 200 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 429 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Router /receipts/verify/{code} [get]
+func VerifyDonationReceipt(c *gin.Context) {
+	receipt, err := repository.VerifyDonationReceipt(database.DbConnection, c.Param("code"))
+	if err != nil {
+		if err.Error() == "receipt not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Kode verifikasi kuitansi tidak dikenal",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal memverifikasi kuitansi",
+		})
+		return
+	}
+
+	orgName, _, _ := receiptOrganization()
+	c.JSON(http.StatusOK, gin.H{
+		"result": gin.H{
+			"valid":          receipt.Status == "confirmed",
+			"receipt_number": receipt.ReceiptNumber,
+			"organization":   orgName,
+			"donor_name":     maskDonorName(receipt.DonorName),
+			"disaster_name":  receipt.DisasterName,
+			"amount":         receipt.Amount,
+			"currency":       receipt.Currency,
+			"item_name":      receipt.ItemName,
+			"unit":           receipt.Unit,
+			"donated_at":     receipt.DonatedAt,
+			"issued_at":      receipt.IssuedAt,
+		},
+	})
+}
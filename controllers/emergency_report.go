@@ -32,14 +32,24 @@ func CreateEmergencyReport(c *gin.Context) {
 	}
 
 	emergencyReport := &structs.EmergencyReport{
-		UserID:      input.UserID,
-		DisasterID:  input.DisasterID,
-		Description: input.Description,
-		Location:    input.Location,
+		UserID:           input.UserID,
+		DisasterID:       input.DisasterID,
+		Description:      input.Description,
+		Location:         input.Location,
+		Latitude:         input.Latitude,
+		Longitude:        input.Longitude,
+		LocationAccuracy: input.LocationAccuracy,
 	}
 
 	err := repository.CreateEmergencyReport(database.DbConnection, emergencyReport)
 	if err != nil {
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mencatat laporan darurat",
 		})
@@ -58,13 +68,30 @@ func CreateEmergencyReport(c *gin.Context) {
 // @Tags EmergencyReport
 // @Accept json
 // @Produce json
+// @Param near query string false "Titik pusat pencarian dalam format lat,lon"
+// @Param radius_km query number false "Radius pencarian dalam kilometer (wajib bersama near)"
+// @Param bbox query string false "Bounding box dalam format min_lat,min_lon,max_lat,max_lon"
 // @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
 // @Failure 404 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /emergency_reports [get]
 func GetAllEmergencyReports(c *gin.Context) {
-	reports, err := repository.GetAllEmergencyReports(database.DbConnection)
+	filter, err := parseGeoFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parameter lokasi tidak valid, gunakan near=lat,lon&radius_km=... atau bbox=min_lat,min_lon,max_lat,max_lon",
+		})
+		return
+	}
+
+	var reports []structs.EmergencyReport
+	if filter != nil {
+		reports, err = repository.GetEmergencyReportsByArea(database.DbConnection, *filter)
+	} else {
+		reports, err = repository.GetAllEmergencyReports(database.DbConnection)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mendapatkan daftar laporan darurat",
@@ -72,7 +99,11 @@ func GetAllEmergencyReports(c *gin.Context) {
 		return
 	}
 
-	if len(reports) == 0 {
+	// Pencarian berdasarkan lokasi mengembalikan daftar kosong bila tidak ada laporan darurat di area tersebut
+	if filter != nil && reports == nil {
+		reports = []structs.EmergencyReport{}
+	}
+	if len(reports) == 0 && filter == nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Tidak ada daftar laporan darurat yang tersedia",
 		})
@@ -149,15 +180,24 @@ func UpdateEmergencyReport(c *gin.Context) {
 	}
 	
 	emergencyReport := structs.EmergencyReport{
-		ID:          id,
-		UserID:      input.UserID,
-		DisasterID:  input.DisasterID,
-		Description: input.Description,
-		Location:    input.Location,
+		ID:               id,
+		UserID:           input.UserID,
+		DisasterID:       input.DisasterID,
+		Description:      input.Description,
+		Location:         input.Location,
+		Latitude:         input.Latitude,
+		Longitude:        input.Longitude,
+		LocationAccuracy: input.LocationAccuracy,
 	}
 
 	err = repository.UpdateEmergencyReport(database.DbConnection, emergencyReport)
 	if err != nil {
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
 		if err.Error() == "emergency report not found" {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Laporan darurat tidak ditemukan",
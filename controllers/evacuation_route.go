@@ -4,6 +4,7 @@ import (
 	"RescueHub/database"
 	"RescueHub/repository"
 	"RescueHub/structs"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -11,6 +12,36 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+func parseRouteGeometry(input structs.EvacuationRouteInput) ([]structs.RouteWaypoint, error) {
+	if input.Geometry != nil && input.Polyline != "" {
+		return nil, errors.New("geometry and polyline are mutually exclusive")
+	}
+
+	if input.Polyline != "" {
+		return repository.DecodePolyline(input.Polyline)
+	}
+
+	if input.Geometry != nil {
+		if input.Geometry.Type != "LineString" {
+			return nil, errors.New("geometry must be a LineString")
+		}
+		waypoints := make([]structs.RouteWaypoint, 0, len(input.Geometry.Coordinates))
+		for i, coordinate := range input.Geometry.Coordinates {
+			if len(coordinate) < 2 {
+				return nil, errors.New("invalid coordinate")
+			}
+			waypoints = append(waypoints, structs.RouteWaypoint{
+				Sequence:  i,
+				Latitude:  coordinate[1],
+				Longitude: coordinate[0],
+			})
+		}
+		return waypoints, nil
+	}
+
+	return nil, nil
+}
+
 // CreateEvacuationRoute godoc
 // @Summary Create an evacuation route
 // @Description Mencatat jalur evakuasi
@@ -32,6 +63,14 @@ func CreateEvacuationRoute(c *gin.Context) {
 		return
 	}
 
+	waypoints, err := parseRouteGeometry(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Geometri jalur tidak valid, gunakan GeoJSON LineString atau encoded polyline (salah satu saja)",
+		})
+		return
+	}
+
 	evacuationRoute := &structs.EvacuationRoute{
 		DisasterID: 	input.DisasterID,
 		Origin:     	input.Origin,
@@ -39,12 +78,20 @@ func CreateEvacuationRoute(c *gin.Context) {
 		Distance:  		input.Distance,
 		Route:      	input.Route,
 		Status:     	input.Status,
+		Waypoints:   waypoints,
 	}	
 
-	err := repository.CreateEvacuationRoute(database.DbConnection, evacuationRoute)
+	err = repository.CreateEvacuationRoute(database.DbConnection, evacuationRoute)
 	if err != nil {
 		fmt.Println("Error Query:", err)
 
+		if err.Error() == "invalid route geometry" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Geometri jalur tidak valid, minimal 2 titik dengan koordinat yang valid",
+			})
+			return
+		}
+
 		if err.Error() == "invalid evacuation route status" {
 			c.JSON(http.StatusBadRequest, gin.H{
 					"error": "Status jalur evakuasi tidak valid, hanya bisa 'safe', 'risky', atau 'blocked'",
@@ -160,6 +207,14 @@ func UpdateEvacuationRoute(c *gin.Context) {
 		return
 	}
 
+	waypoints, err := parseRouteGeometry(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Geometri jalur tidak valid, gunakan GeoJSON LineString atau encoded polyline (salah satu saja)",
+		})
+		return
+	}
+
 	evacuationRoute := structs.EvacuationRoute{
 		ID:          id,
 		DisasterID:  input.DisasterID,
@@ -168,6 +223,7 @@ func UpdateEvacuationRoute(c *gin.Context) {
 		Distance:    input.Distance,
 		Route:       input.Route,
 		Status:      input.Status,
+		Waypoints:   waypoints,
 	}
 
 	err = repository.UpdateEvacuationRoute(database.DbConnection, evacuationRoute)
@@ -186,6 +242,13 @@ func UpdateEvacuationRoute(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "invalid route geometry" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Geometri jalur tidak valid, minimal 2 titik dengan koordinat yang valid",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mengupdate jalur evakuasi",
 		})
@@ -230,3 +293,113 @@ func DeleteEvacuationRoute(c *gin.Context) {
 		"message": "Jalur evakuasi berhasil dihapus",
 	})
 }
+
+// UpdateEvacuationRouteSegment godoc
+// @Summary Update an evacuation route segment status
+// @Description Memperbarui status satu segmen jalur evakuasi (misalnya jembatan putus) tanpa mengubah status jalur secara keseluruhan
+// @Tags EvacuationRoute
+// @Accept json
+// @Produce json
+// @Param id path int true "ID jalur evakuasi"
+// @Param sequence path int true "Urutan segmen (dimulai dari 0)"
+// @Param input body structs.RouteSegmentInput true "Status segmen"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /evacuation_routes/{id}/segments/{sequence} [put]
+func UpdateEvacuationRouteSegment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID tidak valid"})
+		return
+	}
+
+	sequence, err := strconv.Atoi(c.Param("sequence"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Urutan segmen tidak valid"})
+		return
+	}
+
+	var input structs.RouteSegmentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Input tidak valid"})
+		return
+	}
+
+	segment, err := repository.UpdateRouteSegment(database.DbConnection, id, sequence, input)
+	if err != nil {
+		if err.Error() == "invalid evacuation route status" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Status segmen tidak valid, hanya bisa 'safe', 'risky', atau 'blocked'"})
+			return
+		}
+		if err.Error() == "route segment not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Segmen jalur evakuasi tidak ditemukan"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal memperbarui segmen jalur evakuasi"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Segmen jalur evakuasi berhasil diperbarui",
+		"result":  segment,
+	})
+}
+
+// PlanEvacuationRoute godoc
+// @Summary Plan an evacuation path
+// @Description Menghitung jalur evakuasi terbaik dari suatu titik ke shelter terdekat yang masih memiliki kapasitas. Jalur 'blocked' diabaikan dan jalur 'risky' diberi penalti.
+// @Tags EvacuationRoute
+// @Produce json
+// @Param from query string true "Titik awal dalam format lat,lon atau nama lokasi asal jalur"
+// @Param disaster_id query int true "ID bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /evacuation_routes/plan [get]
+func PlanEvacuationRoute(c *gin.Context) {
+	disasterID, err := strconv.Atoi(c.Query("disaster_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID bencana tidak valid"})
+		return
+	}
+
+	from := c.Query("from")
+	if from == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'from' wajib diisi"})
+		return
+	}
+
+	var fromLatitude, fromLongitude *float64
+	if point, err := parseFloatList(from, 2); err == nil {
+		if point[0] < -90 || point[0] > 90 || point[1] < -180 || point[1] > 180 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Koordinat titik awal tidak valid"})
+			return
+		}
+		fromLatitude, fromLongitude = &point[0], &point[1]
+	}
+
+	plan, err := repository.PlanEvacuation(database.DbConnection, disasterID, from, fromLatitude, fromLongitude)
+	if err != nil {
+		switch err.Error() {
+		case "disaster not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Laporan bencana tidak ditemukan"})
+		case "no shelter available":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tidak ada shelter dengan kapasitas tersisa yang terhubung ke jalur evakuasi"})
+		case "start point not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Titik awal tidak ditemukan pada jaringan jalur evakuasi"})
+		case "no evacuation path":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tidak ada jalur evakuasi yang aman menuju shelter"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menghitung jalur evakuasi"})
+		}
+		return
+	}
+	plan.From = from
+
+	c.JSON(http.StatusOK, gin.H{"result": plan})
+}
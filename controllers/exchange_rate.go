@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetExchangeRates godoc
+// @Summary Get exchange rates
+// @Description Mendapatkan kurs mata uang terhadap mata uang laporan (REPORTING_CURRENCY, default IDR). Rate berarti 1 unit mata uang setara rate unit mata uang dasar
+// @Tags Donation
+// @Produce json
+// @Param base query string false "Mata uang dasar, default mata uang laporan"
+// @Success 200 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /exchange_rates [get]
+func GetExchangeRates(c *gin.Context) {
+	base := c.DefaultQuery("base", repository.ReportingCurrency())
+
+	rates, err := repository.GetExchangeRates(database.DbConnection, base)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar kurs",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reporting_currency": repository.ReportingCurrency(),
+		"result":             rates,
+	})
+}
+
+// UpdateExchangeRates godoc
+// @Summary Update exchange rates
+// @Description Memperbarui kurs mata uang (kode ISO 4217). Kurs dipakai untuk mengonversi donasi baru ke mata uang laporan, donasi lama tetap memakai kurs saat dicatat
+// @Tags Donation
+// @Accept json
+// @Produce json
+// @Param input body structs.ExchangeRateInput true "Kurs per mata uang, misal {\"rates\": {\"USD\": 16250}}"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /exchange_rates [put]
+func UpdateExchangeRates(c *gin.Context) {
+	var input structs.ExchangeRateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err := repository.UpsertExchangeRates(database.DbConnection, input.BaseCurrency, input.Rates, "admin", &currentUser.ID)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		switch err.Error() {
+		case "invalid currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Kode mata uang tidak valid, gunakan kode ISO 4217 seperti IDR atau USD"})
+		case "invalid exchange rate":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Kurs harus diisi dan bernilai lebih dari 0"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal memperbarui kurs"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Kurs berhasil diperbarui",
+	})
+}
+
+// GetDonationsByDisasterID godoc
+// @Summary Get donations by disaster ID
+// @Description Mendapatkan daftar donasi untuk bencana tertentu beserta total donasi uang terkonfirmasi per mata uang dan dalam mata uang laporan
+// @Tags Disaster
+// @Produce json
+// @Param id path int true "Disaster ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disasters/{id}/donations [get]
+func GetDonationsByDisasterID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	donations, err := repository.GetDonationsByDisasterID(database.DbConnection, id)
+	if err != nil {
+		donationErrorResponse(c, err, "Gagal mendapatkan daftar donasi bencana")
+		return
+	}
+
+	totals, err := repository.GetDonationTotalsByDisasterID(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal menghitung total donasi bencana",
+		})
+		return
+	}
+
+	if donations == nil {
+		donations = []structs.Donation{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": donations,
+		"totals": totals,
+	})
+}
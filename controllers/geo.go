@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"RescueHub/structs"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func parseFloatList(value string, expected int) ([]float64, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != expected {
+		return nil, errors.New("invalid coordinate list")
+	}
+
+	numbers := make([]float64, 0, expected)
+	for _, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+func parseGeoFilter(c *gin.Context) (*structs.GeoFilter, error) {
+	near := c.Query("near")
+	bbox := c.Query("bbox")
+	radius := c.Query("radius_km")
+
+	if near == "" && bbox == "" {
+		if radius != "" {
+			return nil, errors.New("radius_km requires near")
+		}
+		return nil, nil
+	}
+
+	filter := &structs.GeoFilter{}
+
+	if near != "" {
+		point, err := parseFloatList(near, 2)
+		if err != nil {
+			return nil, err
+		}
+		if point[0] < -90 || point[0] > 90 || point[1] < -180 || point[1] > 180 {
+			return nil, errors.New("invalid coordinates")
+		}
+		filter.Latitude = &point[0]
+		filter.Longitude = &point[1]
+	}
+
+	if radius != "" {
+		if near == "" {
+			return nil, errors.New("radius_km requires near")
+		}
+		radiusKm, err := strconv.ParseFloat(radius, 64)
+		if err != nil || radiusKm <= 0 {
+			return nil, errors.New("invalid radius")
+		}
+		filter.RadiusKm = radiusKm
+	}
+
+	if bbox != "" {
+		box, err := parseFloatList(bbox, 4)
+		if err != nil {
+			return nil, err
+		}
+		if box[0] > box[2] || box[1] > box[3] {
+			return nil, errors.New("invalid bounding box")
+		}
+		filter.BoundingBox = &structs.BoundingBox{
+			MinLatitude:  box[0],
+			MinLongitude: box[1],
+			MaxLatitude:  box[2],
+			MaxLongitude: box[3],
+		}
+	}
+
+	return filter, nil
+}
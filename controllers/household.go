@@ -0,0 +1,417 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateHousehold godoc
+// @Summary Create a household
+// @Description Membuat data keluarga pengungsi, kepala keluarga otomatis menjadi anggota
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Param input body structs.HouseholdInput true "Data keluarga"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households [post]
+func CreateHousehold(c *gin.Context) {
+	var input structs.HouseholdInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, nama keluarga wajib diisi",
+		})
+		return
+	}
+
+	household := &structs.Household{
+		Name:          input.Name,
+		DisasterID:    input.DisasterID,
+		HeadRefugeeID: input.HeadRefugeeID,
+	}
+
+	err := repository.CreateHousehold(database.DbConnection, household)
+	if err != nil {
+		if err.Error() == "refugee not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Kepala keluarga tidak ditemukan di data pengungsi",
+			})
+			return
+		}
+		if err.Error() == "refugee already in household" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Pengungsi sudah terdaftar di keluarga lain",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal membuat data keluarga",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Data keluarga berhasil dibuat",
+		"result":  household,
+	})
+}
+
+// GetAllHouseholds godoc
+// @Summary Get all households
+// @Description Mendapatkan daftar keluarga beserta anggota, kebutuhan, dan ukuran jatah
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Success 200 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households [get]
+func GetAllHouseholds(c *gin.Context) {
+	households, err := repository.GetAllHouseholds(database.DbConnection)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar keluarga",
+		})
+		return
+	}
+
+	if len(households) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Tidak ada daftar keluarga yang tersedia",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": households,
+	})
+}
+
+// GetHouseholdByID godoc
+// @Summary Get household by ID
+// @Description Mendapatkan detail keluarga beserta anggota, kebutuhan, dan ukuran jatah
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households/{id} [get]
+func GetHouseholdByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	household, err := repository.GetHouseholdByID(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Data keluarga tidak ditemukan",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": household,
+	})
+}
+
+// UpdateHousehold godoc
+// @Summary Update household
+// @Description Memperbarui nama, bencana, atau kepala keluarga
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param input body structs.HouseholdInput true "Data keluarga"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households/{id} [put]
+func UpdateHousehold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.HouseholdInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	household := structs.Household{
+		ID:            id,
+		Name:          input.Name,
+		DisasterID:    input.DisasterID,
+		HeadRefugeeID: input.HeadRefugeeID,
+	}
+
+	err = repository.UpdateHousehold(database.DbConnection, household)
+	if err != nil {
+		switch err.Error() {
+		case "household not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Data keluarga tidak ditemukan"})
+		case "refugee not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Kepala keluarga tidak ditemukan di data pengungsi"})
+		case "head is not a household member":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Kepala keluarga harus merupakan anggota keluarga ini"})
+		case "tidak ada field yang dapat diperbarui":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal mengupdate data keluarga"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Data keluarga berhasil diperbarui",
+	})
+}
+
+// DeleteHousehold godoc
+// @Summary Delete household
+// @Description Menghapus data keluarga, anggota tetap tercatat sebagai pengungsi
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households/{id} [delete]
+func DeleteHousehold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	err = repository.DeleteHousehold(database.DbConnection, id)
+	if err != nil {
+		if err.Error() == "household not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Data keluarga tidak ditemukan",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal menghapus data keluarga",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Data keluarga berhasil dihapus",
+	})
+}
+
+// AddHouseholdMember godoc
+// @Summary Add a household member
+// @Description Menambahkan pengungsi ke keluarga, pengungsi dipindahkan ke shelter keluarga bila berbeda
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param input body structs.HouseholdMemberInput true "Anggota keluarga"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households/{id}/members [post]
+func AddHouseholdMember(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.HouseholdMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, refugee_id wajib diisi",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.AddHouseholdMember(database.DbConnection, id, input, currentUser.ID, false)
+	if err != nil {
+		switch err.Error() {
+		case "household not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Data keluarga tidak ditemukan"})
+		case "refugee not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Data pengungsi tidak ditemukan"})
+		case "refugee already in household":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Pengungsi sudah terdaftar di keluarga lain"})
+		case "shelter over capacity":
+			c.JSON(http.StatusConflict, gin.H{"error": "Kapasitas shelter keluarga sudah penuh, anggota tidak dapat ditambahkan"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menambahkan anggota keluarga"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Anggota keluarga berhasil ditambahkan",
+	})
+}
+
+// RemoveHouseholdMember godoc
+// @Summary Remove a household member
+// @Description Mengeluarkan pengungsi dari keluarga
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param refugee_id path int true "Refugee ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households/{id}/members/{refugee_id} [delete]
+func RemoveHouseholdMember(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	refugeeID, err := strconv.Atoi(c.Param("refugee_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID pengungsi tidak valid",
+		})
+		return
+	}
+
+	err = repository.RemoveHouseholdMember(database.DbConnection, id, refugeeID)
+	if err != nil {
+		if err.Error() == "household member not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Pengungsi bukan anggota keluarga ini",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mengeluarkan anggota keluarga",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Anggota keluarga berhasil dikeluarkan",
+	})
+}
+
+// AssignHouseholdShelter godoc
+// @Summary Assign household to a shelter
+// @Description Menempatkan seluruh anggota keluarga ke satu shelter secara bersamaan
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param input body structs.HouseholdShelterInput true "Shelter tujuan"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /households/{id}/shelter [put]
+func AssignHouseholdShelter(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.HouseholdShelterInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, shelter_id wajib diisi",
+		})
+		return
+	}
+
+	if !isCapacityOverrideAllowed(c, input.OverrideCapacity) {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.AssignHouseholdShelter(database.DbConnection, id, input, currentUser.ID, input.OverrideCapacity)
+	if err != nil {
+		switch err.Error() {
+		case "household not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Data keluarga tidak ditemukan"})
+		case "shelter not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shelter tidak ditemukan"})
+		case "household has no members":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Keluarga belum memiliki anggota"})
+		case "shelter over capacity":
+			c.JSON(http.StatusConflict, gin.H{"error": "Kapasitas shelter tidak mencukupi untuk seluruh anggota keluarga"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menempatkan keluarga ke shelter"})
+		}
+		return
+	}
+
+	household, err := repository.GetHouseholdByID(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan data keluarga",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Keluarga berhasil ditempatkan di shelter",
+		"result":  household,
+	})
+}
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func itemErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "invalid item":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama barang dan satuan dasar wajib diisi"})
+	case "invalid item unit":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Satuan konversi tidak valid, nama satuan harus unik dan faktor harus lebih dari 0"})
+	case "item already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": "Barang dengan nama tersebut sudah ada di katalog"})
+	case "item not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Barang tidak ditemukan di katalog"})
+	case "item units required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Satuan konversi (units) wajib diisi ulang saat satuan dasar diubah"})
+	case "item in use":
+		c.JSON(http.StatusConflict, gin.H{"error": "Satuan dasar tidak bisa diubah karena barang sudah dipakai pada stok logistik, permintaan kebutuhan, atau donasi"})
+	case "tidak ada field yang dapat diperbarui":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tidak ada field yang dapat diperbarui"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// CreateItem godoc
+// @Summary Create a catalog item
+// @Description Menambahkan barang ke katalog logistik beserta satuan dasar dan konversi satuan lainnya
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Param input body structs.ItemInput true "Data barang"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /items [post]
+func CreateItem(c *gin.Context) {
+	var input structs.ItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	item := &structs.Item{
+		Name:        input.Name,
+		Category:    input.Category,
+		BaseUnit:    input.BaseUnit,
+		Description: input.Description,
+		Units:       input.Units,
+	}
+
+	err := repository.CreateItem(database.DbConnection, item)
+	if err != nil {
+		itemErrorResponse(c, err, "Gagal menambahkan barang ke katalog")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Barang berhasil ditambahkan ke katalog",
+		"result":  item,
+	})
+}
+
+// GetAllItems godoc
+// @Summary Get all catalog items
+// @Description Mendapatkan daftar barang di katalog logistik
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Success 200 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /items [get]
+func GetAllItems(c *gin.Context) {
+	items, err := repository.GetAllItems(database.DbConnection)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan katalog barang",
+		})
+		return
+	}
+
+	if len(items) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Tidak ada barang di katalog",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": items,
+	})
+}
+
+// GetItemByID godoc
+// @Summary Get catalog item by ID
+// @Description Mendapatkan detail barang di katalog logistik berdasarkan ID
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Param id path int true "Item ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /items/{id} [get]
+func GetItemByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	item, err := repository.GetItemByID(database.DbConnection, id)
+	if err != nil {
+		itemErrorResponse(c, err, "Gagal mendapatkan detail barang")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": item,
+	})
+}
+
+// UpdateItem godoc
+// @Summary Update catalog item
+// @Description Memperbarui barang di katalog logistik. Jika units diisi, seluruh konversi satuan akan diganti
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Param id path int true "Item ID"
+// @Param input body structs.ItemInput true "Data barang"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /items/{id} [put]
+func UpdateItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.ItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	err = repository.UpdateItem(database.DbConnection, id, input)
+	if err != nil {
+		itemErrorResponse(c, err, "Gagal memperbarui barang")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Barang berhasil diperbarui",
+	})
+}
+
+// DeleteItem godoc
+// @Summary Delete catalog item
+// @Description Menghapus barang dari katalog logistik
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Param id path int true "Item ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /items/{id} [delete]
+func DeleteItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	err = repository.DeleteItem(database.DbConnection, id)
+	if err != nil {
+		itemErrorResponse(c, err, "Gagal menghapus barang")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Barang berhasil dihapus dari katalog",
+	})
+}
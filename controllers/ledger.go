@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateDisbursement godoc
+// @Summary Record a disbursement
+// @Description Mencatat pengeluaran dana donasi. Setiap pengeluaran langsung ditambahkan ke buku besar publik dan tidak dapat diubah atau dihapus
+// @Tags Ledger
+// @Accept json
+// @Produce json
+// @Param input body structs.DisbursementInput true "Data pengeluaran"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disbursements [post]
+func CreateDisbursement(c *gin.Context) {
+	var input structs.DisbursementInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	disbursement := structs.Disbursement{
+		DisasterID:  input.DisasterID,
+		Amount:      input.Amount,
+		Currency:    input.Currency,
+		Recipient:   input.Recipient,
+		Purpose:     input.Purpose,
+		DisbursedBy: &currentUser.ID,
+	}
+
+	err := repository.CreateDisbursement(database.DbConnection, &disbursement)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		donationErrorResponse(c, err, "Gagal mencatat pengeluaran")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Pengeluaran berhasil dicatat di buku besar",
+		"result":  disbursement,
+	})
+}
+
+// GetAllDisbursements godoc
+// @Summary Get all disbursements
+// @Description Mendapatkan semua pengeluaran dana donasi beserta penerimanya
+// @Tags Ledger
+// @Produce json
+// @Success 200 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disbursements [get]
+func GetAllDisbursements(c *gin.Context) {
+	disbursements, err := repository.GetAllDisbursements(database.DbConnection)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar pengeluaran",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": disbursements,
+	})
+}
+
+// GetLedger godoc
+// @Summary Get public donation ledger
+// @Description Buku besar publik berisi donasi terkonfirmasi dan pengeluaran tanpa data donatur maupun penerima. Setiap entri memuat hash entri sebelumnya sehingga rantai dapat diperiksa ulang oleh siapa pun
+// @Tags Ledger
+// @Produce json
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 429 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Router /ledger [get]
+func GetLedger(c *gin.Context) {
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	entries, err := repository.GetLedgerEntries(database.DbConnection, disasterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan buku besar",
+		})
+		return
+	}
+
+	headSequence, headHash, err := repository.GetLedgerHead(database.DbConnection)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan buku besar",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"head_sequence": headSequence,
+		"head_hash":     headHash,
+		"result":        entries,
+	})
+}
+
+// VerifyLedger godoc
+// @Summary Verify the donation ledger
+// @Description Menghitung ulang rantai hash buku besar dan mencocokkannya dengan data donasi dan pengeluaran. Masalah yang dilaporkan: altered (isi entri diubah), broken_link atau sequence_gap (entri dihapus atau disisipkan), source_mismatch (data sumber berubah), dan missing_entry (entri tidak ada)
+// @Tags Ledger
+// @Produce json
+// @Success 200 {object} structs.APIResponse
+// @Failure 429 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Router /ledger/verify [get]
+func VerifyLedger(c *gin.Context) {
+	verification, err := repository.VerifyLedger(database.DbConnection)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal memverifikasi buku besar",
+		})
+		return
+	}
+
+	message := "Buku besar utuh, tidak ada entri yang diubah atau dihapus"
+	if !verification.Valid {
+		message = "Buku besar tidak utuh, ditemukan entri yang diubah atau dihapus"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"result":  verification,
+	})
+}
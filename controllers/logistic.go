@@ -31,25 +31,31 @@ func CreateLogistic(c *gin.Context) {
 		return
 	}
 
+	expiryDate, ok := parseExpiryDate(c, input.ExpiryDate)
+	if !ok {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
 	logistic := &structs.Logistic{
 		Type:       input.Type,
 		Quantity:   input.Quantity,
-		Status:     input.Status,
 		DisasterID: input.DisasterID,
+		ItemID:     input.ItemID,
+		Unit:       input.Unit,
+		Location:   input.Location,
+		Latitude:   input.Latitude,
+		Longitude:  input.Longitude,
 	}
 
-	err := repository.CreateLogistic(database.DbConnection, logistic)
+	batch := structs.StockBatchInput{BatchNumber: input.BatchNumber, ExpiryDate: expiryDate}
+	err := repository.CreateLogistic(database.DbConnection, logistic, batch, currentUser.ID)
 	if err != nil {
-		if err.Error() == "invalid logistics status" {
-			c.JSON(http.StatusBadRequest, gin.H{
-					"error": "Status logistik tidak valid, hanya bisa 'available', 'distributed', atau 'out_of_stock'",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal mencatat bantuan logistik",
-		})
+		stockErrorResponse(c, err, "Gagal mencatat bantuan logistik")
 		return
 	}
 
@@ -127,7 +133,7 @@ func GetLogisticByID(c *gin.Context) {
 
 // UpdateLogistic godoc
 // @Summary Update logistic
-// @Description Memperbarui bantuan logistik
+// @Description Memperbarui bantuan logistik. Status logistik dihitung dari saldo stok dan tidak bisa diisi
 // @Tags Logistic
 // @Accept json
 // @Produce json
@@ -135,6 +141,8 @@ func GetLogisticByID(c *gin.Context) {
 // @Param input body structs.LogisticInput true "Data bantuan logistik"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /logistics/{id} [put]
@@ -156,7 +164,12 @@ func UpdateLogistic(c *gin.Context) {
 	}
 	input.ID = id
 
-	err = repository.UpdateLogistic(database.DbConnection, input)
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.UpdateLogistic(database.DbConnection, input, currentUser.ID)
 	if err != nil {
 		if err.Error() == "logistics not found" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -165,16 +178,14 @@ func UpdateLogistic(c *gin.Context) {
 			return
 		}
 		
-		if err.Error() == "invalid logistics status" {
+		if err.Error() == "logistics status derived" {
 			c.JSON(http.StatusBadRequest, gin.H{
-					"error": "Status logistik tidak valid, hanya bisa 'available', 'distributed', atau 'out_of_stock'",
+				"error": "Status logistik dihitung otomatis dari stok dan tidak bisa diubah langsung, catat pergerakan stok untuk mengubahnya",
 			})
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal mengupdate bantuan logistik",
-		})
+		stockErrorResponse(c, err, "Gagal mengupdate bantuan logistik")
 		return
 	}
 
@@ -192,6 +203,8 @@ func UpdateLogistic(c *gin.Context) {
 // @Param id path int true "Logistic ID"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /logistics/{id} [delete]
@@ -206,9 +219,7 @@ func DeleteLogistic(c *gin.Context) {
 
 	err = repository.DeleteLogistic(database.DbConnection, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Gagal menghapus bantuan logistik",
-		})
+		stockErrorResponse(c, err, "Gagal menghapus bantuan logistik")
 		return
 	}
 
@@ -0,0 +1,387 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var routeStatusColors = map[string]string{
+	"safe":    "#2ecc71",
+	"risky":   "#f39c12",
+	"blocked": "#e74c3c",
+}
+
+var triageColors = map[string]string{
+	"immediate": "#c0392b",
+	"delayed":   "#f1c40f",
+	"minor":     "#27ae60",
+	"deceased":  "#2c3e50",
+}
+
+func shelterOccupancyColor(percentage float64) string {
+	switch {
+	case percentage >= 100:
+		return "#e74c3c"
+	case percentage >= 80:
+		return "#f39c12"
+	default:
+		return "#2ecc71"
+	}
+}
+
+func pointGeometry(latitude, longitude *float64) *structs.GeoJSONGeometry {
+	if latitude == nil || longitude == nil {
+		return nil
+	}
+	return &structs.GeoJSONGeometry{
+		Type:        "Point",
+		Coordinates: []float64{*longitude, *latitude},
+	}
+}
+
+func waypointCoordinates(waypoints []structs.RouteWaypoint) [][]float64 {
+	coordinates := make([][]float64, 0, len(waypoints))
+	for _, waypoint := range waypoints {
+		coordinates = append(coordinates, []float64{waypoint.Longitude, waypoint.Latitude})
+	}
+	return coordinates
+}
+
+func buildDisasterMap(disasterID int) (structs.GeoJSONFeatureCollection, error) {
+	collection := structs.GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []structs.GeoJSONFeature{},
+	}
+
+	disaster, err := repository.GetDisasterByID(database.DbConnection, disasterID)
+	if err != nil {
+		return collection, err
+	}
+	collection.Name = fmt.Sprintf("RescueHub - %s (%s)", disaster.Type, disaster.Location)
+
+	shelters, err := repository.GetSheltersByDisasterID(database.DbConnection, disasterID)
+	if err != nil {
+		return collection, err
+	}
+
+	sheltersByName := make(map[string]structs.Shelter)
+	for _, shelter := range shelters {
+		sheltersByName[strings.ToLower(strings.TrimSpace(shelter.Name))] = shelter
+
+		collection.Features = append(collection.Features, structs.GeoJSONFeature{
+			Type:     "Feature",
+			ID:       "shelter-" + strconv.Itoa(shelter.ID),
+			Geometry: pointGeometry(shelter.Latitude, shelter.Longitude),
+			Properties: map[string]interface{}{
+				"layer":                "shelters",
+				"title":                shelter.Name,
+				"location":             shelter.Location,
+				"capacity_total":       shelter.CapacityTotal,
+				"capacity_remaining":   shelter.CapacityRemaining,
+				"occupancy":            shelter.Occupancy,
+				"occupancy_percentage": shelter.OccupancyPercentage,
+				"emergency_needs":      shelter.EmergencyNeeds,
+				"marker-symbol":        "shelter",
+				"marker-color":         shelterOccupancyColor(shelter.OccupancyPercentage),
+			},
+		})
+	}
+
+	reports, err := repository.GetEmergencyReportsByDisasterID(database.DbConnection, disasterID)
+	if err != nil {
+		return collection, err
+	}
+	for _, report := range reports {
+		properties := map[string]interface{}{
+			"layer":         "emergency_reports",
+			"title":         report.Description,
+			"location":      report.Location,
+			"reported_at":   report.CreatedAt,
+			"marker-symbol": "danger",
+			"marker-color":  "#c0392b",
+		}
+		if color, ok := triageColors[report.TriageCategory]; ok {
+			properties["triage_category"] = report.TriageCategory
+			properties["marker-color"] = color
+		}
+
+		collection.Features = append(collection.Features, structs.GeoJSONFeature{
+			Type:       "Feature",
+			ID:         "emergency-report-" + strconv.Itoa(report.ID),
+			Geometry:   pointGeometry(report.Latitude, report.Longitude),
+			Properties: properties,
+		})
+	}
+
+	routes, err := repository.GetEvacuationRoutesByDisasterID(database.DbConnection, disasterID)
+	if err != nil {
+		return collection, err
+	}
+	for _, route := range routes {
+		var geometry *structs.GeoJSONGeometry
+		if len(route.Waypoints) >= 2 {
+			geometry = &structs.GeoJSONGeometry{Type: "LineString", Coordinates: waypointCoordinates(route.Waypoints)}
+		}
+
+		segmentStatuses := map[string]int{"safe": 0, "risky": 0, "blocked": 0}
+		var segmentFeatures []structs.GeoJSONFeature
+		for _, segment := range route.Segments {
+			segmentStatuses[segment.Status]++
+			if segment.Status == "safe" || segment.Sequence+1 >= len(route.Waypoints) {
+				continue
+			}
+
+			segmentFeatures = append(segmentFeatures, structs.GeoJSONFeature{
+				Type: "Feature",
+				ID:   "evacuation-route-" + strconv.Itoa(route.ID) + "-segment-" + strconv.Itoa(segment.Sequence),
+				Geometry: &structs.GeoJSONGeometry{
+					Type:        "LineString",
+					Coordinates: waypointCoordinates(route.Waypoints[segment.Sequence : segment.Sequence+2]),
+				},
+				Properties: map[string]interface{}{
+					"layer":        "evacuation_route_segments",
+					"title":        route.Origin + " - " + route.Destination + " (segmen " + strconv.Itoa(segment.Sequence) + ")",
+					"route_id":     route.ID,
+					"sequence":     segment.Sequence,
+					"distance":     segment.Distance,
+					"status":       segment.Status,
+					"note":         segment.Note,
+					"stroke":       routeStatusColors[segment.Status],
+					"stroke-width": 6,
+				},
+			})
+		}
+
+		collection.Features = append(collection.Features, structs.GeoJSONFeature{
+			Type:     "Feature",
+			ID:       "evacuation-route-" + strconv.Itoa(route.ID),
+			Geometry: geometry,
+			Properties: map[string]interface{}{
+				"layer":            "evacuation_routes",
+				"title":            route.Origin + " - " + route.Destination,
+				"origin":           route.Origin,
+				"destination":      route.Destination,
+				"distance":         route.Distance,
+				"status":           route.Status,
+				"risky_segments":   segmentStatuses["risky"],
+				"blocked_segments": segmentStatuses["blocked"],
+				"stroke":           routeStatusColors[route.Status],
+				"stroke-width":     4,
+			},
+		})
+		collection.Features = append(collection.Features, segmentFeatures...)
+	}
+
+	logistics, err := repository.GetLogisticsByDisasterID(database.DbConnection, disasterID)
+	if err != nil {
+		return collection, err
+	}
+	logisticTypes := make(map[int]string)
+	for _, logistic := range logistics {
+		logisticTypes[logistic.ID] = logistic.Type
+	}
+
+	logs, err := repository.GetDistributionLogsByDisasterID(database.DbConnection, disasterID)
+	if err != nil {
+		return collection, err
+	}
+	for _, log := range logs {
+		var geometry *structs.GeoJSONGeometry
+		if shelter, ok := sheltersByName[strings.ToLower(strings.TrimSpace(log.Destination))]; ok {
+			geometry = pointGeometry(shelter.Latitude, shelter.Longitude)
+		}
+
+		logisticType := ""
+		if log.LogisticID != nil {
+			logisticType = logisticTypes[*log.LogisticID]
+		}
+
+		collection.Features = append(collection.Features, structs.GeoJSONFeature{
+			Type:     "Feature",
+			ID:       "distribution-" + strconv.Itoa(log.ID),
+			Geometry: geometry,
+			Properties: map[string]interface{}{
+				"layer":          "distributions",
+				"title":          log.Destination,
+				"origin":         log.Origin,
+				"destination":    log.Destination,
+				"logistic_type":  logisticType,
+				"quantity_sent":  log.QuantitySent,
+				"recipient_name": log.RecipientName,
+				"sent_at":        log.SentAt,
+				"marker-symbol":  "warehouse",
+				"marker-color":   "#2980b9",
+			},
+		})
+	}
+
+	return collection, nil
+}
+
+func kmlColor(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return "ff888888"
+	}
+	return "ff" + hex[4:6] + hex[2:4] + hex[0:2]
+}
+
+func kmlCoordinates(coordinates [][]float64) string {
+	var parts []string
+	for _, coordinate := range coordinates {
+		if len(coordinate) < 2 {
+			continue
+		}
+		parts = append(parts, strconv.FormatFloat(coordinate[0], 'f', -1, 64)+","+strconv.FormatFloat(coordinate[1], 'f', -1, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+func writeKMLText(buffer *bytes.Buffer, tag, value string) {
+	buffer.WriteString("<" + tag + ">")
+	xml.EscapeText(buffer, []byte(value))
+	buffer.WriteString("</" + tag + ">")
+}
+
+func renderKML(collection structs.GeoJSONFeatureCollection) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	buffer.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2"><Document>`)
+	writeKMLText(&buffer, "name", collection.Name)
+
+	for _, feature := range collection.Features {
+		if feature.Geometry == nil {
+			continue
+		}
+
+		buffer.WriteString("<Placemark>")
+		writeKMLText(&buffer, "name", fmt.Sprint(feature.Properties["title"]))
+
+		buffer.WriteString("<Style>")
+		switch coordinates := feature.Geometry.Coordinates.(type) {
+		case []float64:
+			color, _ := feature.Properties["marker-color"].(string)
+			buffer.WriteString("<IconStyle><color>" + kmlColor(color) + "</color></IconStyle>")
+			buffer.WriteString("</Style>")
+			writeKMLExtendedData(&buffer, feature.Properties)
+			buffer.WriteString("<Point><coordinates>" + kmlCoordinates([][]float64{coordinates}) + "</coordinates></Point>")
+		case [][]float64:
+			color, _ := feature.Properties["stroke"].(string)
+			buffer.WriteString("<LineStyle><color>" + kmlColor(color) + "</color><width>4</width></LineStyle>")
+			buffer.WriteString("</Style>")
+			writeKMLExtendedData(&buffer, feature.Properties)
+			buffer.WriteString("<LineString><tessellate>1</tessellate><coordinates>" + kmlCoordinates(coordinates) + "</coordinates></LineString>")
+		default:
+			buffer.WriteString("</Style>")
+		}
+		buffer.WriteString("</Placemark>")
+	}
+
+	buffer.WriteString("</Document></kml>")
+	return buffer.Bytes()
+}
+
+func writeKMLExtendedData(buffer *bytes.Buffer, properties map[string]interface{}) {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		if key == "title" || strings.HasPrefix(key, "marker-") || strings.HasPrefix(key, "stroke") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buffer.WriteString("<ExtendedData>")
+	for _, key := range keys {
+		value := properties[key]
+		buffer.WriteString(`<Data name="`)
+		xml.EscapeText(buffer, []byte(key))
+		buffer.WriteString(`">`)
+		writeKMLText(buffer, "value", fmt.Sprint(value))
+		buffer.WriteString("</Data>")
+	}
+	buffer.WriteString("</ExtendedData>")
+}
+
+// GetDisasterMapGeoJSON godoc
+// @Summary Export disaster map as GeoJSON
+// @Description Mengekspor layer peta operasional bencana (shelter, laporan darurat, jalur evakuasi, distribusi) sebagai GeoJSON FeatureCollection
+// @Tags Disaster
+// @Produce json
+// @Param id path int true "Disaster ID"
+// @Success 200 {object} structs.GeoJSONFeatureCollection
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disasters/{id}/map.geojson [get]
+func GetDisasterMapGeoJSON(c *gin.Context) {
+	disasterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID bencana tidak valid"})
+		return
+	}
+
+	collection, err := buildDisasterMap(disasterID)
+	if err != nil {
+		if err.Error() == "disaster not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Laporan bencana tidak ditemukan"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuat peta bencana"})
+		return
+	}
+
+	data, err := json.Marshal(collection)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuat peta bencana"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="disaster-%d.geojson"`, disasterID))
+	c.Data(http.StatusOK, "application/geo+json", data)
+}
+
+// GetDisasterMapKML godoc
+// @Summary Export disaster map as KML
+// @Description Mengekspor layer peta operasional bencana sebagai dokumen KML
+// @Tags Disaster
+// @Produce xml
+// @Param id path int true "Disaster ID"
+// @Success 200 {string} string "Dokumen KML"
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disasters/{id}/map.kml [get]
+func GetDisasterMapKML(c *gin.Context) {
+	disasterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID bencana tidak valid"})
+		return
+	}
+
+	collection, err := buildDisasterMap(disasterID)
+	if err != nil {
+		if err.Error() == "disaster not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Laporan bencana tidak ditemukan"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuat peta bencana"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="disaster-%d.kml"`, disasterID))
+	c.Data(http.StatusOK, "application/vnd.google-earth.kml+xml", renderKML(collection))
+}
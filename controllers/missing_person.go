@@ -0,0 +1,471 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/middlewares"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func missingPersonFromInput(input structs.MissingPersonInput) (structs.MissingPerson, error) {
+	person := structs.MissingPerson{
+		Name:             input.Name,
+		DisasterID:       input.DisasterID,
+		AgeMin:           input.AgeMin,
+		AgeMax:           input.AgeMax,
+		LastSeenLocation: input.LastSeenLocation,
+		ContactName:      input.ContactName,
+		ContactPhone:     input.ContactPhone,
+		ContactEmail:     input.ContactEmail,
+		Description:      input.Description,
+		Status:           input.Status,
+	}
+
+	if input.LastSeenAt != "" {
+		lastSeenAt, err := time.Parse("02/01/2006 15:04", input.LastSeenAt)
+		if err != nil {
+			return person, err
+		}
+		person.LastSeenAt = &lastSeenAt
+	}
+	return person, nil
+}
+
+func missingPersonErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "missing person not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Laporan orang hilang tidak ditemukan"})
+	case "invalid missing person status":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status tidak valid, hanya bisa 'missing', 'found', atau 'closed'"})
+	case "invalid age range":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rentang usia tidak valid"})
+	case "missing person already resolved":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Laporan orang hilang sudah ditutup atau ditemukan"})
+	case "tidak ada field yang dapat diperbarui":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// CreateMissingPerson godoc
+// @Summary Report a missing person
+// @Description Membuat laporan orang hilang dan langsung mencocokkannya dengan data pengungsi
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param input body structs.MissingPersonInput true "Data orang hilang, format last_seen_at 'DD/MM/YYYY HH:mm'"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons [post]
+func CreateMissingPerson(c *gin.Context) {
+	var input structs.MissingPersonInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, nama orang hilang wajib diisi",
+		})
+		return
+	}
+
+	person, err := missingPersonFromInput(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Format tanggal harus 'DD/MM/YYYY HH:mm'",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+	person.ReportedBy = &currentUser.ID
+
+	err = repository.CreateMissingPerson(database.DbConnection, &person)
+	if err != nil {
+		missingPersonErrorResponse(c, err, "Gagal membuat laporan orang hilang")
+		return
+	}
+
+	matches, err := repository.MatchMissingPerson(database.DbConnection, person.ID)
+	if err != nil {
+		fmt.Println("Error MatchMissingPerson:", err)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":           "Laporan orang hilang berhasil dibuat",
+		"result":            person,
+		"candidate_matches": len(matches),
+	})
+}
+
+// Kontak pelapor hanya ditampilkan untuk admin, relawan, dan pelapor sendiri
+func hideMissingPersonContacts(c *gin.Context, persons []structs.MissingPerson) bool {
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return false
+	}
+	if currentUser.Role == "admin" {
+		return true
+	}
+
+	isVolunteer, err := repository.IsUserVolunteer(database.DbConnection, currentUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal memeriksa status volunteer",
+		})
+		return false
+	}
+	if isVolunteer {
+		return true
+	}
+
+	for i := range persons {
+		if persons[i].ReportedBy != nil && *persons[i].ReportedBy == currentUser.ID {
+			continue
+		}
+		persons[i].ContactName = ""
+		persons[i].ContactPhone = ""
+		persons[i].ContactEmail = ""
+	}
+	return true
+}
+
+// GetAllMissingPersons godoc
+// @Summary Get all missing persons
+// @Description Mendapatkan daftar laporan orang hilang, dapat difilter berdasarkan status. Kontak pelapor hanya ditampilkan untuk admin, relawan, dan pelapor sendiri
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param status query string false "Status laporan (missing, found, closed)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons [get]
+func GetAllMissingPersons(c *gin.Context) {
+	persons, err := repository.GetAllMissingPersons(database.DbConnection, c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar orang hilang",
+		})
+		return
+	}
+
+	if len(persons) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Tidak ada laporan orang hilang yang tersedia",
+		})
+		return
+	}
+
+	if !hideMissingPersonContacts(c, persons) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": persons,
+	})
+}
+
+// GetMissingPersonByID godoc
+// @Summary Get missing person by ID
+// @Description Mendapatkan detail laporan orang hilang. Kontak pelapor hanya ditampilkan untuk admin, relawan, dan pelapor sendiri
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param id path int true "Missing Person ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons/{id} [get]
+func GetMissingPersonByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	person, err := repository.GetMissingPersonByID(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Laporan orang hilang tidak ditemukan",
+		})
+		return
+	}
+
+	persons := []structs.MissingPerson{person}
+	if !hideMissingPersonContacts(c, persons) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": persons[0],
+	})
+}
+
+// UpdateMissingPerson godoc
+// @Summary Update missing person report
+// @Description Memperbarui laporan orang hilang
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param id path int true "Missing Person ID"
+// @Param input body structs.MissingPersonInput true "Data orang hilang"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons/{id} [put]
+func UpdateMissingPerson(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.MissingPersonInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	person, err := missingPersonFromInput(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Format tanggal harus 'DD/MM/YYYY HH:mm'",
+		})
+		return
+	}
+	person.ID = id
+
+	err = repository.UpdateMissingPerson(database.DbConnection, person)
+	if err != nil {
+		missingPersonErrorResponse(c, err, "Gagal mengupdate laporan orang hilang")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Laporan orang hilang berhasil diperbarui",
+	})
+}
+
+// DeleteMissingPerson godoc
+// @Summary Delete missing person report
+// @Description Menghapus laporan orang hilang
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param id path int true "Missing Person ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons/{id} [delete]
+func DeleteMissingPerson(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	err = repository.DeleteMissingPerson(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal menghapus laporan orang hilang",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Laporan orang hilang berhasil dihapus",
+	})
+}
+
+// RunMissingPersonMatcher godoc
+// @Summary Run missing person matcher
+// @Description Menjalankan ulang pencocokan laporan orang hilang dengan data pengungsi (kemiripan nama, toleransi usia, dan bencana)
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param id path int true "Missing Person ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons/{id}/match [post]
+func RunMissingPersonMatcher(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	matches, err := repository.MatchMissingPerson(database.DbConnection, id)
+	if err != nil {
+		missingPersonErrorResponse(c, err, "Gagal mencocokkan laporan orang hilang")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": matches,
+	})
+}
+
+// GetMissingPersonMatches godoc
+// @Summary Get candidate matches
+// @Description Mendapatkan kandidat pengungsi yang cocok dengan laporan orang hilang
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param id path int true "Missing Person ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons/{id}/matches [get]
+func GetMissingPersonMatches(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	matches, err := repository.GetMissingPersonMatches(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan kandidat kecocokan",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": matches,
+	})
+}
+
+// ReviewMissingPersonMatch godoc
+// @Summary Confirm or reject a candidate match
+// @Description Relawan mengonfirmasi atau menolak kandidat kecocokan, pelapor diberi tahu lewat email saat dikonfirmasi
+// @Tags MissingPerson
+// @Accept json
+// @Produce json
+// @Param id path int true "Missing Person ID"
+// @Param match_id path int true "Match ID"
+// @Param input body structs.MissingPersonMatchInput true "Status kecocokan (confirmed atau rejected)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /missing_persons/{id}/matches/{match_id} [put]
+func ReviewMissingPersonMatch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("match_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID kecocokan tidak valid",
+		})
+		return
+	}
+
+	var input structs.MissingPersonMatchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, status wajib diisi",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.ReviewMissingPersonMatch(database.DbConnection, id, matchID, input.Status, currentUser.ID)
+	if err != nil {
+		switch err.Error() {
+		case "invalid match status":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Status tidak valid, hanya bisa 'confirmed' atau 'rejected'"})
+		case "match not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Kandidat kecocokan tidak ditemukan"})
+		case "match already reviewed":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Kandidat kecocokan sudah ditinjau"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal meninjau kandidat kecocokan"})
+		}
+		return
+	}
+
+	notified := false
+	if input.Status == "confirmed" {
+		notified = notifyMissingPersonReporter(id)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Kandidat kecocokan berhasil ditinjau",
+		"notified": notified,
+	})
+}
+
+func notifyMissingPersonReporter(missingPersonID int) bool {
+	person, err := repository.GetMissingPersonByID(database.DbConnection, missingPersonID)
+	if err != nil || person.FoundRefugeeID == nil {
+		return false
+	}
+
+	recipient := person.ContactEmail
+	if recipient == "" && person.ReportedBy != nil {
+		reporter, err := repository.GetUserByID(database.DbConnection, *person.ReportedBy)
+		if err == nil {
+			recipient = reporter.Email
+		}
+	}
+	if recipient == "" {
+		return false
+	}
+
+	location := "Belum ditempatkan di shelter"
+	refugee, err := repository.GetRefugeeByID(database.DbConnection, *person.FoundRefugeeID)
+	if err == nil && refugee.ShelterID != nil {
+		if shelter, err := repository.GetShelterByID(database.DbConnection, *refugee.ShelterID); err == nil {
+			location = shelter.Name + " - " + shelter.Location
+		}
+	}
+
+	if err := middlewares.SendMissingPersonFoundEmail(recipient, person.Name, location); err != nil {
+		fmt.Println("Error SendMissingPersonFoundEmail:", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,340 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func needRequestErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "invalid need priority":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Prioritas tidak valid, hanya bisa 'critical', 'high', 'medium', atau 'low'"})
+	case "invalid need request status":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status hanya bisa diubah menjadi 'open' atau 'cancelled', status lain dihitung otomatis dari distribusi"})
+	case "invalid need request":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama barang atau item_id wajib diisi"})
+	case "tidak ada field yang dapat diperbarui":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tidak ada field yang dapat diperbarui"})
+	case "need request not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Permintaan kebutuhan tidak ditemukan"})
+	case "shelter not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shelter tidak ditemukan"})
+	case "disaster not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bencana tidak ditemukan"})
+	case "need request closed":
+		c.JSON(http.StatusConflict, gin.H{"error": "Permintaan kebutuhan sudah terpenuhi atau dibatalkan"})
+	case "logistic not in shelter disaster":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Logistik harus berasal dari bencana yang sama dengan shelter"})
+	case "logistic does not match need":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jenis logistik tidak sesuai dengan barang yang diminta"})
+	case "allocation exceeds need":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jumlah alokasi melebihi sisa kebutuhan shelter"})
+	default:
+		stockErrorResponse(c, err, fallback)
+	}
+}
+
+// CreateNeedRequest godoc
+// @Summary Create a shelter need request
+// @Description Mencatat permintaan kebutuhan terstruktur (barang, jumlah, prioritas) untuk shelter
+// @Tags NeedRequest
+// @Accept json
+// @Produce json
+// @Param id path int true "Shelter ID"
+// @Param input body structs.NeedRequestInput true "Data permintaan kebutuhan"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shelters/{id}/needs [post]
+func CreateNeedRequest(c *gin.Context) {
+	shelterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID shelter tidak valid",
+		})
+		return
+	}
+
+	var input structs.NeedRequestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	need := &structs.NeedRequest{
+		ShelterID:   shelterID,
+		ItemID:      input.ItemID,
+		ItemName:    input.ItemName,
+		Quantity:    input.Quantity,
+		Unit:        input.Unit,
+		Priority:    input.Priority,
+		Note:        input.Note,
+		RequestedBy: &currentUser.ID,
+	}
+
+	err = repository.CreateNeedRequest(database.DbConnection, need)
+	if err != nil {
+		needRequestErrorResponse(c, err, "Gagal mencatat permintaan kebutuhan")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Permintaan kebutuhan berhasil dicatat",
+		"result":  need,
+	})
+}
+
+// GetNeedRequestsByShelterID godoc
+// @Summary Get shelter need requests
+// @Description Mendapatkan daftar permintaan kebutuhan shelter, diurutkan dari yang masih terbuka dan paling mendesak
+// @Tags NeedRequest
+// @Accept json
+// @Produce json
+// @Param id path int true "Shelter ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shelters/{id}/needs [get]
+func GetNeedRequestsByShelterID(c *gin.Context) {
+	shelterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID shelter tidak valid",
+		})
+		return
+	}
+
+	needs, err := repository.GetNeedRequestsByShelterID(database.DbConnection, shelterID)
+	if err != nil {
+		needRequestErrorResponse(c, err, "Gagal mendapatkan daftar permintaan kebutuhan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": needs,
+	})
+}
+
+// GetNeedRequestByID godoc
+// @Summary Get need request by ID
+// @Description Mendapatkan detail permintaan kebutuhan shelter
+// @Tags NeedRequest
+// @Accept json
+// @Produce json
+// @Param id path int true "Need Request ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /need_requests/{id} [get]
+func GetNeedRequestByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	need, err := repository.GetNeedRequestByID(database.DbConnection, id)
+	if err != nil {
+		needRequestErrorResponse(c, err, "Gagal mendapatkan permintaan kebutuhan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": need,
+	})
+}
+
+// UpdateNeedRequest godoc
+// @Summary Update need request
+// @Description Memperbarui permintaan kebutuhan shelter. Status hanya bisa diubah menjadi 'open' atau 'cancelled'
+// @Tags NeedRequest
+// @Accept json
+// @Produce json
+// @Param id path int true "Need Request ID"
+// @Param input body structs.NeedRequestInput true "Data permintaan kebutuhan"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /need_requests/{id} [put]
+func UpdateNeedRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.NeedRequestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	err = repository.UpdateNeedRequest(database.DbConnection, id, input)
+	if err != nil {
+		needRequestErrorResponse(c, err, "Gagal memperbarui permintaan kebutuhan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Permintaan kebutuhan berhasil diperbarui",
+	})
+}
+
+// DeleteNeedRequest godoc
+// @Summary Delete need request
+// @Description Menghapus permintaan kebutuhan shelter
+// @Tags NeedRequest
+// @Accept json
+// @Produce json
+// @Param id path int true "Need Request ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /need_requests/{id} [delete]
+func DeleteNeedRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	err = repository.DeleteNeedRequest(database.DbConnection, id)
+	if err != nil {
+		needRequestErrorResponse(c, err, "Gagal menghapus permintaan kebutuhan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Permintaan kebutuhan berhasil dihapus",
+	})
+}
+
+// GetAllocationProposals godoc
+// @Summary Get allocation proposals
+// @Description Mengusulkan distribusi dari stok logistik bencana ke permintaan kebutuhan shelter, diurutkan berdasarkan prioritas, rasio kekurangan, dan jarak
+// @Tags NeedRequest
+// @Accept json
+// @Produce json
+// @Param id path int true "Disaster ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disasters/{id}/allocations [get]
+func GetAllocationProposals(c *gin.Context) {
+	disasterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID bencana tidak valid",
+		})
+		return
+	}
+
+	proposals, err := repository.GetAllocationProposals(database.DbConnection, disasterID)
+	if err != nil {
+		needRequestErrorResponse(c, err, "Gagal membuat usulan alokasi")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": proposals,
+	})
+}
+
+// AcceptAllocation godoc
+// @Summary Accept an allocation proposal
+// @Description Menerima usulan alokasi untuk permintaan kebutuhan dan mencatatnya sebagai log distribusi yang langsung mengurangi stok. Jika quantity kosong, seluruh sisa kebutuhan dialokasikan
+// @Tags NeedRequest
+// @Accept json
+// @Produce json
+// @Param id path int true "Need Request ID"
+// @Param input body structs.AllocationAcceptInput true "Data alokasi"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /need_requests/{id}/allocate [post]
+func AcceptAllocation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.AllocationAcceptInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, logistic_id wajib diisi",
+		})
+		return
+	}
+
+	sentAt := time.Now()
+	if input.SentAt != "" {
+		sentAt, err = time.Parse("02/01/2006 15:04", input.SentAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Format tanggal harus 'DD/MM/YYYY HH:mm'",
+			})
+			return
+		}
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+	if input.SenderName == "" {
+		input.SenderName = currentUser.Name
+	}
+
+	log, err := repository.AcceptAllocation(database.DbConnection, id, input, sentAt, currentUser.ID)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		needRequestErrorResponse(c, err, "Gagal mencatat distribusi dari usulan alokasi")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":           "Usulan alokasi diterima dan distribusi bantuan berhasil dicatat",
+		"result":            log,
+		"confirmation_code": log.ConfirmationCode,
+	})
+}
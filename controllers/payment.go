@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/payment"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func processPaymentWebhook(c *gin.Context, provider payment.Provider, header http.Header, body []byte) {
+	event, err := provider.VerifyWebhook(header, body)
+	if err != nil {
+		switch err.Error() {
+		case "invalid signature":
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Tanda tangan webhook tidak valid"})
+		case "invalid webhook payload":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Isi webhook tidak valid"})
+		default:
+			donationErrorResponse(c, err, "Gagal memverifikasi webhook pembayaran")
+		}
+		return
+	}
+
+	err = repository.ApplyPaymentWebhook(database.DbConnection, provider.Name(), event)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		switch err.Error() {
+		case "invalid webhook payload":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Status pembayaran pada webhook tidak dikenal"})
+		case "payment amount mismatch":
+			c.JSON(http.StatusConflict, gin.H{"error": "Nominal pembayaran tidak sesuai dengan tagihan donasi"})
+		default:
+			donationErrorResponse(c, err, "Gagal memproses webhook pembayaran")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook pembayaran berhasil diproses",
+	})
+}
+
+// CreateDonationPayment godoc
+// @Summary Create a payment for a donation
+// @Description Membuat tagihan pembayaran donasi uang di payment gateway aktif (PAYMENT_PROVIDER, tanpa default) dan mengembalikan redirect_url halaman pembayaran. Tagihan yang masih berjalan dikembalikan ulang. Status donasi menjadi confirmed hanya lewat webhook yang terverifikasi
+// @Tags Donation
+// @Accept json
+// @Produce json
+// @Param id path int true "Donation ID"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Failure 503 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /donations/{id}/pay [post]
+func CreateDonationPayment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	provider, ok := payment.Active()
+	if !ok {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Payment gateway belum dikonfigurasi",
+		})
+		return
+	}
+
+	if _, err := repository.GetPayableDonation(database.DbConnection, id); err != nil {
+		donationErrorResponse(c, err, "Gagal membuat tagihan pembayaran")
+		return
+	}
+
+	if intent, err := repository.GetPendingPaymentIntent(database.DbConnection, id, provider.Name()); err == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Tagihan pembayaran masih berlaku",
+			"result":  intent,
+		})
+		return
+	}
+
+	intent := structs.PaymentIntent{DonationID: id}
+	if err := provider.CreatePaymentIntent(&intent); err != nil {
+		fmt.Println("Error Payment:", err)
+		donationErrorResponse(c, err, "Gagal membuat tagihan di payment gateway")
+		return
+	}
+
+	if err := repository.CreatePaymentIntent(database.DbConnection, &intent); err != nil {
+		fmt.Println("Error Query:", err)
+		donationErrorResponse(c, err, "Gagal menyimpan tagihan pembayaran")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tagihan pembayaran berhasil dibuat, lanjutkan pembayaran melalui redirect_url",
+		"result":  intent,
+	})
+}
+
+// PaymentWebhook godoc
+// @Summary Payment gateway webhook
+// @Description Menerima notifikasi status pembayaran dari payment gateway. Tanda tangan HMAC-SHA256 atas body diverifikasi sebelum status donasi diubah, dan event yang sama hanya diproses sekali
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param provider path string true "Nama payment gateway, misal mock"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 401 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Router /payments/webhook/{provider} [post]
+func PaymentWebhook(c *gin.Context) {
+	provider, ok := payment.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Payment gateway tidak dikenal",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, 1<<20))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Gagal membaca isi webhook",
+		})
+		return
+	}
+
+	processPaymentWebhook(c, provider, c.Request.Header, body)
+}
+
+func getMockPaymentIntent(c *gin.Context) (structs.PaymentIntent, bool) {
+	intent, err := repository.GetPaymentIntentByReference(database.DbConnection, "mock", c.Param("reference"))
+	if err != nil {
+		donationErrorResponse(c, err, "Gagal mendapatkan tagihan pembayaran")
+		return intent, false
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return intent, false
+	}
+
+	donation, err := repository.GetDonationByID(database.DbConnection, intent.DonationID)
+	if err != nil {
+		donationErrorResponse(c, err, "Gagal mendapatkan tagihan pembayaran")
+		return intent, false
+	}
+	if currentUser.Role != "admin" && (donation.DonorID == nil || *donation.DonorID != currentUser.ID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Anda hanya bisa membayar donasi Anda sendiri",
+		})
+		return intent, false
+	}
+	return intent, true
+}
+
+// GetMockPayment godoc
+// @Summary Mock payment checkout page
+// @Description Halaman pembayaran payment gateway mock (redirect_url) yang menampilkan detail tagihan. Hanya tersedia bila PAYMENT_PROVIDER=mock dan PAYMENT_MOCK_ENABLED=true
+// @Tags Payment
+// @Produce json
+// @Param reference path string true "Referensi pembayaran"
+// @Success 200 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /payments/mock/{reference} [get]
+func GetMockPayment(c *gin.Context) {
+	intent, ok := getMockPaymentIntent(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Kirim POST ke alamat ini dengan status paid, failed, atau expired untuk mensimulasikan pembayaran",
+		"result":  intent,
+	})
+}
+
+// PayMockPayment godoc
+// @Summary Simulate a mock payment
+// @Description Mensimulasikan hasil pembayaran di payment gateway mock. Server menandatangani webhook dengan PAYMENT_WEBHOOK_SECRET lalu memprosesnya melalui jalur verifikasi webhook yang sama. Hanya tersedia bila PAYMENT_PROVIDER=mock dan PAYMENT_MOCK_ENABLED=true
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param reference path string true "Referensi pembayaran"
+// @Param input body structs.MockPaymentInput true "Hasil pembayaran: paid, failed, atau expired"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /payments/mock/{reference} [post]
+func PayMockPayment(c *gin.Context) {
+	var input structs.MockPaymentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	intent, ok := getMockPaymentIntent(c)
+	if !ok {
+		return
+	}
+
+	eventID := make([]byte, 8)
+	if _, err := rand.Read(eventID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mensimulasikan pembayaran",
+		})
+		return
+	}
+
+	body, _ := json.Marshal(structs.PaymentWebhookEvent{
+		EventID:           "evt_" + hex.EncodeToString(eventID),
+		ProviderReference: intent.ProviderReference,
+		Status:            input.Status,
+		Amount:            intent.Amount,
+		Currency:          intent.Currency,
+	})
+
+	provider := &payment.MockProvider{}
+	signature, err := provider.Sign(body)
+	if err != nil {
+		donationErrorResponse(c, err, "Gagal mensimulasikan pembayaran")
+		return
+	}
+
+	header := http.Header{}
+	header.Set(payment.MockSignatureHeader, "sha256="+signature)
+	processPaymentWebhook(c, provider, header, body)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func parseDisasterIDQuery(c *gin.Context) (*int, bool) {
+	value := c.Query("disaster_id")
+	if value == "" {
+		return nil, true
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID bencana tidak valid",
+		})
+		return nil, false
+	}
+	return &id, true
+}
+
+// Respons publik boleh disimpan cache selama PUBLIC_CACHE_MAX_AGE detik (default 60) dan memakai ETag untuk If-None-Match
+func publicJSON(c *gin.Context, payload gin.H) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal menyiapkan data publik",
+		})
+		return
+	}
+
+	maxAge, err := strconv.Atoi(os.Getenv("PUBLIC_CACHE_MAX_AGE"))
+	if err != nil || maxAge < 0 {
+		maxAge = 60
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	c.Header("ETag", etag)
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// GetPublicDisasters godoc
+// @Summary Get active disasters (public)
+// @Description Daftar bencana yang masih aktif tanpa data pelapor, dapat diakses tanpa login dan boleh disimpan cache
+// @Tags Public
+// @Produce json
+// @Success 200 {object} structs.APIResponse
+// @Failure 429 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Router /public/disasters [get]
+func GetPublicDisasters(c *gin.Context) {
+	disasters, err := repository.GetPublicDisasters(database.DbConnection)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar bencana",
+		})
+		return
+	}
+
+	publicJSON(c, gin.H{"result": disasters})
+}
+
+// GetPublicShelters godoc
+// @Summary Get open shelters (public)
+// @Description Daftar shelter untuk bencana aktif beserta sisa kapasitasnya, tanpa data pengungsi. Dapat diakses tanpa login dan boleh disimpan cache
+// @Tags Public
+// @Produce json
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Param available query bool false "Hanya shelter yang masih memiliki sisa kapasitas"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 429 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Router /public/shelters [get]
+func GetPublicShelters(c *gin.Context) {
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	shelters, err := repository.GetPublicShelters(database.DbConnection, disasterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar shelter",
+		})
+		return
+	}
+
+	if c.Query("available") == "true" {
+		for i := len(shelters) - 1; i >= 0; i-- {
+			if shelters[i].Full {
+				shelters = append(shelters[:i], shelters[i+1:]...)
+			}
+		}
+	}
+
+	publicJSON(c, gin.H{"result": shelters})
+}
+
+// GetPublicEvacuationRoutes godoc
+// @Summary Get evacuation route statuses (public)
+// @Description Daftar jalur evakuasi untuk bencana aktif beserta status jalur dan status tiap segmen (safe, risky, blocked). Dapat diakses tanpa login dan boleh disimpan cache
+// @Tags Public
+// @Produce json
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 429 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Router /public/evacuation_routes [get]
+func GetPublicEvacuationRoutes(c *gin.Context) {
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	routes, err := repository.GetPublicEvacuationRoutes(database.DbConnection, disasterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar jalur evakuasi",
+		})
+		return
+	}
+
+	publicJSON(c, gin.H{"result": routes})
+}
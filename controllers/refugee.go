@@ -4,21 +4,35 @@ import (
 	"RescueHub/database"
 	"RescueHub/repository"
 	"RescueHub/structs"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+func isCapacityOverrideAllowed(c *gin.Context, overrideCapacity bool) bool {
+	if role, _ := c.Get("role"); overrideCapacity && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Hanya admin yang dapat menempatkan pengungsi melebihi kapasitas shelter",
+		})
+		return false
+	}
+	return true
+}
+
 // CreateRefugee godoc
 // @Summary Create a refugee
-// @Description Membuat data pengungsi baru
+// @Description Membuat data pengungsi baru, respons memuat possible_duplicates berisi data pengungsi yang kemungkinan ganda
 // @Tags Refugee
 // @Accept json
 // @Produce json
 // @Param input body structs.RefugeeInput true "Data pengungsi"
 // @Success 201 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /refugees [post]
@@ -31,6 +45,10 @@ func CreateRefugee(c *gin.Context) {
 		return
 	}
 
+	if !isCapacityOverrideAllowed(c, input.OverrideCapacity) {
+		return
+	}
+
 	refugee := &structs.Refugee{
 		Name:       input.Name,
 		Age:        input.Age,
@@ -40,17 +58,39 @@ func CreateRefugee(c *gin.Context) {
 		DisasterID: input.DisasterID,
 	}
 
-	err := repository.CreateRefugee(database.DbConnection, refugee)
+	err := repository.CreateRefugee(database.DbConnection, refugee, input.OverrideCapacity)
 	if err != nil {
+		if err.Error() == "shelter over capacity" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Kapasitas shelter sudah penuh, penempatan pengungsi ditolak",
+			})
+			return
+		}
+		if err.Error() == "shelter not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Shelter tidak ditemukan",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal membuat data pengungsi",
 		})
 		return
 	}
 
+	if _, err := repository.MatchRefugeeWithMissingPersons(database.DbConnection, *refugee); err != nil {
+		fmt.Println("Error MatchRefugeeWithMissingPersons:", err)
+	}
+
+	duplicates, err := repository.FindDuplicateRefugees(database.DbConnection, *refugee)
+	if err != nil {
+		fmt.Println("Error FindDuplicateRefugees:", err)
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Data pengungsi berhasil dibuat",
-		"result":  refugee,
+		"message":             "Data pengungsi berhasil dibuat",
+		"result":              refugee,
+		"possible_duplicates": duplicates,
 	})
 }
 
@@ -122,7 +162,7 @@ func GetRefugeeByID(c *gin.Context) {
 
 // UpdateRefugee godoc
 // @Summary Update a refugee
-// @Description Memperbarui data pengungsi
+// @Description Memperbarui data pengungsi. Shelter anggota keluarga hanya bisa diubah melalui penempatan keluarga
 // @Tags Refugee
 // @Accept json
 // @Produce json
@@ -130,6 +170,8 @@ func GetRefugeeByID(c *gin.Context) {
 // @Param input body structs.RefugeeInput true "Data pengungsi"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /refugees/{id} [put]
@@ -150,6 +192,15 @@ func UpdateRefugee(c *gin.Context) {
 		return
 	}
 
+	if !isCapacityOverrideAllowed(c, input.OverrideCapacity) {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
 	refugee := structs.Refugee{
 		ID:         id,
 		Name:       input.Name,
@@ -160,7 +211,7 @@ func UpdateRefugee(c *gin.Context) {
 		DisasterID: input.DisasterID,
 	}
 
-	err = repository.UpdateRefugee(database.DbConnection, refugee)
+	err = repository.UpdateRefugee(database.DbConnection, refugee, input.OverrideCapacity, currentUser.ID)
 	if err != nil {
 		if err.Error() == "refugee not found" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -168,6 +219,24 @@ func UpdateRefugee(c *gin.Context) {
 			})
 			return
 		}
+		if err.Error() == "shelter over capacity" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Kapasitas shelter sudah penuh, penempatan pengungsi ditolak",
+			})
+			return
+		}
+		if err.Error() == "shelter not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Shelter tidak ditemukan",
+			})
+			return
+		}
+		if err.Error() == "refugee in household" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Pengungsi tergabung dalam keluarga, pindahkan shelter melalui penempatan keluarga",
+			})
+			return
+		}
 		
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mengupdate data pengungsi",
@@ -189,6 +258,7 @@ func UpdateRefugee(c *gin.Context) {
 // @Param id path int true "Refugee ID"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /refugees/{id} [delete]
@@ -203,6 +273,12 @@ func DeleteRefugee(c *gin.Context) {
 
 	err = repository.DeleteRefugee(database.DbConnection, id)
 	if err != nil {
+		if err.Error() == "refugee not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Data pengungsi tidak ditemukan",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal menghapus data pengungsi",
 		})
@@ -212,4 +288,220 @@ func DeleteRefugee(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Data pengungsi berhasil dihapus",
 	})
-}
\ No newline at end of file
+}
+
+// TransferRefugee godoc
+// @Summary Transfer a refugee to another shelter
+// @Description Memindahkan pengungsi ke shelter lain dan mencatat riwayat perpindahannya. Anggota keluarga dipindahkan melalui penempatan keluarga
+// @Tags Refugee
+// @Accept json
+// @Produce json
+// @Param id path int true "Refugee ID"
+// @Param input body structs.RefugeeTransferInput true "Shelter tujuan dan alasan perpindahan"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /refugees/{id}/transfer [post]
+func TransferRefugee(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.RefugeeTransferInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, shelter_id dan reason wajib diisi",
+		})
+		return
+	}
+
+	if !isCapacityOverrideAllowed(c, input.OverrideCapacity) {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	movement, err := repository.TransferRefugee(database.DbConnection, id, input.ShelterID, input.Reason, currentUser.ID, input.OverrideCapacity)
+	if err != nil {
+		switch err.Error() {
+		case "refugee not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Data pengungsi tidak ditemukan"})
+		case "shelter not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shelter tujuan tidak ditemukan"})
+		case "refugee already in shelter":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Pengungsi sudah berada di shelter tujuan"})
+		case "shelter over capacity":
+			c.JSON(http.StatusConflict, gin.H{"error": "Kapasitas shelter tujuan sudah penuh, perpindahan ditolak"})
+		case "refugee in household":
+			c.JSON(http.StatusConflict, gin.H{"error": "Pengungsi tergabung dalam keluarga, pindahkan shelter melalui penempatan keluarga"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal memindahkan pengungsi"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Pengungsi berhasil dipindahkan",
+		"result":  movement,
+	})
+}
+
+// GetRefugeeMovements godoc
+// @Summary Get refugee movement history
+// @Description Mendapatkan riwayat perpindahan pengungsi antar shelter
+// @Tags Refugee
+// @Accept json
+// @Produce json
+// @Param id path int true "Refugee ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /refugees/{id}/movements [get]
+func GetRefugeeMovements(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	movements, err := repository.GetRefugeeMovements(database.DbConnection, id)
+	if err != nil {
+		if err.Error() == "refugee not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Data pengungsi tidak ditemukan",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan riwayat perpindahan pengungsi",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": movements,
+	})
+}
+
+// GetRefugeeDuplicates godoc
+// @Summary Get probable duplicate refugees
+// @Description Mendapatkan data pengungsi yang kemungkinan ganda berdasarkan nama, usia, bencana, dan kedekatan shelter
+// @Tags Refugee
+// @Accept json
+// @Produce json
+// @Param id path int true "Refugee ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /refugees/{id}/duplicates [get]
+func GetRefugeeDuplicates(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	refugee, err := repository.GetRefugeeByID(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Data pengungsi tidak ditemukan",
+		})
+		return
+	}
+
+	duplicates, err := repository.FindDuplicateRefugees(database.DbConnection, refugee)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mencari data pengungsi ganda",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": duplicates,
+	})
+}
+
+// MergeRefugees godoc
+// @Summary Merge duplicate refugee records
+// @Description Menggabungkan data pengungsi ganda ke data utama beserta riwayatnya, data ganda kemudian dihapus
+// @Tags Refugee
+// @Accept json
+// @Produce json
+// @Param id path int true "ID pengungsi utama"
+// @Param input body structs.RefugeeMergeInput true "ID pengungsi ganda"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /refugees/{id}/merge [post]
+func MergeRefugees(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.RefugeeMergeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, duplicate_id wajib diisi",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	merge, err := repository.MergeRefugees(database.DbConnection, id, input.DuplicateID, currentUser.ID)
+	if err != nil {
+		switch err.Error() {
+		case "cannot merge refugee with itself":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Data pengungsi tidak dapat digabungkan dengan dirinya sendiri"})
+		case "refugee not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Data pengungsi tidak ditemukan"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menggabungkan data pengungsi"})
+		}
+		return
+	}
+
+	refugee, err := repository.GetRefugeeByID(database.DbConnection, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan data pengungsi",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Data pengungsi berhasil digabungkan",
+		"result":  refugee,
+		"merge":   merge,
+	})
+}
@@ -38,10 +38,20 @@ func CreateShelter(c *gin.Context) {
 		CapacityRemaining: input.CapacityTotal,
 		EmergencyNeeds: input.EmergencyNeeds,
 		DisasterID: input.DisasterID,
+		Latitude:         input.Latitude,
+		Longitude:        input.Longitude,
+		LocationAccuracy: input.LocationAccuracy,
 	}
 
 	err := repository.CreateShelter(database.DbConnection, shelter)
 	if err != nil {
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal membuat shelter",
 		})
@@ -60,12 +70,29 @@ func CreateShelter(c *gin.Context) {
 // @Tags Shelter
 // @Accept json
 // @Produce json
+// @Param near query string false "Titik pusat pencarian dalam format lat,lon"
+// @Param radius_km query number false "Radius pencarian dalam kilometer (wajib bersama near)"
+// @Param bbox query string false "Bounding box dalam format min_lat,min_lon,max_lat,max_lon"
 // @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /shelters [get]
 func GetAllShelters(c *gin.Context) {
-	shelters, err := repository.GetAllShelters(database.DbConnection)
+	filter, err := parseGeoFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parameter lokasi tidak valid, gunakan near=lat,lon&radius_km=... atau bbox=min_lat,min_lon,max_lat,max_lon",
+		})
+		return
+	}
+
+	var shelters []structs.Shelter
+	if filter != nil {
+		shelters, err = repository.GetSheltersByArea(database.DbConnection, *filter)
+	} else {
+		shelters, err = repository.GetAllShelters(database.DbConnection)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mendapatkan daftar shelters",
@@ -73,7 +100,11 @@ func GetAllShelters(c *gin.Context) {
 		return
 	}
 
-	if len(shelters) == 0 {
+	// Pencarian berdasarkan lokasi mengembalikan daftar kosong bila tidak ada shelter di area tersebut
+	if filter != nil && shelters == nil {
+		shelters = []structs.Shelter{}
+	}
+	if len(shelters) == 0 && filter == nil {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Tidak ada shelter",
 		})
@@ -129,6 +160,9 @@ func GetShelterByID(c *gin.Context) {
 // @Param input body structs.ShelterInput true "Data shelter"
 // @Success 200 {object} structs.APIResponse
 // @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
 // @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /shelters/{id} [put]
@@ -141,23 +175,50 @@ func UpdateShelter(c *gin.Context) {
 		return
 	}
 
-	var input structs.Shelter
+	var input structs.ShelterInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Input tidak valid",
 		})
 		return
 	}
-	input.ID = id
 
-	err = repository.UpdateShelter(database.DbConnection, input)
+	if !isCapacityOverrideAllowed(c, input.OverrideCapacity) {
+		return
+	}
+
+	shelter := structs.Shelter{
+		ID:               id,
+		Name:             input.Name,
+		Location:         input.Location,
+		CapacityTotal:    input.CapacityTotal,
+		EmergencyNeeds:   input.EmergencyNeeds,
+		DisasterID:       input.DisasterID,
+		Latitude:         input.Latitude,
+		Longitude:        input.Longitude,
+		LocationAccuracy: input.LocationAccuracy,
+	}
+
+	err = repository.UpdateShelter(database.DbConnection, shelter, input.OverrideCapacity)
 	if err != nil {
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
 		if err.Error() == "shelter not found" {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Shelter tidak ditemukan",
 			})
 			return
 		}
+		if err.Error() == "shelter over capacity" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Kapasitas shelter tidak boleh lebih kecil dari jumlah pengungsi saat ini",
+			})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Gagal mengupdate shelter",
@@ -0,0 +1,611 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func shiftErrorResponse(c *gin.Context, err error, fallback string) {
+	var overlap *repository.ShiftOverlapError
+	if errors.As(err, &overlap) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":                "Jadwal bentrok dengan shift lain yang sudah diambil relawan",
+			"conflicting_shift_id": overlap.ShiftID,
+		})
+		return
+	}
+	var missing *repository.CertificationMissingError
+	if errors.As(err, &missing) {
+		response := gin.H{
+			"error":                  "Relawan belum memiliki sertifikasi wajib yang terverifikasi dan masih berlaku",
+			"missing_certifications": missing.Certifications,
+		}
+		// Saat persyaratan diubah, relawan yang sudah ditugaskan dan tidak memenuhi ikut disebutkan
+		if missing.VolunteerID != 0 {
+			response["volunteer_id"] = missing.VolunteerID
+		}
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	switch err.Error() {
+	case "shift not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shift tidak ditemukan"})
+	case "shift assignment not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Relawan tidak terdaftar di shift ini"})
+	case "volunteer not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Relawan tidak ditemukan"})
+	case "shelter not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shelter tidak ditemukan"})
+	case "disaster not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bencana tidak ditemukan"})
+	case "user not volunteer":
+		c.JSON(http.StatusForbidden, gin.H{"error": "Anda belum terdaftar sebagai relawan"})
+	case "shelter not in shift disaster":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Shelter harus berada di bencana yang sama dengan shift"})
+	case "shift location required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Lokasi atau shelter shift wajib diisi"})
+	case "invalid shift time":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Waktu selesai shift harus setelah waktu mulai"})
+	case "invalid shift headcount":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jumlah relawan yang dibutuhkan harus lebih dari 0"})
+	case "shift headcount below assigned":
+		c.JSON(http.StatusConflict, gin.H{"error": "Jumlah relawan yang dibutuhkan tidak boleh kurang dari relawan yang sudah terdaftar"})
+	case "shift already ended":
+		c.JSON(http.StatusConflict, gin.H{"error": "Shift sudah berakhir"})
+	case "shift full":
+		c.JSON(http.StatusConflict, gin.H{"error": "Kuota relawan untuk shift ini sudah penuh"})
+	case "volunteer already assigned":
+		c.JSON(http.StatusConflict, gin.H{"error": "Relawan sudah terdaftar di shift ini"})
+	case "unknown skill":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keahlian tidak dikenal, gunakan kode atau nama dari daftar keahlian"})
+	case "volunteer skill mismatch":
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Keahlian relawan tidak sesuai dengan kebutuhan shift"})
+	case "tidak ada field yang dapat diperbarui":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tidak ada field yang dapat diperbarui"})
+	default:
+		fmt.Println("Error Query:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func parseDateTimeField(c *gin.Context, value, field string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := time.Parse("02/01/2006 15:04", value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Format " + field + " tidak valid, gunakan DD/MM/YYYY HH:mm",
+		})
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// Rentang jadwal dari query from dan to (DD/MM/YYYY), default hari ini sampai 7 hari ke depan
+func parseRosterRange(c *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("02/01/2006", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Format from tidak valid, gunakan DD/MM/YYYY"})
+			return from, from, false
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, 7)
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("02/01/2006", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Format to tidak valid, gunakan DD/MM/YYYY"})
+			return from, from, false
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tanggal to tidak boleh sebelum from"})
+		return from, to, false
+	}
+	return from, to, true
+}
+
+// CreateShift godoc
+// @Summary Create a volunteer shift
+// @Description Membuat shift relawan untuk bencana di lokasi atau shelter tertentu, dengan waktu mulai dan selesai (DD/MM/YYYY HH:mm), keahlian yang dibutuhkan, dan jumlah relawan
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param input body structs.ShiftInput true "Data shift"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts [post]
+func CreateShift(c *gin.Context) {
+	var input structs.ShiftInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	if input.StartTime == "" || input.EndTime == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Waktu mulai dan selesai shift wajib diisi",
+		})
+		return
+	}
+	startTime, ok := parseDateTimeField(c, input.StartTime, "start_time")
+	if !ok {
+		return
+	}
+	endTime, ok := parseDateTimeField(c, input.EndTime, "end_time")
+	if !ok {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	shift := structs.Shift{
+		ShelterID:              input.ShelterID,
+		Location:               input.Location,
+		StartTime:              *startTime,
+		EndTime:                *endTime,
+		RequiredSkills:         input.RequiredSkills,
+		RequiredCertifications: input.RequiredCertifications,
+		Headcount:              input.Headcount,
+		Note:                   input.Note,
+		CreatedBy:              &currentUser.ID,
+	}
+	if input.DisasterID != nil {
+		shift.DisasterID = *input.DisasterID
+	}
+
+	err := repository.CreateShift(database.DbConnection, &shift)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal membuat shift")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Shift berhasil dibuat",
+		"result":  shift,
+	})
+}
+
+// GetShifts godoc
+// @Summary Get volunteer shifts
+// @Description Mendapatkan daftar shift dalam rentang tanggal (default hari ini sampai 7 hari ke depan)
+// @Tags Shift
+// @Produce json
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Param shelter_id query int false "Filter berdasarkan shelter"
+// @Param from query string false "Tanggal awal (DD/MM/YYYY)"
+// @Param to query string false "Tanggal akhir (DD/MM/YYYY)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts [get]
+func GetShifts(c *gin.Context) {
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	var shelterID *int
+	if value := c.Query("shelter_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "ID shelter tidak valid",
+			})
+			return
+		}
+		shelterID = &id
+	}
+
+	from, to, ok := parseRosterRange(c)
+	if !ok {
+		return
+	}
+
+	shifts, err := repository.GetShifts(database.DbConnection, disasterID, shelterID, from, to)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal mendapatkan daftar shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": shifts,
+	})
+}
+
+// GetShiftByID godoc
+// @Summary Get shift by ID
+// @Description Mendapatkan detail shift beserta relawan yang terdaftar
+// @Tags Shift
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts/{id} [get]
+func GetShiftByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	shift, err := repository.GetShiftByID(database.DbConnection, id)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal mendapatkan shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": shift,
+	})
+}
+
+// UpdateShift godoc
+// @Summary Update a volunteer shift
+// @Description Memperbarui shift. Perubahan jam ditolak bila membuat relawan yang sudah terdaftar bentrok dengan shift lain, dan jumlah relawan tidak boleh kurang dari yang sudah terdaftar. Perubahan keahlian menurunkan ulang sertifikasi wajib bila required_certifications tidak diisi, dan ditolak (422) bila relawan yang sudah terdaftar tidak memenuhinya
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Param input body structs.ShiftInput true "Data shift yang diperbarui"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 422 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts/{id} [put]
+func UpdateShift(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.ShiftInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	startTime, ok := parseDateTimeField(c, input.StartTime, "start_time")
+	if !ok {
+		return
+	}
+	endTime, ok := parseDateTimeField(c, input.EndTime, "end_time")
+	if !ok {
+		return
+	}
+
+	err = repository.UpdateShift(database.DbConnection, id, input, startTime, endTime)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal memperbarui shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shift berhasil diperbarui",
+	})
+}
+
+// DeleteShift godoc
+// @Summary Delete a volunteer shift
+// @Description Menghapus shift beserta daftar relawannya
+// @Tags Shift
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts/{id} [delete]
+func DeleteShift(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	if err := repository.DeleteShift(database.DbConnection, id); err != nil {
+		shiftErrorResponse(c, err, "Gagal menghapus shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shift berhasil dihapus",
+	})
+}
+
+// SignUpForShift godoc
+// @Summary Sign up for a shift
+// @Description Relawan mendaftar sendiri ke shift. Ditolak bila kuota penuh, keahlian tidak sesuai, atau jadwal bentrok dengan shift lain yang sudah diambil
+// @Tags Shift
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 422 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts/{id}/signup [post]
+func SignUpForShift(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	assignment, err := repository.SignUpForShift(database.DbConnection, id, currentUser.ID)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal mendaftar ke shift")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Berhasil mendaftar ke shift",
+		"result":  assignment,
+	})
+}
+
+// AssignVolunteerToShift godoc
+// @Summary Assign a volunteer to a shift
+// @Description Koordinator menugaskan relawan ke shift dengan pemeriksaan kuota, keahlian, dan bentrok jadwal yang sama seperti pendaftaran mandiri
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Param input body structs.ShiftAssignInput true "Relawan yang ditugaskan"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 422 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts/{id}/assignments [post]
+func AssignVolunteerToShift(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.ShiftAssignInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	assignment, err := repository.AssignVolunteerToShift(database.DbConnection, id, input.VolunteerID, currentUser.ID)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal menugaskan relawan ke shift")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Relawan berhasil ditugaskan ke shift",
+		"result":  assignment,
+	})
+}
+
+// RemoveShiftAssignment godoc
+// @Summary Remove a volunteer from a shift
+// @Description Membatalkan pendaftaran relawan dari shift. Relawan hanya bisa membatalkan pendaftarannya sendiri, admin bisa membatalkan siapa pun
+// @Tags Shift
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Param volunteer_id path int true "Volunteer ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts/{id}/assignments/{volunteer_id} [delete]
+func RemoveShiftAssignment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+	volunteerID, err := strconv.Atoi(c.Param("volunteer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID relawan tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	if currentUser.Role != "admin" {
+		volunteer, err := repository.GetVolunteerByID(database.DbConnection, volunteerID)
+		if err != nil {
+			shiftErrorResponse(c, err, "Gagal membatalkan pendaftaran shift")
+			return
+		}
+		if volunteer.UserID == nil || *volunteer.UserID != currentUser.ID {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Anda hanya bisa membatalkan pendaftaran shift Anda sendiri",
+			})
+			return
+		}
+	}
+
+	if err := repository.RemoveShiftAssignment(database.DbConnection, id, volunteerID); err != nil {
+		shiftErrorResponse(c, err, "Gagal membatalkan pendaftaran shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Pendaftaran shift berhasil dibatalkan",
+	})
+}
+
+// GetShiftConflicts godoc
+// @Summary Get shift conflicts
+// @Description Mendeteksi relawan dengan shift yang waktunya bertabrakan (overlap) dan shift mendatang yang kekurangan relawan (understaffed)
+// @Tags Shift
+// @Produce json
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shifts/conflicts [get]
+func GetShiftConflicts(c *gin.Context) {
+	disasterID, ok := parseDisasterIDQuery(c)
+	if !ok {
+		return
+	}
+
+	conflicts, err := repository.GetShiftConflicts(database.DbConnection, disasterID)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal memeriksa bentrok shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": conflicts,
+	})
+}
+
+// GetShelterRoster godoc
+// @Summary Get shelter roster
+// @Description Jadwal shift relawan di shelter beserta relawan yang bertugas, default hari ini sampai 7 hari ke depan
+// @Tags Shift
+// @Produce json
+// @Param id path int true "Shelter ID"
+// @Param from query string false "Tanggal awal (DD/MM/YYYY)"
+// @Param to query string false "Tanggal akhir (DD/MM/YYYY)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shelters/{id}/roster [get]
+func GetShelterRoster(c *gin.Context) {
+	shelterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID shelter tidak valid",
+		})
+		return
+	}
+
+	from, to, ok := parseRosterRange(c)
+	if !ok {
+		return
+	}
+
+	shifts, err := repository.GetShelterRoster(database.DbConnection, shelterID, from, to)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal mendapatkan jadwal shelter")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": shifts,
+	})
+}
+
+// GetVolunteerRoster godoc
+// @Summary Get volunteer roster
+// @Description Jadwal shift yang diambil relawan, default hari ini sampai 7 hari ke depan
+// @Tags Shift
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param from query string false "Tanggal awal (DD/MM/YYYY)"
+// @Param to query string false "Tanggal akhir (DD/MM/YYYY)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/roster [get]
+func GetVolunteerRoster(c *gin.Context) {
+	volunteerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID relawan tidak valid",
+		})
+		return
+	}
+
+	from, to, ok := parseRosterRange(c)
+	if !ok {
+		return
+	}
+
+	shifts, err := repository.GetVolunteerRoster(database.DbConnection, volunteerID, from, to)
+	if err != nil {
+		shiftErrorResponse(c, err, "Gagal mendapatkan jadwal relawan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": shifts,
+	})
+}
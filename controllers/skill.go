@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func skillErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "skill code required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama atau kode keahlian wajib diisi"})
+	case "skill already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": "Kode keahlian sudah terdaftar"})
+	case "invalid skill proficiency":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tingkat kemahiran tidak valid, hanya bisa 'beginner', 'intermediate', 'advanced', atau 'expert'"})
+	default:
+		taskErrorResponse(c, err, fallback)
+	}
+}
+
+// Parameter skills berisi daftar keahlian dipisah koma, limit default 10 dan maksimal 50
+func parseMatchQuery(c *gin.Context) ([]string, int, bool) {
+	var skills []string
+	if value, exists := c.GetQuery("skills"); exists {
+		skills = strings.Split(value, ",")
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parameter limit harus berupa bilangan bulat antara 1 dan 50",
+		})
+		return nil, 0, false
+	}
+	return skills, limit, true
+}
+
+// GetSkills godoc
+// @Summary Get skill taxonomy
+// @Description Mendapatkan daftar keahlian relawan beserta kategorinya. Kode keahlian dipakai pada keahlian relawan, shift, dan tugas
+// @Tags Skill
+// @Produce json
+// @Success 200 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /skills [get]
+func GetSkills(c *gin.Context) {
+	skills, err := repository.GetSkills(database.DbConnection)
+	if err != nil {
+		skillErrorResponse(c, err, "Gagal mendapatkan daftar keahlian")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": skills,
+	})
+}
+
+// CreateSkill godoc
+// @Summary Add a skill to the taxonomy
+// @Description Menambahkan keahlian baru ke taksonomi. Kode dibuat dari nama bila kosong, kategori default 'lainnya'
+// @Tags Skill
+// @Accept json
+// @Produce json
+// @Param input body structs.SkillInput true "Data keahlian"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /skills [post]
+func CreateSkill(c *gin.Context) {
+	var input structs.SkillInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	skill := structs.Skill{
+		Code:                  input.Code,
+		Name:                  input.Name,
+		Category:              input.Category,
+		RequiredCertification: input.RequiredCertification,
+	}
+	if err := repository.CreateSkill(database.DbConnection, &skill); err != nil {
+		skillErrorResponse(c, err, "Gagal menambahkan keahlian")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Keahlian berhasil ditambahkan",
+		"result":  skill,
+	})
+}
+
+// GetVolunteerSkills godoc
+// @Summary Get volunteer skills
+// @Description Mendapatkan keahlian relawan beserta tingkat kemahirannya
+// @Tags Skill
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/skills [get]
+func GetVolunteerSkills(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	skills, err := repository.GetVolunteerSkills(database.DbConnection, id)
+	if err != nil {
+		skillErrorResponse(c, err, "Gagal mendapatkan keahlian relawan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": skills,
+	})
+}
+
+// SetVolunteerSkills godoc
+// @Summary Replace volunteer skills
+// @Description Mengganti seluruh keahlian relawan. Keahlian ditulis sebagai kode atau nama dari taksonomi, tingkat kemahiran default 'intermediate'
+// @Tags Skill
+// @Accept json
+// @Produce json
+// @Param id path int true "Volunteer ID"
+// @Param input body []structs.VolunteerSkillInput true "Daftar keahlian"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /volunteers/{id}/skills [put]
+func SetVolunteerSkills(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input []structs.VolunteerSkillInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	skills, err := repository.SetVolunteerSkills(database.DbConnection, id, input)
+	if err != nil {
+		skillErrorResponse(c, err, "Gagal memperbarui keahlian relawan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Keahlian relawan berhasil diperbarui",
+		"result":  skills,
+	})
+}
+
+// GetTaskMatches godoc
+// @Summary Rank volunteers for a task
+// @Description Memberi peringkat relawan yang cocok untuk tugas berdasarkan kecocokan keahlian, jarak, status, dan beban kerja. Relawan yang sudah ditugaskan tidak ikut diperingkat
+// @Tags Skill
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param skills query string false "Keahlian pengganti, dipisah koma (default keahlian yang dibutuhkan tugas)"
+// @Param limit query int false "Jumlah relawan (default 10, maksimal 50)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id}/matches [get]
+func GetTaskMatches(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	skills, limit, ok := parseMatchQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := repository.MatchVolunteersForTask(database.DbConnection, id, skills, limit)
+	if err != nil {
+		skillErrorResponse(c, err, "Gagal mencocokkan relawan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": result,
+	})
+}
+
+// GetEmergencyReportMatches godoc
+// @Summary Rank volunteers for an emergency report
+// @Description Memberi peringkat relawan yang cocok untuk laporan darurat. Tanpa parameter skills, keahlian mengikuti kategori triase (immediate: medical dan first_aid, delayed/minor: first_aid, deceased: search_and_rescue)
+// @Tags Skill
+// @Produce json
+// @Param id path int true "Emergency Report ID"
+// @Param skills query string false "Keahlian yang dibutuhkan, dipisah koma"
+// @Param limit query int false "Jumlah relawan (default 10, maksimal 50)"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /emergency_reports/{id}/matches [get]
+func GetEmergencyReportMatches(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	skills, limit, ok := parseMatchQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := repository.MatchVolunteersForEmergencyReport(database.DbConnection, id, skills, limit)
+	if err != nil {
+		skillErrorResponse(c, err, "Gagal mencocokkan relawan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": result,
+	})
+}
@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func stockErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "insufficient stock":
+		c.JSON(http.StatusConflict, gin.H{"error": "Stok logistik tidak mencukupi untuk pengeluaran ini"})
+	case "invalid stock quantity":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jumlah stok tidak valid"})
+	case "logistic not found", "logistics not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bantuan logistik tidak ditemukan"})
+	case "invalid coordinates":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan"})
+	case "item not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Barang tidak ditemukan di katalog"})
+	case "batch not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Batch logistik tidak ditemukan"})
+	case "batch expiry mismatch":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nomor batch sudah terdaftar dengan tanggal kedaluwarsa yang berbeda"})
+	case "unknown unit":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Satuan tidak dikenal untuk barang ini"})
+	case "invalid unit conversion":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Jumlah tidak dapat dikonversi ke satuan dasar tanpa pecahan"})
+	case "logistics unit locked":
+		c.JSON(http.StatusConflict, gin.H{"error": "Barang atau satuan logistik tidak bisa diubah karena sudah memiliki riwayat stok"})
+	case "logistic has stock movements":
+		c.JSON(http.StatusConflict, gin.H{"error": "Bantuan logistik tidak bisa dihapus karena sudah memiliki riwayat stok, gunakan penyesuaian stok untuk mengosongkannya"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func parseExpiryDate(c *gin.Context, value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	expiryDate, err := time.Parse("02/01/2006", value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Format tanggal kedaluwarsa harus 'DD/MM/YYYY'",
+		})
+		return nil, false
+	}
+	return &expiryDate, true
+}
+
+// RecordStockMovement godoc
+// @Summary Record a stock movement
+// @Description Mencatat penerimaan (receipt), penyesuaian (adjustment), atau kehilangan (loss) stok logistik. Penerimaan masuk ke batch sesuai batch_number dan expiry_date (DD/MM/YYYY), sedangkan pengurangan diambil dari batch yang paling cepat kedaluwarsa (FEFO) kecuali batch_id diisi. Pengeluaran (dispatch) hanya dicatat melalui log distribusi
+// @Tags Logistic
+// @Accept json
+// @Produce json
+// @Param id path int true "Logistic ID"
+// @Param input body structs.StockMovementInput true "Data pergerakan stok"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /logistics/{id}/movements [post]
+func RecordStockMovement(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.StockMovementInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, movement_type dan quantity wajib diisi",
+		})
+		return
+	}
+
+	expiryDate, ok := parseExpiryDate(c, input.ExpiryDate)
+	if !ok {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	batch := structs.StockBatchInput{BatchID: input.BatchID, BatchNumber: input.BatchNumber, ExpiryDate: expiryDate}
+	movements, err := repository.RecordStockMovement(database.DbConnection, id, input, batch, currentUser.ID)
+	if err != nil {
+		switch err.Error() {
+		case "invalid stock movement type":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Jenis pergerakan stok tidak valid, hanya bisa 'receipt', 'adjustment', atau 'loss'"})
+		case "dispatch requires distribution log":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Pengeluaran stok harus dicatat melalui log distribusi"})
+		default:
+			stockErrorResponse(c, err, "Gagal mencatat pergerakan stok")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Pergerakan stok berhasil dicatat",
+		"result":  movements,
+	})
+}
+
+// GetStockLedger godoc
+// @Summary Get stock ledger
+// @Description Mendapatkan buku besar pergerakan stok logistik beserta saldo yang dihitung dari seluruh pergerakan
+// @Tags Logistic
+// @Accept json
+// @Produce json
+// @Param id path int true "Logistic ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /logistics/{id}/movements [get]
+func GetStockLedger(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	ledger, err := repository.GetStockLedger(database.DbConnection, id)
+	if err != nil {
+		stockErrorResponse(c, err, "Gagal mendapatkan buku besar stok")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": ledger,
+	})
+}
+
+// GetLogisticBatches godoc
+// @Summary Get logistic batches
+// @Description Mendapatkan daftar batch/lot stok logistik beserta tanggal kedaluwarsa, diurutkan sesuai urutan pengeluaran FEFO
+// @Tags Logistic
+// @Accept json
+// @Produce json
+// @Param id path int true "Logistic ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /logistics/{id}/batches [get]
+func GetLogisticBatches(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	batches, err := repository.GetLogisticBatches(database.DbConnection, id)
+	if err != nil {
+		stockErrorResponse(c, err, "Gagal mendapatkan daftar batch logistik")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": batches,
+	})
+}
+
+// GetExpiringLogistics godoc
+// @Summary Get expiring logistics
+// @Description Mendapatkan batch logistik yang akan kedaluwarsa dalam N hari ke depan, termasuk yang sudah kedaluwarsa namun masih tersisa
+// @Tags Logistic
+// @Accept json
+// @Produce json
+// @Param days query int false "Jumlah hari ke depan (default 30)"
+// @Param disaster_id query int false "Filter berdasarkan bencana"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /logistics/expiring [get]
+func GetExpiringLogistics(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parameter days harus berupa bilangan bulat positif",
+		})
+		return
+	}
+
+	var disasterID *int
+	if value := c.Query("disaster_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "ID bencana tidak valid",
+			})
+			return
+		}
+		disasterID = &id
+	}
+
+	batches, err := repository.GetExpiringBatches(database.DbConnection, days, disasterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Gagal mendapatkan daftar logistik yang akan kedaluwarsa",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": batches,
+	})
+}
@@ -0,0 +1,482 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func taskErrorResponse(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "task not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tugas tidak ditemukan"})
+	case "task assignee not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Relawan tidak ditugaskan di tugas ini"})
+	case "task title required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Judul tugas wajib diisi"})
+	case "invalid need priority":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Prioritas tidak valid, hanya bisa 'critical', 'high', 'medium', atau 'low'"})
+	case "invalid status transition":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Perubahan status tidak valid. Alur status: open/assigned -> in_progress <-> blocked -> done, dan cancelled dari status mana pun sebelum selesai"})
+	case "task link not in disaster":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Laporan darurat, shelter, dan distribusi harus berada di bencana yang sama dengan tugas"})
+	case "task closed":
+		c.JSON(http.StatusConflict, gin.H{"error": "Tugas sudah selesai atau dibatalkan"})
+	case "volunteer already assigned":
+		c.JSON(http.StatusConflict, gin.H{"error": "Relawan sudah ditugaskan di tugas ini"})
+	case "emergency report not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Laporan darurat tidak ditemukan"})
+	case "distribution log not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Log distribusi tidak ditemukan"})
+	default:
+		shiftErrorResponse(c, err, fallback)
+	}
+}
+
+// Status tugas hanya bisa diubah admin atau relawan yang ditugaskan
+func canUpdateTask(c *gin.Context, taskID int, user structs.User) bool {
+	if user.Role == "admin" {
+		return true
+	}
+
+	assigned, err := repository.IsTaskAssignee(database.DbConnection, taskID, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal memeriksa penugasan"})
+		return false
+	}
+	if !assigned {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Hanya admin dan relawan yang ditugaskan yang bisa mengubah status tugas"})
+		return false
+	}
+	return true
+}
+
+// CreateTask godoc
+// @Summary Create a field task
+// @Description Membuat tugas lapangan untuk bencana, opsional ditautkan ke laporan darurat, shelter, atau log distribusi. Bencana diambil dari tautan bila disaster_id kosong. Tenggat memakai format DD/MM/YYYY HH:mm
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param input body structs.TaskInput true "Data tugas"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks [post]
+func CreateTask(c *gin.Context) {
+	var input structs.TaskInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	dueAt, ok := parseDateTimeField(c, input.DueAt, "due_at")
+	if !ok {
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	task := structs.Task{
+		EmergencyReportID:      input.EmergencyReportID,
+		ShelterID:              input.ShelterID,
+		DistributionLogID:      input.DistributionLogID,
+		Title:                  input.Title,
+		Description:            input.Description,
+		Priority:               input.Priority,
+		RequiredSkills:         input.RequiredSkills,
+		RequiredCertifications: input.RequiredCertifications,
+		DueAt:                  dueAt,
+		CreatedBy:              &currentUser.ID,
+	}
+	if input.DisasterID != nil {
+		task.DisasterID = *input.DisasterID
+	}
+
+	err := repository.CreateTask(database.DbConnection, &task)
+	if err != nil {
+		taskErrorResponse(c, err, "Gagal membuat tugas")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tugas berhasil dibuat",
+		"result":  task,
+	})
+}
+
+// GetTaskByID godoc
+// @Summary Get task by ID
+// @Description Mendapatkan detail tugas beserta relawan yang ditugaskan, komentar, dan riwayat status
+// @Tags Task
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id} [get]
+func GetTaskByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	task, err := repository.GetTaskByID(database.DbConnection, id)
+	if err != nil {
+		taskErrorResponse(c, err, "Gagal mendapatkan tugas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": task,
+	})
+}
+
+// GetMyTasks godoc
+// @Summary Get my tasks
+// @Description Mendapatkan tugas yang ditugaskan ke relawan milik pengguna yang login, diurutkan dari prioritas tertinggi dan tenggat terdekat. Tugas selesai dan dibatalkan hanya ditampilkan dengan include_closed=true
+// @Tags Task
+// @Produce json
+// @Param include_closed query bool false "Sertakan tugas yang sudah selesai atau dibatalkan"
+// @Success 200 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/mine [get]
+func GetMyTasks(c *gin.Context) {
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := repository.GetTasksByUserID(database.DbConnection, currentUser.ID, c.Query("include_closed") == "true")
+	if err != nil {
+		taskErrorResponse(c, err, "Gagal mendapatkan daftar tugas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": tasks,
+	})
+}
+
+// GetTaskBoard godoc
+// @Summary Get disaster task board
+// @Description Papan tugas bencana yang dikelompokkan per status (open, assigned, in_progress, blocked, done, cancelled)
+// @Tags Task
+// @Produce json
+// @Param id path int true "Disaster ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disasters/{id}/tasks [get]
+func GetTaskBoard(c *gin.Context) {
+	disasterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID bencana tidak valid",
+		})
+		return
+	}
+
+	board, err := repository.GetTaskBoard(database.DbConnection, disasterID)
+	if err != nil {
+		taskErrorResponse(c, err, "Gagal mendapatkan papan tugas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": board,
+	})
+}
+
+// UpdateTask godoc
+// @Summary Update a task
+// @Description Memperbarui judul, deskripsi, prioritas, keahlian yang dibutuhkan, atau tenggat tugas yang belum selesai. Perubahan keahlian menurunkan ulang sertifikasi wajib bila required_certifications tidak diisi, dan ditolak (422) bila relawan yang sudah ditugaskan tidak memenuhinya
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param input body structs.TaskInput true "Data tugas yang diperbarui"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 422 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id} [put]
+func UpdateTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.TaskInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	dueAt, ok := parseDateTimeField(c, input.DueAt, "due_at")
+	if !ok {
+		return
+	}
+
+	err = repository.UpdateTask(database.DbConnection, id, input, dueAt)
+	if err != nil {
+		taskErrorResponse(c, err, "Gagal memperbarui tugas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tugas berhasil diperbarui",
+	})
+}
+
+// UpdateTaskStatus godoc
+// @Summary Update task status
+// @Description Mengubah status tugas oleh admin atau relawan yang ditugaskan. Status assigned dan open diatur otomatis saat relawan ditambah atau dilepas
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param input body structs.TaskStatusInput true "Status baru: in_progress, blocked, done, atau cancelled"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 403 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id}/status [put]
+func UpdateTaskStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.TaskStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+	if !canUpdateTask(c, id, currentUser) {
+		return
+	}
+
+	err = repository.UpdateTaskStatus(database.DbConnection, id, input.Status, input.Note, currentUser.ID)
+	if err != nil {
+		fmt.Println("Error Query:", err)
+		taskErrorResponse(c, err, "Gagal mengubah status tugas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Status tugas berhasil diubah",
+	})
+}
+
+// DeleteTask godoc
+// @Summary Delete a task
+// @Description Menghapus tugas beserta penugasan, komentar, dan riwayat statusnya
+// @Tags Task
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id} [delete]
+func DeleteTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	if err := repository.DeleteTask(database.DbConnection, id); err != nil {
+		taskErrorResponse(c, err, "Gagal menghapus tugas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tugas berhasil dihapus",
+	})
+}
+
+// AssignTask godoc
+// @Summary Assign a volunteer to a task
+// @Description Menugaskan relawan ke tugas. Tugas berstatus open otomatis menjadi assigned
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param input body structs.TaskAssignInput true "Relawan yang ditugaskan"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id}/assignees [post]
+func AssignTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.TaskAssignInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.AssignTask(database.DbConnection, id, input.VolunteerID, currentUser.ID)
+	if err != nil {
+		taskErrorResponse(c, err, "Gagal menugaskan relawan")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Relawan berhasil ditugaskan",
+	})
+}
+
+// UnassignTask godoc
+// @Summary Remove a volunteer from a task
+// @Description Melepas relawan dari tugas. Tugas berstatus assigned kembali menjadi open bila tidak ada relawan tersisa
+// @Tags Task
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param volunteer_id path int true "Volunteer ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 409 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id}/assignees/{volunteer_id} [delete]
+func UnassignTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+	volunteerID, err := strconv.Atoi(c.Param("volunteer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID relawan tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	err = repository.UnassignTask(database.DbConnection, id, volunteerID, currentUser.ID)
+	if err != nil {
+		taskErrorResponse(c, err, "Gagal melepas relawan dari tugas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Relawan berhasil dilepas dari tugas",
+	})
+}
+
+// AddTaskComment godoc
+// @Summary Comment on a task
+// @Description Menambahkan komentar atau laporan perkembangan pada tugas
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param input body structs.TaskCommentInput true "Isi komentar"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /tasks/{id}/comments [post]
+func AddTaskComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	var input structs.TaskCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid",
+		})
+		return
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	comment := structs.TaskComment{TaskID: id, UserID: &currentUser.ID, UserName: currentUser.Name, Body: input.Body}
+	if err := repository.AddTaskComment(database.DbConnection, &comment); err != nil {
+		taskErrorResponse(c, err, "Gagal menambahkan komentar")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Komentar berhasil ditambahkan",
+		"result":  comment,
+	})
+}
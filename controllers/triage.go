@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"RescueHub/database"
+	"RescueHub/repository"
+	"RescueHub/structs"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func bindTriage(c *gin.Context) (int, structs.TriageInput, structs.User, bool) {
+	var input structs.TriageInput
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return 0, input, structs.User{}, false
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Input tidak valid, category wajib diisi",
+		})
+		return 0, input, structs.User{}, false
+	}
+
+	currentUser, ok := getCurrentUser(c)
+	return id, input, currentUser, ok
+}
+
+func triageErrorResponse(c *gin.Context, err error, notFound string) {
+	switch err.Error() {
+	case "invalid triage category":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Kategori triase tidak valid, hanya bisa 'immediate', 'delayed', 'minor', atau 'deceased'"})
+	case "triage target not found", "refugee not found", "emergency report not found", "shelter not found", "disaster not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal memproses data triase"})
+	}
+}
+
+// TriageRefugee godoc
+// @Summary Triage a refugee
+// @Description Mencatat triase atau triase ulang pengungsi dengan skala START (immediate, delayed, minor, deceased)
+// @Tags Triage
+// @Accept json
+// @Produce json
+// @Param id path int true "Refugee ID"
+// @Param input body structs.TriageInput true "Kategori triase"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /refugees/{id}/triage [post]
+func TriageRefugee(c *gin.Context) {
+	id, input, currentUser, ok := bindTriage(c)
+	if !ok {
+		return
+	}
+
+	assessment, err := repository.TriageRefugee(database.DbConnection, id, input, currentUser.ID)
+	if err != nil {
+		triageErrorResponse(c, err, "Data pengungsi tidak ditemukan")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Triase pengungsi berhasil dicatat",
+		"result":  assessment,
+	})
+}
+
+// GetRefugeeTriageHistory godoc
+// @Summary Get refugee triage history
+// @Description Mendapatkan riwayat triase pengungsi
+// @Tags Triage
+// @Accept json
+// @Produce json
+// @Param id path int true "Refugee ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /refugees/{id}/triage [get]
+func GetRefugeeTriageHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	assessments, err := repository.GetRefugeeTriageHistory(database.DbConnection, id)
+	if err != nil {
+		triageErrorResponse(c, err, "Data pengungsi tidak ditemukan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": assessments,
+	})
+}
+
+// TriageEmergencyReport godoc
+// @Summary Triage an emergency report
+// @Description Mencatat tingkat urgensi laporan darurat dengan skala START (immediate, delayed, minor, deceased)
+// @Tags Triage
+// @Accept json
+// @Produce json
+// @Param id path int true "Emergency Report ID"
+// @Param input body structs.TriageInput true "Kategori triase"
+// @Success 201 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /emergency_reports/{id}/triage [post]
+func TriageEmergencyReport(c *gin.Context) {
+	id, input, currentUser, ok := bindTriage(c)
+	if !ok {
+		return
+	}
+
+	assessment, err := repository.TriageEmergencyReport(database.DbConnection, id, input, currentUser.ID)
+	if err != nil {
+		triageErrorResponse(c, err, "Laporan darurat tidak ditemukan")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Triase laporan darurat berhasil dicatat",
+		"result":  assessment,
+	})
+}
+
+// GetEmergencyReportTriageHistory godoc
+// @Summary Get emergency report triage history
+// @Description Mendapatkan riwayat triase laporan darurat
+// @Tags Triage
+// @Accept json
+// @Produce json
+// @Param id path int true "Emergency Report ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /emergency_reports/{id}/triage [get]
+func GetEmergencyReportTriageHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	assessments, err := repository.GetEmergencyReportTriageHistory(database.DbConnection, id)
+	if err != nil {
+		triageErrorResponse(c, err, "Laporan darurat tidak ditemukan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": assessments,
+	})
+}
+
+// GetShelterTriageSummary godoc
+// @Summary Get shelter triage summary
+// @Description Mendapatkan jumlah pengungsi per kategori triase di shelter tertentu
+// @Tags Triage
+// @Accept json
+// @Produce json
+// @Param id path int true "Shelter ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /shelters/{id}/triage [get]
+func GetShelterTriageSummary(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	summary, err := repository.GetShelterTriageSummary(database.DbConnection, id)
+	if err != nil {
+		triageErrorResponse(c, err, "Shelter tidak ditemukan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": summary,
+	})
+}
+
+// GetDisasterTriageSummary godoc
+// @Summary Get disaster triage summary
+// @Description Mendapatkan jumlah pengungsi dan laporan darurat per kategori triase, beserta rincian per shelter yang diurutkan dari kasus kritis terbanyak
+// @Tags Triage
+// @Accept json
+// @Produce json
+// @Param id path int true "Disaster ID"
+// @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
+// @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
+// @Security BearerAuth
+// @Router /disasters/{id}/triage [get]
+func GetDisasterTriageSummary(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ID tidak valid",
+		})
+		return
+	}
+
+	summary, err := repository.GetDisasterTriageSummary(database.DbConnection, id)
+	if err != nil {
+		triageErrorResponse(c, err, "Bencana tidak ditemukan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": summary,
+	})
+}
@@ -591,7 +591,7 @@ func UpdateUserInfoWithoutEmail(c *gin.Context) {
 
 // GetDonationsByUserID godoc
 // @Summary Get donations by user ID
-// @Description Menampilkan daftar donasi yang diberikan oleh user tertentu
+// @Description Menampilkan daftar donasi yang diberikan oleh user tertentu beserta total donasi uang terkonfirmasi per mata uang dan dalam mata uang laporan
 // @Tags Users
 // @Produce json
 // @Param id path int true "User ID"
@@ -619,7 +619,13 @@ func GetDonationsByUserID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"result": donations})
+	totals, err := repository.GetDonationTotalsByDonorID(database.DbConnection, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menghitung total donasi"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": donations, "totals": totals})
 }
 
 // GetEmergencyReportsByUserID godoc
@@ -653,4 +659,19 @@ func GetEmergencyReportsByUserID(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"result": reports})
-}
\ No newline at end of file
+}
+
+func getCurrentUser(c *gin.Context) (structs.User, bool) {
+	email, exists := c.Get("email")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Tidak dapat mengidentifikasi pengguna"})
+		return structs.User{}, false
+	}
+
+	currentUser, err := repository.GetUserByEmail(database.DbConnection, email.(string))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Pengguna tidak ditemukan"})
+		return structs.User{}, false
+	}
+	return currentUser, true
+}
@@ -37,10 +37,19 @@ func CreateVolunteer(c *gin.Context) {
 		Skill			: input.Skill,
 		Location	: input.Location,
 		Status		: input.Status,
+		Latitude:         input.Latitude,
+		Longitude:        input.Longitude,
+		LocationAccuracy: input.LocationAccuracy,
 	}
 
 	err := repository.CreateVolunteer(database.DbConnection, volunteer)
 	if err != nil {
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
 		if err.Error() == "invalid volunteer status" {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Status relawan tidak valid, hanya bisa 'available', 'on_mission', atau 'completed'",
@@ -66,11 +75,43 @@ func CreateVolunteer(c *gin.Context) {
 // @Tags Volunteer
 // @Accept json
 // @Produce json
+// @Param near query string false "Titik pusat pencarian dalam format lat,lon"
+// @Param radius_km query number false "Radius pencarian dalam kilometer (wajib bersama near)"
+// @Param bbox query string false "Bounding box dalam format min_lat,min_lon,max_lat,max_lon"
 // @Success 200 {object} structs.APIResponse
+// @Failure 400 {object} structs.APIResponse
 // @Failure 404 {object} structs.APIResponse
+// @Failure 500 {object} structs.APIResponse
 // @Security BearerAuth
 // @Router /volunteers [get]
 func GetAllVolunteers(c *gin.Context) {
+	filter, err := parseGeoFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parameter lokasi tidak valid, gunakan near=lat,lon&radius_km=... atau bbox=min_lat,min_lon,max_lat,max_lon",
+		})
+		return
+	}
+
+	// Pencarian berdasarkan lokasi mengembalikan daftar kosong bila tidak ada relawan di area tersebut
+	if filter != nil {
+		volunteers, err := repository.GetVolunteersByArea(database.DbConnection, *filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Gagal mendapatkan daftar relawan",
+			})
+			return
+		}
+		if volunteers == nil {
+			volunteers = []structs.Volunteer{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"result": volunteers,
+		})
+		return
+	}
+
 	volunteers, err := repository.GetAllVolunteers(database.DbConnection)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
@@ -183,9 +224,29 @@ func UpdateVolunteer(c *gin.Context) {
 			volunteer.Status = statusStr
 		}
 	}
+	for field, target := range map[string]**float64{
+		"latitude":          &volunteer.Latitude,
+		"longitude":         &volunteer.Longitude,
+		"location_accuracy": &volunteer.LocationAccuracy,
+	} {
+		if value, exists := input[field]; exists {
+			number, ok := value.(float64)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Koordinat tidak valid"})
+				return
+			}
+			*target = &number
+		}
+	}
 
 	err = repository.UpdateVolunteer(database.DbConnection, volunteer)
 	if err != nil {
+		if err.Error() == "invalid coordinates" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Koordinat tidak valid, latitude (-90 s/d 90) dan longitude (-180 s/d 180) harus diisi bersamaan",
+			})
+			return
+		}
 		if err.Error() == "invalid volunteer status" {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Status volunteer tidak valid, hanya bisa 'available', 'on_mission', atau 'completed'",
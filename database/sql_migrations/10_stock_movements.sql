@@ -0,0 +1,47 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Buku besar pergerakan stok logistik (append-only)
+CREATE TYPE stock_movement_type AS ENUM ('receipt', 'dispatch', 'adjustment', 'loss');
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id SERIAL PRIMARY KEY,
+    logistic_id INT NOT NULL REFERENCES logistics(id) ON DELETE CASCADE,
+    movement_type stock_movement_type NOT NULL,
+    quantity INT NOT NULL CHECK (quantity <> 0),
+    balance_after INT NOT NULL CHECK (balance_after >= 0),
+    distribution_log_id INT REFERENCES distribution_logs(id) ON DELETE SET NULL,
+    note TEXT,
+    recorded_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_logistic_id ON stock_movements (logistic_id, id);
+
+-- Saldo awal dari kuantitas logistik yang sudah tercatat
+INSERT INTO stock_movements (logistic_id, movement_type, quantity, balance_after, note, created_at)
+SELECT id, 'receipt', quantity, quantity, 'Saldo awal', created_at FROM logistics WHERE quantity > 0;
+
+-- Distribusi lama yang belum pernah mengurangi stok
+INSERT INTO stock_movements (logistic_id, movement_type, quantity, balance_after, distribution_log_id, note, created_at)
+SELECT d.logistic_id, 'dispatch', -d.quantity_sent,
+       GREATEST(l.quantity - SUM(d.quantity_sent) OVER (PARTITION BY d.logistic_id ORDER BY d.id), 0),
+       d.id, 'Distribusi sebelum pencatatan stok', d.created_at
+FROM distribution_logs d JOIN logistics l ON l.id = d.logistic_id
+WHERE d.quantity_sent > 0;
+
+-- Koreksi saldo negatif akibat distribusi lama yang melebihi stok
+INSERT INTO stock_movements (logistic_id, movement_type, quantity, balance_after, note)
+SELECT logistic_id, 'adjustment', -SUM(quantity), 0, 'Koreksi saldo negatif saat migrasi'
+FROM stock_movements GROUP BY logistic_id HAVING SUM(quantity) < 0;
+
+UPDATE logistics l SET
+    quantity = COALESCE((SELECT SUM(m.quantity) FROM stock_movements m WHERE m.logistic_id = l.id), 0),
+    status = CASE
+        WHEN COALESCE((SELECT SUM(m.quantity) FROM stock_movements m WHERE m.logistic_id = l.id), 0) <= 0 THEN 'out_of_stock'
+        WHEN EXISTS (SELECT 1 FROM stock_movements m WHERE m.logistic_id = l.id AND m.movement_type = 'dispatch') THEN 'distributed'
+        ELSE 'available'
+    END::logistics_status,
+    updated_at = NOW();
+
+-- +migrate StatementEnd
@@ -0,0 +1,47 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Katalog barang logistik beserta satuan dasarnya
+CREATE TABLE IF NOT EXISTS items (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    category VARCHAR(100),
+    base_unit VARCHAR(50) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Konversi satuan lain ke satuan dasar (misal 1 dus = 24 pcs)
+CREATE TABLE IF NOT EXISTS item_units (
+    id SERIAL PRIMARY KEY,
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    unit VARCHAR(50) NOT NULL,
+    factor DECIMAL(12,4) NOT NULL CHECK (factor > 0),
+    UNIQUE (item_id, unit)
+);
+
+ALTER TABLE logistics ADD COLUMN IF NOT EXISTS item_id INT REFERENCES items(id) ON DELETE SET NULL;
+ALTER TABLE logistics ADD COLUMN IF NOT EXISTS unit VARCHAR(50);
+
+-- Batch/lot stok dengan tanggal kedaluwarsa
+CREATE TABLE IF NOT EXISTS logistic_batches (
+    id SERIAL PRIMARY KEY,
+    logistic_id INT NOT NULL REFERENCES logistics(id) ON DELETE CASCADE,
+    batch_number VARCHAR(100) NOT NULL,
+    expiry_date DATE,
+    quantity_received INT NOT NULL DEFAULT 0,
+    quantity_remaining INT NOT NULL DEFAULT 0 CHECK (quantity_remaining >= 0),
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (logistic_id, batch_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_logistic_batches_expiry ON logistic_batches (expiry_date) WHERE quantity_remaining > 0;
+
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS batch_id INT REFERENCES logistic_batches(id) ON DELETE SET NULL;
+
+-- Saldo yang sudah ada dipindahkan ke satu batch tanpa tanggal kedaluwarsa
+INSERT INTO logistic_batches (logistic_id, batch_number, quantity_received, quantity_remaining, received_at)
+SELECT id, 'AWAL-' || id, quantity, quantity, created_at FROM logistics WHERE quantity > 0;
+
+-- +migrate StatementEnd
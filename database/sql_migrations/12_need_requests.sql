@@ -0,0 +1,35 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Lokasi gudang/posko tempat stok logistik disimpan
+ALTER TABLE logistics
+    ADD COLUMN IF NOT EXISTS location VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+-- Permintaan kebutuhan terstruktur per shelter
+CREATE TYPE need_priority AS ENUM ('critical', 'high', 'medium', 'low');
+CREATE TYPE need_request_status AS ENUM ('open', 'partially_fulfilled', 'fulfilled', 'cancelled');
+
+CREATE TABLE IF NOT EXISTS need_requests (
+    id SERIAL PRIMARY KEY,
+    shelter_id INT NOT NULL REFERENCES shelters(id) ON DELETE CASCADE,
+    item_id INT REFERENCES items(id) ON DELETE SET NULL,
+    item_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    quantity_fulfilled INT NOT NULL DEFAULT 0,
+    unit VARCHAR(50),
+    priority need_priority NOT NULL DEFAULT 'medium',
+    status need_request_status NOT NULL DEFAULT 'open',
+    note TEXT,
+    requested_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_need_requests_shelter_id ON need_requests (shelter_id, status);
+
+-- Distribusi yang dibuat dari rekomendasi alokasi
+ALTER TABLE distribution_logs ADD COLUMN IF NOT EXISTS need_request_id INT REFERENCES need_requests(id) ON DELETE SET NULL;
+
+-- +migrate StatementEnd
@@ -0,0 +1,45 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Status pengiriman distribusi bantuan
+CREATE TYPE distribution_status AS ENUM ('planned', 'dispatched', 'in_transit', 'delivered', 'confirmed', 'failed', 'returned');
+
+ALTER TABLE distribution_logs
+    ADD COLUMN IF NOT EXISTS status distribution_status NOT NULL DEFAULT 'dispatched',
+    ADD COLUMN IF NOT EXISTS confirmation_code VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS quantity_received INT CHECK (quantity_received >= 0),
+    ADD COLUMN IF NOT EXISTS discrepancy_reason TEXT,
+    ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMP;
+
+-- Kode konfirmasi untuk distribusi lama
+UPDATE distribution_logs SET confirmation_code = UPPER(SUBSTRING(MD5(RANDOM()::TEXT || id::TEXT) FROM 1 FOR 6)) WHERE confirmation_code IS NULL;
+
+-- Riwayat perubahan status beserta waktunya
+CREATE TABLE IF NOT EXISTS distribution_status_history (
+    id SERIAL PRIMARY KEY,
+    distribution_log_id INT NOT NULL REFERENCES distribution_logs(id) ON DELETE CASCADE,
+    status distribution_status NOT NULL,
+    note TEXT,
+    changed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO distribution_status_history (distribution_log_id, status, note, changed_at)
+SELECT id, 'dispatched', 'Distribusi sebelum pencatatan status', sent_at FROM distribution_logs;
+
+CREATE INDEX IF NOT EXISTS idx_distribution_status_history_log_id ON distribution_status_history (distribution_log_id, changed_at);
+
+-- Bukti serah terima (tanda tangan atau foto)
+CREATE TABLE IF NOT EXISTS distribution_proofs (
+    id SERIAL PRIMARY KEY,
+    distribution_log_id INT NOT NULL REFERENCES distribution_logs(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('signature', 'photo')),
+    file_name VARCHAR(255),
+    content_type VARCHAR(100) NOT NULL,
+    data BYTEA NOT NULL,
+    uploaded_by INT REFERENCES users(id) ON DELETE SET NULL,
+    uploaded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate StatementEnd
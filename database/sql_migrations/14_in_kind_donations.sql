@@ -0,0 +1,17 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Donasi barang: jumlah disimpan di kolom amount dalam satuan donasi
+ALTER TABLE donations
+    ADD COLUMN IF NOT EXISTS item_id INT REFERENCES items(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS unit VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS expiry_date DATE,
+    ADD COLUMN IF NOT EXISTS logistic_id INT REFERENCES logistics(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS received_at TIMESTAMP;
+
+-- Batch stok yang berasal dari donasi, untuk menelusuri barang donasi sampai distribusi
+ALTER TABLE logistic_batches ADD COLUMN IF NOT EXISTS donation_id INT REFERENCES donations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_logistic_batches_donation_id ON logistic_batches (donation_id);
+
+-- +migrate StatementEnd
@@ -0,0 +1,23 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Kuitansi resmi untuk donasi yang sudah dikonfirmasi
+CREATE SEQUENCE IF NOT EXISTS donation_receipt_number_seq;
+
+CREATE TABLE IF NOT EXISTS donation_receipts (
+    id SERIAL PRIMARY KEY,
+    donation_id INT NOT NULL UNIQUE REFERENCES donations(id) ON DELETE CASCADE,
+    receipt_number VARCHAR(50) NOT NULL UNIQUE,
+    verification_code VARCHAR(20) NOT NULL UNIQUE,
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Kuitansi untuk donasi yang sudah dikonfirmasi sebelumnya
+INSERT INTO donation_receipts (donation_id, receipt_number, verification_code, issued_at)
+SELECT id,
+       'RH/' || TO_CHAR(updated_at, 'YYYY') || '/' || LPAD(NEXTVAL('donation_receipt_number_seq')::TEXT, 6, '0'),
+       UPPER(SUBSTRING(MD5(RANDOM()::TEXT || id::TEXT) FROM 1 FOR 12)),
+       updated_at
+FROM donations WHERE status = 'confirmed' ORDER BY id;
+
+-- +migrate StatementEnd
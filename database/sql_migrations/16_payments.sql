@@ -0,0 +1,35 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Pembayaran donasi uang melalui payment gateway
+CREATE TYPE payment_status AS ENUM ('pending', 'paid', 'failed', 'expired');
+
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id SERIAL PRIMARY KEY,
+    donation_id INT NOT NULL REFERENCES donations(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    provider_reference VARCHAR(100) NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    status payment_status NOT NULL DEFAULT 'pending',
+    redirect_url TEXT,
+    paid_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, provider_reference)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_intents_donation_id ON payment_intents (donation_id);
+
+-- Webhook yang sudah terverifikasi, event yang sama tidak diproses dua kali
+CREATE TABLE IF NOT EXISTS payment_webhook_events (
+    id SERIAL PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    event_id VARCHAR(100) NOT NULL,
+    provider_reference VARCHAR(100) NOT NULL,
+    status payment_status NOT NULL,
+    payload TEXT NOT NULL,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, event_id)
+);
+
+-- +migrate StatementEnd
@@ -0,0 +1,35 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Nominal donasi diperlebar agar tidak terbatas di bawah 100 juta
+ALTER TABLE donations ALTER COLUMN amount TYPE DECIMAL(18,2);
+ALTER TABLE payment_intents ALTER COLUMN amount TYPE DECIMAL(18,2);
+
+-- Mata uang ISO 4217 per donasi uang beserta konversi ke mata uang laporan saat donasi dicatat
+ALTER TABLE donations
+    ADD COLUMN IF NOT EXISTS currency VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(24,10),
+    ADD COLUMN IF NOT EXISTS reporting_currency VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS amount_reporting DECIMAL(24,2);
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'IDR';
+
+-- Kurs: 1 unit currency = rate unit base_currency
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id SERIAL PRIMARY KEY,
+    base_currency VARCHAR(3) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(24,10) NOT NULL CHECK (rate > 0),
+    source VARCHAR(50),
+    updated_by INT REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (base_currency, currency)
+);
+
+INSERT INTO exchange_rates (base_currency, currency, rate, source) VALUES ('IDR', 'IDR', 1, 'default');
+
+-- Donasi uang sebelumnya dianggap dalam Rupiah
+UPDATE donations SET currency = 'IDR', exchange_rate = 1, reporting_currency = 'IDR', amount_reporting = amount
+WHERE COALESCE(item_name, '') = '' AND item_id IS NULL;
+
+-- +migrate StatementEnd
@@ -0,0 +1,53 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Pengeluaran dana donasi
+CREATE TABLE IF NOT EXISTS disbursements (
+    id SERIAL PRIMARY KEY,
+    disaster_id INT REFERENCES disasters(id) ON DELETE SET NULL,
+    amount DECIMAL(18,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    recipient VARCHAR(255) NOT NULL,
+    purpose TEXT NOT NULL,
+    disbursed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    disbursed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_disbursements_disaster_id ON disbursements (disaster_id);
+
+-- Buku besar donasi dan pengeluaran, setiap entri menyimpan hash entri sebelumnya
+-- Tanpa foreign key agar penghapusan data sumber tidak pernah mengubah entri
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    sequence BIGINT PRIMARY KEY,
+    entry_type VARCHAR(20) NOT NULL CHECK (entry_type IN ('donation', 'disbursement')),
+    reference_id INT NOT NULL,
+    disaster_id INT,
+    amount DECIMAL(18,2) NOT NULL,
+    currency VARCHAR(3),
+    unit VARCHAR(50),
+    description TEXT,
+    recorded_at TIMESTAMP NOT NULL,
+    previous_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE,
+    UNIQUE (entry_type, reference_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_disaster_id ON ledger_entries (disaster_id);
+
+-- Entri buku besar hanya boleh ditambah
+CREATE OR REPLACE FUNCTION reject_ledger_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entries_no_update_delete
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER ledger_entries_no_truncate
+    BEFORE TRUNCATE ON ledger_entries
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
+
+-- +migrate StatementEnd
@@ -0,0 +1,38 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Jadwal shift relawan per lokasi atau shelter
+CREATE TABLE IF NOT EXISTS volunteer_shifts (
+    id SERIAL PRIMARY KEY,
+    disaster_id INT NOT NULL REFERENCES disasters(id) ON DELETE CASCADE,
+    shelter_id INT REFERENCES shelters(id) ON DELETE SET NULL,
+    location VARCHAR(255) NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    required_skills TEXT[] NOT NULL DEFAULT '{}',
+    headcount INT NOT NULL CHECK (headcount > 0),
+    note TEXT,
+    created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_shifts_disaster_id ON volunteer_shifts (disaster_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_shifts_shelter_id ON volunteer_shifts (shelter_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_shifts_time ON volunteer_shifts (start_time, end_time);
+
+-- Relawan yang mendaftar sendiri (signup) atau ditugaskan koordinator (assigned)
+CREATE TABLE IF NOT EXISTS shift_assignments (
+    id SERIAL PRIMARY KEY,
+    shift_id INT NOT NULL REFERENCES volunteer_shifts(id) ON DELETE CASCADE,
+    volunteer_id INT NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('signup', 'assigned')),
+    assigned_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (shift_id, volunteer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shift_assignments_volunteer_id ON shift_assignments (volunteer_id);
+
+-- +migrate StatementEnd
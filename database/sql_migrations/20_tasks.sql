@@ -0,0 +1,61 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Tugas lapangan untuk relawan
+CREATE TYPE task_status AS ENUM ('open', 'assigned', 'in_progress', 'blocked', 'done', 'cancelled');
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id SERIAL PRIMARY KEY,
+    disaster_id INT NOT NULL REFERENCES disasters(id) ON DELETE CASCADE,
+    emergency_report_id INT REFERENCES emergency_reports(id) ON DELETE SET NULL,
+    shelter_id INT REFERENCES shelters(id) ON DELETE SET NULL,
+    distribution_log_id INT REFERENCES distribution_logs(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority need_priority NOT NULL DEFAULT 'medium',
+    status task_status NOT NULL DEFAULT 'open',
+    required_skills TEXT[] NOT NULL DEFAULT '{}',
+    due_at TIMESTAMP,
+    created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_disaster_id ON tasks (disaster_id, status);
+CREATE INDEX IF NOT EXISTS idx_tasks_emergency_report_id ON tasks (emergency_report_id);
+
+CREATE TABLE IF NOT EXISTS task_assignees (
+    id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    volunteer_id INT NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    assigned_by INT REFERENCES users(id) ON DELETE SET NULL,
+    assigned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (task_id, volunteer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_assignees_volunteer_id ON task_assignees (volunteer_id);
+
+CREATE TABLE IF NOT EXISTS task_comments (
+    id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON task_comments (task_id, created_at);
+
+-- Riwayat perubahan status tugas
+CREATE TABLE IF NOT EXISTS task_status_history (
+    id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    status task_status NOT NULL,
+    note TEXT,
+    changed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_status_history_task_id ON task_status_history (task_id, changed_at);
+
+-- +migrate StatementEnd
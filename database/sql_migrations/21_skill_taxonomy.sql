@@ -0,0 +1,79 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Kode keahlian dari teks bebas, misal "Pertolongan Pertama" menjadi pertolongan_pertama
+CREATE OR REPLACE FUNCTION skill_code(value TEXT) RETURNS TEXT AS $$
+    SELECT TRIM(BOTH '_' FROM REGEXP_REPLACE(LOWER(TRIM(value)), '[^a-z0-9]+', '_', 'g'));
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Taksonomi keahlian relawan
+CREATE TYPE skill_proficiency AS ENUM ('beginner', 'intermediate', 'advanced', 'expert');
+
+CREATE TABLE IF NOT EXISTS skills (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(50) NOT NULL DEFAULT 'lainnya',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO skills (code, name, category) VALUES
+    ('first_aid', 'Pertolongan Pertama', 'medis'),
+    ('medical', 'Tenaga Medis', 'medis'),
+    ('nursing', 'Keperawatan', 'medis'),
+    ('psychosocial', 'Dukungan Psikososial', 'medis'),
+    ('search_and_rescue', 'Pencarian dan Penyelamatan', 'penyelamatan'),
+    ('water_rescue', 'Penyelamatan di Air', 'penyelamatan'),
+    ('firefighting', 'Pemadaman Kebakaran', 'penyelamatan'),
+    ('logistics', 'Logistik', 'logistik'),
+    ('driving', 'Pengemudi', 'logistik'),
+    ('cooking', 'Dapur Umum', 'logistik'),
+    ('shelter_management', 'Manajemen Shelter', 'shelter'),
+    ('childcare', 'Pendampingan Anak', 'shelter'),
+    ('communication', 'Komunikasi dan Radio', 'koordinasi'),
+    ('translation', 'Penerjemah', 'koordinasi'),
+    ('construction', 'Konstruksi dan Perbaikan', 'teknis')
+ON CONFLICT (code) DO NOTHING;
+
+-- Keahlian teks bebas yang sudah dipakai relawan, shift, dan tugas ikut masuk taksonomi
+INSERT INTO skills (code, name, category)
+SELECT DISTINCT ON (skill_code(name)) skill_code(name), INITCAP(TRIM(name)), 'lainnya'
+FROM (
+    SELECT skill AS name FROM volunteers
+    UNION ALL SELECT UNNEST(required_skills) FROM volunteer_shifts
+    UNION ALL SELECT UNNEST(required_skills) FROM tasks
+) existing
+WHERE skill_code(name) <> ''
+  AND NOT EXISTS (SELECT 1 FROM skills k WHERE LOWER(k.name) = LOWER(TRIM(existing.name)))
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS volunteer_skills (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    skill_id INT NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    proficiency skill_proficiency NOT NULL DEFAULT 'intermediate',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (volunteer_id, skill_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_skills_skill_id ON volunteer_skills (skill_id);
+
+INSERT INTO volunteer_skills (volunteer_id, skill_id, proficiency)
+SELECT v.id, k.id, 'intermediate'
+FROM volunteers v
+JOIN skills k ON k.code = skill_code(v.skill) OR LOWER(k.name) = LOWER(TRIM(v.skill))
+ON CONFLICT (volunteer_id, skill_id) DO NOTHING;
+
+-- Keahlian yang dibutuhkan shift dan tugas disimpan sebagai kode taksonomi
+UPDATE volunteer_shifts s SET required_skills = ARRAY(
+    SELECT DISTINCT k.code FROM UNNEST(s.required_skills) r
+    JOIN skills k ON k.code = skill_code(r) OR LOWER(k.name) = LOWER(TRIM(r))
+);
+
+UPDATE tasks t SET required_skills = ARRAY(
+    SELECT DISTINCT k.code FROM UNNEST(t.required_skills) r
+    JOIN skills k ON k.code = skill_code(r) OR LOWER(k.name) = LOWER(TRIM(r))
+);
+
+-- +migrate StatementEnd
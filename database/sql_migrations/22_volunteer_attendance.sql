@@ -0,0 +1,30 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Catatan check-in dan check-out relawan di lokasi
+CREATE TABLE IF NOT EXISTS volunteer_attendances (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    disaster_id INT REFERENCES disasters(id) ON DELETE SET NULL,
+    shift_id INT REFERENCES volunteer_shifts(id) ON DELETE SET NULL,
+    check_in_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    check_in_location VARCHAR(255) NOT NULL,
+    check_in_latitude DOUBLE PRECISION,
+    check_in_longitude DOUBLE PRECISION,
+    check_out_at TIMESTAMP,
+    check_out_location VARCHAR(255),
+    check_out_latitude DOUBLE PRECISION,
+    check_out_longitude DOUBLE PRECISION,
+    -- Status relawan sebelum check-in, dikembalikan saat check-out
+    previous_status volunteer_status NOT NULL DEFAULT 'available',
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (check_out_at IS NULL OR check_out_at >= check_in_at)
+);
+
+-- Relawan hanya bisa memiliki satu check-in yang belum ditutup
+CREATE UNIQUE INDEX IF NOT EXISTS idx_volunteer_attendances_open ON volunteer_attendances (volunteer_id) WHERE check_out_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_volunteer_attendances_disaster_id ON volunteer_attendances (disaster_id, check_in_at);
+
+-- +migrate StatementEnd
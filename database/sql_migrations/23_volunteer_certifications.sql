@@ -0,0 +1,40 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Sertifikasi dan lisensi relawan yang diverifikasi admin
+CREATE TYPE certification_status AS ENUM ('pending', 'verified', 'rejected');
+
+CREATE TABLE IF NOT EXISTS volunteer_certifications (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    certification_type VARCHAR(50) NOT NULL,
+    issuer VARCHAR(255) NOT NULL,
+    certificate_number VARCHAR(100) NOT NULL,
+    issued_at DATE,
+    expires_at DATE,
+    document_name VARCHAR(255),
+    document_content_type VARCHAR(100),
+    document BYTEA,
+    status certification_status NOT NULL DEFAULT 'pending',
+    verified_by INT REFERENCES users(id) ON DELETE SET NULL,
+    verified_at TIMESTAMP,
+    review_note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (certification_type, issuer, certificate_number),
+    CHECK (expires_at IS NULL OR issued_at IS NULL OR expires_at >= issued_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_certifications_volunteer ON volunteer_certifications (volunteer_id, certification_type);
+CREATE INDEX IF NOT EXISTS idx_volunteer_certifications_expires_at ON volunteer_certifications (expires_at) WHERE status = 'verified';
+
+-- Keahlian yang mewajibkan lisensi, dipakai sebagai default sertifikasi wajib shift dan tugas
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS required_certification VARCHAR(50);
+
+UPDATE skills SET required_certification = 'medical_license' WHERE code IN ('medical', 'nursing');
+UPDATE skills SET required_certification = 'sar_certificate' WHERE code IN ('search_and_rescue', 'water_rescue');
+
+ALTER TABLE volunteer_shifts ADD COLUMN IF NOT EXISTS required_certifications TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS required_certifications TEXT[] NOT NULL DEFAULT '{}';
+
+-- +migrate StatementEnd
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Entri buku stok bersifat append-only, log distribusi yang sudah tercatat di buku stok tidak boleh dihapus
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS stock_movements_distribution_log_id_fkey;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_distribution_log_id_fkey
+    FOREIGN KEY (distribution_log_id) REFERENCES distribution_logs(id) ON DELETE RESTRICT;
+
+-- +migrate StatementEnd
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Logistik yang sudah memiliki riwayat di buku stok tidak boleh dihapus beserta riwayatnya
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS stock_movements_logistic_id_fkey;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_logistic_id_fkey
+    FOREIGN KEY (logistic_id) REFERENCES logistics(id) ON DELETE RESTRICT;
+
+-- +migrate StatementEnd
@@ -0,0 +1,18 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Kuitansi menyimpan salinan nominal dan barang saat diterbitkan agar tidak ikut berubah bila data donasi berubah
+ALTER TABLE donation_receipts
+    ADD COLUMN IF NOT EXISTS amount DECIMAL(18,2),
+    ADD COLUMN IF NOT EXISTS currency VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS item_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS unit VARCHAR(50);
+
+UPDATE donation_receipts r
+SET amount = COALESCE(d.amount, 0), currency = d.currency, item_name = d.item_name, unit = d.unit
+FROM donations d
+WHERE d.id = r.donation_id AND r.amount IS NULL;
+
+ALTER TABLE donation_receipts ALTER COLUMN amount SET NOT NULL;
+
+-- +migrate StatementEnd
@@ -0,0 +1,30 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Koordinat geografis (WGS84) dan akurasi lokasi dalam meter
+ALTER TABLE disasters
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS location_accuracy DOUBLE PRECISION;
+
+ALTER TABLE shelters
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS location_accuracy DOUBLE PRECISION;
+
+ALTER TABLE emergency_reports
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS location_accuracy DOUBLE PRECISION;
+
+ALTER TABLE volunteers
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS location_accuracy DOUBLE PRECISION;
+
+CREATE INDEX IF NOT EXISTS idx_disasters_lat_lon ON disasters (latitude, longitude);
+CREATE INDEX IF NOT EXISTS idx_shelters_lat_lon ON shelters (latitude, longitude);
+CREATE INDEX IF NOT EXISTS idx_emergency_reports_lat_lon ON emergency_reports (latitude, longitude);
+CREATE INDEX IF NOT EXISTS idx_volunteers_lat_lon ON volunteers (latitude, longitude);
+
+-- +migrate StatementEnd
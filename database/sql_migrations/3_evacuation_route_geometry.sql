@@ -0,0 +1,27 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Titik-titik jalur evakuasi secara berurutan
+CREATE TABLE IF NOT EXISTS evacuation_route_waypoints (
+    id SERIAL PRIMARY KEY,
+    route_id INT NOT NULL REFERENCES evacuation_routes(id) ON DELETE CASCADE,
+    sequence INT NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    UNIQUE (route_id, sequence)
+);
+
+-- Segmen antara dua titik berurutan, dengan status per segmen
+CREATE TABLE IF NOT EXISTS evacuation_route_segments (
+    id SERIAL PRIMARY KEY,
+    route_id INT NOT NULL REFERENCES evacuation_routes(id) ON DELETE CASCADE,
+    sequence INT NOT NULL,
+    distance DECIMAL(10,3) NOT NULL,
+    status evacuation_status NOT NULL DEFAULT 'safe',
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (route_id, sequence)
+);
+
+-- +migrate StatementEnd
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Sinkronisasi kapasitas tersisa dengan jumlah pengungsi yang tercatat di setiap shelter
+UPDATE shelters s
+SET capacity_remaining = GREATEST(s.capacity_total - (SELECT COUNT(*) FROM refugees r WHERE r.shelter_id = s.id), 0),
+    updated_at = NOW();
+
+CREATE INDEX IF NOT EXISTS idx_refugees_shelter_id ON refugees (shelter_id);
+
+-- +migrate StatementEnd
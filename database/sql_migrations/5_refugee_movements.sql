@@ -0,0 +1,17 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Riwayat perpindahan pengungsi antar shelter
+CREATE TABLE IF NOT EXISTS refugee_movements (
+    id SERIAL PRIMARY KEY,
+    refugee_id INT NOT NULL REFERENCES refugees(id) ON DELETE CASCADE,
+    from_shelter_id INT REFERENCES shelters(id) ON DELETE SET NULL,
+    to_shelter_id INT REFERENCES shelters(id) ON DELETE SET NULL,
+    reason TEXT,
+    authorized_by INT REFERENCES users(id) ON DELETE SET NULL,
+    moved_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refugee_movements_refugee_id ON refugee_movements (refugee_id, moved_at);
+
+-- +migrate StatementEnd
@@ -0,0 +1,20 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Tabel Households (kelompok keluarga pengungsi)
+CREATE TABLE IF NOT EXISTS households (
+    id SERIAL PRIMARY KEY,
+    disaster_id INT REFERENCES disasters(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    head_refugee_id INT REFERENCES refugees(id) ON DELETE SET NULL,
+    shelter_id INT REFERENCES shelters(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE refugees ADD COLUMN IF NOT EXISTS household_id INT REFERENCES households(id) ON DELETE SET NULL;
+ALTER TABLE refugees ADD COLUMN IF NOT EXISTS relationship VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_refugees_household_id ON refugees (household_id);
+
+-- +migrate StatementEnd
@@ -0,0 +1,42 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Tabel Missing Persons (laporan orang hilang)
+CREATE TYPE missing_person_status AS ENUM ('missing', 'found', 'closed');
+
+CREATE TABLE IF NOT EXISTS missing_persons (
+    id SERIAL PRIMARY KEY,
+    disaster_id INT REFERENCES disasters(id) ON DELETE SET NULL,
+    reported_by INT REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    age_min INT,
+    age_max INT,
+    last_seen_location VARCHAR(255),
+    last_seen_at TIMESTAMP,
+    contact_name VARCHAR(255),
+    contact_phone VARCHAR(50),
+    contact_email VARCHAR(255),
+    description TEXT,
+    status missing_person_status NOT NULL DEFAULT 'missing',
+    found_refugee_id INT REFERENCES refugees(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Kandidat kecocokan antara laporan orang hilang dan data pengungsi
+CREATE TYPE match_status AS ENUM ('pending', 'confirmed', 'rejected');
+
+CREATE TABLE IF NOT EXISTS missing_person_matches (
+    id SERIAL PRIMARY KEY,
+    missing_person_id INT NOT NULL REFERENCES missing_persons(id) ON DELETE CASCADE,
+    refugee_id INT NOT NULL REFERENCES refugees(id) ON DELETE CASCADE,
+    score DECIMAL(4,3) NOT NULL,
+    status match_status NOT NULL DEFAULT 'pending',
+    reviewed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (missing_person_id, refugee_id)
+);
+
+-- +migrate StatementEnd
@@ -0,0 +1,16 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Jejak penggabungan data pengungsi ganda
+CREATE TABLE IF NOT EXISTS refugee_merges (
+    id SERIAL PRIMARY KEY,
+    refugee_id INT NOT NULL REFERENCES refugees(id) ON DELETE CASCADE,
+    merged_refugee_id INT NOT NULL,
+    merged_data JSONB NOT NULL,
+    merged_by INT REFERENCES users(id) ON DELETE SET NULL,
+    merged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refugees_disaster_age ON refugees (disaster_id, age);
+
+-- +migrate StatementEnd
@@ -0,0 +1,28 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Kategori triase START
+CREATE TYPE triage_category AS ENUM ('immediate', 'delayed', 'minor', 'deceased');
+
+ALTER TABLE refugees ADD COLUMN IF NOT EXISTS triage_category triage_category;
+ALTER TABLE refugees ADD COLUMN IF NOT EXISTS triaged_at TIMESTAMP;
+
+ALTER TABLE emergency_reports ADD COLUMN IF NOT EXISTS triage_category triage_category;
+ALTER TABLE emergency_reports ADD COLUMN IF NOT EXISTS triaged_at TIMESTAMP;
+
+-- Riwayat triase dan triase ulang
+CREATE TABLE IF NOT EXISTS triage_assessments (
+    id SERIAL PRIMARY KEY,
+    refugee_id INT REFERENCES refugees(id) ON DELETE CASCADE,
+    emergency_report_id INT REFERENCES emergency_reports(id) ON DELETE CASCADE,
+    category triage_category NOT NULL,
+    note TEXT,
+    assessed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    assessed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((refugee_id IS NULL) <> (emergency_report_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_triage_assessments_refugee_id ON triage_assessments (refugee_id, assessed_at);
+CREATE INDEX IF NOT EXISTS idx_triage_assessments_emergency_report_id ON triage_assessments (emergency_report_id, assessed_at);
+
+-- +migrate StatementEnd
@@ -18,6 +18,166 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/certifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar sertifikasi semua relawan, dapat difilter berdasarkan status (pending, verified, rejected) untuk antrean verifikasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Get certifications for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status sertifikasi",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/certifications/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sertifikasi terverifikasi yang akan kedaluwarsa dalam jumlah hari tertentu, diurutkan dari yang paling dekat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Get expiring certifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah hari ke depan (default CERTIFICATION_WARNING_DAYS, 30)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/certifications/{id}/review": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin memverifikasi atau menolak sertifikasi relawan. Hanya sertifikasi terverifikasi yang belum kedaluwarsa yang memenuhi syarat penugasan shift dan tugas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Verify or reject a certification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Certification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hasil verifikasi",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.CertificationReviewInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/disasters": {
             "get": {
                 "security": [
@@ -33,6 +193,26 @@ const docTemplate = `{
                     "Disaster"
                 ],
                 "summary": "Get all disasters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Titik pusat pencarian dalam format lat,lon",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius pencarian dalam kilometer (wajib bersama near)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bounding box dalam format min_lat,min_lon,max_lat,max_lon",
+                        "name": "bbox",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -40,6 +220,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -268,21 +454,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/disasters/{id}/emergency-reports": {
+        "/disasters/{id}/allocations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar laporan darurat untuk bencana tertentu",
+                "description": "Mengusulkan distribusi dari stok logistik bencana ke permintaan kebutuhan shelter, diurutkan berdasarkan prioritas, rasio kekurangan, dan jarak",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disaster"
+                    "NeedRequest"
                 ],
-                "summary": "Get emergency reports by disaster ID",
+                "summary": "Get allocation proposals",
                 "parameters": [
                     {
                         "type": "integer",
@@ -320,21 +509,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/disasters/{id}/evacuation-routes": {
+        "/disasters/{id}/donations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar jalur evakuasi untuk bencana tertentu",
+                "description": "Mendapatkan daftar donasi untuk bencana tertentu beserta total donasi uang terkonfirmasi per mata uang dan dalam mata uang laporan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Disaster"
                 ],
-                "summary": "Get evacuation routes by disaster ID",
+                "summary": "Get donations by disaster ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -372,21 +561,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/disasters/{id}/logistics": {
+        "/disasters/{id}/emergency-reports": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar logistik untuk bencana tertentu",
+                "description": "Menampilkan daftar laporan darurat untuk bencana tertentu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Disaster"
                 ],
-                "summary": "Get logistics by disaster ID",
+                "summary": "Get emergency reports by disaster ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -424,21 +613,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/disasters/{id}/refugees": {
+        "/disasters/{id}/evacuation-routes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar pengungsi untuk bencana tertentu",
+                "description": "Menampilkan daftar jalur evakuasi untuk bencana tertentu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Disaster"
                 ],
-                "summary": "Get refugees by disaster ID",
+                "summary": "Get evacuation routes by disaster ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -476,21 +665,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/disasters/{id}/shelters": {
+        "/disasters/{id}/hours": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan shelter berdasarkan ID bencana",
+                "description": "Total jam layanan relawan untuk bencana, dirinci per relawan, beserta jumlah relawan yang sedang di lokasi",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disaster"
+                    "Attendance"
                 ],
-                "summary": "Get shelters by disaster ID",
+                "summary": "Get disaster service hours",
                 "parameters": [
                     {
                         "type": "integer",
@@ -507,6 +696,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -522,21 +717,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/disasters/{id}/volunteers": {
+        "/disasters/{id}/logistics": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar relawan yang bekerja dalam suatu bencana",
+                "description": "Menampilkan daftar logistik untuk bencana tertentu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Disaster"
                 ],
-                "summary": "Get volunteers by disaster ID",
+                "summary": "Get logistics by disaster ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -574,27 +769,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/distribution_logs": {
+        "/disasters/{id}/map.geojson": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan daftar distribusi bantuan",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengekspor layer peta operasional bencana (shelter, laporan darurat, jalur evakuasi, distribusi) sebagai GeoJSON FeatureCollection",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DistributionLog"
+                    "Disaster"
+                ],
+                "summary": "Export disaster map as GeoJSON",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Disaster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all distribution logs",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.GeoJSONFeatureCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -612,40 +819,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/disasters/{id}/map.kml": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mencatat distribusi bantuan",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengekspor layer peta operasional bencana sebagai dokumen KML",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "DistributionLog"
+                    "Disaster"
                 ],
-                "summary": "Create a distribution log",
+                "summary": "Export disaster map as KML",
                 "parameters": [
                     {
-                        "description": "Data distribusi bantuan",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.DistributionLogInput"
-                        }
+                        "type": "integer",
+                        "description": "Disaster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Dokumen KML",
                         "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -654,6 +858,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -663,28 +873,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/distribution_logs/{id}": {
+        "/disasters/{id}/refugees": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan distribusi bantuan berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Menampilkan daftar pengungsi untuk bencana tertentu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DistributionLog"
+                    "Disaster"
                 ],
-                "summary": "Get distribution log by ID",
+                "summary": "Get refugees by disaster ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Distribution Log ID",
+                        "description": "Disaster ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -708,42 +915,38 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/disasters/{id}/shelters": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui distribusi bantuan",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mendapatkan shelter berdasarkan ID bencana",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DistributionLog"
+                    "Disaster"
                 ],
-                "summary": "Update a distribution log",
+                "summary": "Get shelters by disaster ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Distribution Log ID",
+                        "description": "Disaster ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Data distribusi bantuan",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.DistributionLogInput"
-                        }
                     }
                 ],
                 "responses": {
@@ -753,8 +956,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -766,28 +969,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/disasters/{id}/tasks": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus distribusi bantuan",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Papan tugas bencana yang dikelompokkan per status (open, assigned, in_progress, blocked, done, cancelled)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DistributionLog"
+                    "Task"
                 ],
-                "summary": "Delete a distribution log",
+                "summary": "Get disaster task board",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Distribution Log ID",
+                        "description": "Disaster ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -806,40 +1008,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/donations": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mendapatkan daftar donasi",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Donation"
-                ],
-                "summary": "Get all donations",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -853,14 +1021,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/disasters/{id}/triage": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mencatat donasi",
+                "description": "Mendapatkan jumlah pengungsi dan laporan darurat per kategori triase, beserta rincian per shelter yang diurutkan dari kasus kritis terbanyak",
                 "consumes": [
                     "application/json"
                 ],
@@ -868,23 +1038,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Donation"
+                    "Triage"
                 ],
-                "summary": "Create a donation",
+                "summary": "Get disaster triage summary",
                 "parameters": [
                     {
-                        "description": "Data donasi",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.DonationInput"
-                        }
+                        "type": "integer",
+                        "description": "Disaster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -895,6 +1063,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -904,28 +1078,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/donations/{id}": {
+        "/disasters/{id}/volunteers": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan donasi berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Menampilkan daftar relawan yang bekerja dalam suatu bencana",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Donation"
+                    "Disaster"
                 ],
-                "summary": "Get a donation by ID",
+                "summary": "Get volunteers by disaster ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Donation ID",
+                        "description": "Disaster ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -949,44 +1120,31 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/disbursements": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui donasi",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mendapatkan semua pengeluaran dana donasi beserta penerimanya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Donation"
-                ],
-                "summary": "Update a donation",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Donation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Data donasi",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.DonationInput"
-                        }
-                    }
+                    "Ledger"
                 ],
+                "summary": "Get all disbursements",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -994,12 +1152,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1008,13 +1160,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus donasi",
+                "description": "Mencatat pengeluaran dana donasi. Setiap pengeluaran langsung ditambahkan ke buku besar publik dan tidak dapat diubah atau dihapus",
                 "consumes": [
                     "application/json"
                 ],
@@ -1022,21 +1174,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Donation"
+                    "Ledger"
                 ],
-                "summary": "Delete a donation",
+                "summary": "Record a disbursement",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Donation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data pengeluaran",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.DisbursementInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -1047,6 +1201,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1056,14 +1216,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/emergency_reports": {
+        "/distribution_logs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan daftar laporan darurat",
+                "description": "Mendapatkan daftar distribusi bantuan",
                 "consumes": [
                     "application/json"
                 ],
@@ -1071,9 +1231,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EmergencyReport"
+                    "DistributionLog"
                 ],
-                "summary": "Get all emergency reports",
+                "summary": "Get all distribution logs",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1101,7 +1261,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mencatat laporan darurat",
+                "description": "Mencatat distribusi bantuan dan mengurangi stok logistik secara atomik. Distribusi yang melebihi stok tersedia ditolak. Status awal boleh planned atau dispatched (default), dan kode konfirmasi untuk penerima hanya ditampilkan pada respons ini",
                 "consumes": [
                     "application/json"
                 ],
@@ -1109,17 +1269,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EmergencyReport"
+                    "DistributionLog"
                 ],
-                "summary": "Create an emergency report",
+                "summary": "Create a distribution log",
                 "parameters": [
                     {
-                        "description": "Input data laporan darurat",
+                        "description": "Data distribusi bantuan",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.EmergencyReportInput"
+                            "$ref": "#/definitions/structs.DistributionLogInput"
                         }
                     }
                 ],
@@ -1136,6 +1296,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1145,14 +1317,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/emergency_reports/{id}": {
+        "/distribution_logs/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan laporan darurat berdasarkan ID",
+                "description": "Mendapatkan distribusi bantuan berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1160,13 +1332,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EmergencyReport"
+                    "DistributionLog"
                 ],
-                "summary": "Get an emergency report by ID",
+                "summary": "Get distribution log by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID laporan darurat",
+                        "description": "Distribution Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1199,7 +1371,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui laporan darurat",
+                "description": "Memperbarui distribusi bantuan",
                 "consumes": [
                     "application/json"
                 ],
@@ -1207,24 +1379,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EmergencyReport"
+                    "DistributionLog"
                 ],
-                "summary": "Update an emergency report",
+                "summary": "Update a distribution log",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID laporan darurat",
+                        "description": "Distribution Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Input data laporan darurat",
+                        "description": "Data distribusi bantuan",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.EmergencyReportInput"
+                            "$ref": "#/definitions/structs.DistributionLogInput"
                         }
                     }
                 ],
@@ -1241,6 +1413,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1255,7 +1439,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus laporan darurat",
+                "description": "Menghapus distribusi bantuan berstatus planned atau dispatched yang belum tercatat di buku stok. Distribusi yang sudah mengurangi stok dibatalkan lewat status failed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1263,13 +1447,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EmergencyReport"
+                    "DistributionLog"
                 ],
-                "summary": "Delete an emergency report",
+                "summary": "Delete a distribution log",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID laporan darurat",
+                        "description": "Distribution Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1288,42 +1472,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/evacuation_routes": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mendapatkan daftar jalur evakuasi",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "EvacuationRoute"
-                ],
-                "summary": "Get all evacuation routes",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -1335,38 +1491,70 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/distribution_logs/{id}/confirm": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mencatat jalur evakuasi",
+                "description": "Konfirmasi penerimaan bantuan oleh penerima menggunakan kode konfirmasi, jumlah yang diterima, alasan selisih (wajib jika jumlah berbeda), serta lampiran tanda tangan dan/atau foto (gambar maksimal 5MB)",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "EvacuationRoute"
+                    "DistributionLog"
                 ],
-                "summary": "Create an evacuation route",
+                "summary": "Confirm aid delivery",
                 "parameters": [
                     {
-                        "description": "Input data jalur evakuasi",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.EvacuationRouteInput"
-                        }
+                        "type": "integer",
+                        "description": "Distribution Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kode konfirmasi penerima",
+                        "name": "confirmation_code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah yang diterima",
+                        "name": "quantity_received",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alasan selisih jumlah",
+                        "name": "discrepancy_reason",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Foto tanda tangan penerima",
+                        "name": "signature",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Foto serah terima",
+                        "name": "photo",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -1377,6 +1565,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1386,14 +1592,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/evacuation_routes/{id}": {
+        "/distribution_logs/{id}/delivery": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan jalur evakuasi berdasarkan ID",
+                "description": "Mendapatkan status pengiriman distribusi beserta riwayat perubahan status dan daftar bukti serah terima",
                 "consumes": [
                     "application/json"
                 ],
@@ -1401,13 +1607,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EvacuationRoute"
+                    "DistributionLog"
                 ],
-                "summary": "Get an evacuation route by ID",
+                "summary": "Get distribution delivery detail",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID jalur evakuasi",
+                        "description": "Distribution Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1431,49 +1637,52 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/distribution_logs/{id}/proofs/{proof_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui jalur evakuasi",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengunduh file bukti serah terima (tanda tangan atau foto) sebuah distribusi",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "EvacuationRoute"
+                    "DistributionLog"
                 ],
-                "summary": "Update an evacuation route",
+                "summary": "Download proof of delivery",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID jalur evakuasi",
+                        "description": "Distribution Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Input data jalur evakuasi",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.EvacuationRouteInput"
-                        }
+                        "type": "integer",
+                        "description": "Proof ID",
+                        "name": "proof_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1482,6 +1691,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1489,14 +1704,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/distribution_logs/{id}/status": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus jalur evakuasi",
+                "description": "Memperbarui status pengiriman distribusi mengikuti alur planned → dispatched → in_transit → delivered → confirmed, atau failed/returned. Distribusi yang gagal atau dikembalikan mengembalikan stok ke batch asalnya. Status confirmed hanya bisa diisi melalui endpoint konfirmasi penerimaan",
                 "consumes": [
                     "application/json"
                 ],
@@ -1504,16 +1721,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EvacuationRoute"
+                    "DistributionLog"
                 ],
-                "summary": "Delete an evacuation route",
+                "summary": "Update distribution delivery status",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID jalur evakuasi",
+                        "description": "Distribution Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Status pengiriman baru",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.DistributionStatusInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -1529,6 +1755,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1538,14 +1776,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/logistics": {
+        "/donations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan daftar bantuan logistik",
+                "description": "Mendapatkan daftar donasi",
                 "consumes": [
                     "application/json"
                 ],
@@ -1553,9 +1791,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Logistic"
+                    "Donation"
                 ],
-                "summary": "Get all logistics",
+                "summary": "Get all donations",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1583,7 +1821,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mencatat bantuan logistik",
+                "description": "Mencatat donasi. Donasi uang memakai currency ISO 4217 (default IDR) dan dikonversi ke mata uang laporan dengan kurs tersimpan. Donasi barang (item_name atau item_id) memakai amount sebagai jumlah barang dalam satuan unit, dan saat dikonfirmasi langsung menambah stok logistik bencana dalam batch DONASI-\u003cid\u003e",
                 "consumes": [
                     "application/json"
                 ],
@@ -1591,17 +1829,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Logistic"
+                    "Donation"
                 ],
-                "summary": "Create a logistic",
+                "summary": "Create a donation",
                 "parameters": [
                     {
-                        "description": "Data bantuan logistik",
+                        "description": "Data donasi",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.LogisticInput"
+                            "$ref": "#/definitions/structs.DonationInput"
                         }
                     }
                 ],
@@ -1618,6 +1856,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1627,14 +1877,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/logistics/{id}": {
+        "/donations/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan bantuan logistik berdasarkan ID",
+                "description": "Mendapatkan donasi berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1642,13 +1892,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Logistic"
+                    "Donation"
                 ],
-                "summary": "Get logistic by ID",
+                "summary": "Get a donation by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Logistic ID",
+                        "description": "Donation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1681,7 +1931,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui bantuan logistik",
+                "description": "Memperbarui donasi. Mengubah status donasi barang menjadi confirmed (khusus admin dan relawan) menambah stok logistik bencana, setelah itu donasi tidak dapat diubah lagi",
                 "consumes": [
                     "application/json"
                 ],
@@ -1689,24 +1939,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Logistic"
+                    "Donation"
                 ],
-                "summary": "Update logistic",
+                "summary": "Update a donation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Logistic ID",
+                        "description": "Donation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data bantuan logistik",
+                        "description": "Data donasi",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.LogisticInput"
+                            "$ref": "#/definitions/structs.DonationInput"
                         }
                     }
                 ],
@@ -1723,6 +1973,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1737,7 +2005,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus bantuan logistik",
+                "description": "Menghapus donasi. Donasi yang sudah dikonfirmasi tercatat di buku besar publik dan tidak dapat dihapus",
                 "consumes": [
                     "application/json"
                 ],
@@ -1745,13 +2013,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Logistic"
+                    "Donation"
                 ],
-                "summary": "Delete logistic",
+                "summary": "Delete a donation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Logistic ID",
+                        "description": "Donation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1770,6 +2038,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1779,14 +2059,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/refugees": {
-            "get": {
+        "/donations/{id}/pay": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan daftar pengungsi",
+                "description": "Membuat tagihan pembayaran donasi uang di payment gateway aktif (PAYMENT_PROVIDER, tanpa default) dan mengembalikan redirect_url halaman pembayaran. Tagihan yang masih berjalan dikembalikan ulang. Status donasi menjadi confirmed hanya lewat webhook yang terverifikasi",
                 "consumes": [
                     "application/json"
                 ],
@@ -1794,56 +2074,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Refugee"
+                    "Donation"
                 ],
-                "summary": "Get all refugees",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Membuat data pengungsi baru",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Refugee"
-                ],
-                "summary": "Create a refugee",
+                "summary": "Create a payment for a donation",
                 "parameters": [
                     {
-                        "description": "Data pengungsi",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.RefugeeInput"
-                        }
+                        "type": "integer",
+                        "description": "Donation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1859,37 +2099,52 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
                     }
                 }
             }
         },
-        "/refugees/{id}": {
+        "/donations/{id}/receipt": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan data pengungsi berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengunduh kuitansi resmi (PDF) bernomor untuk donasi yang sudah dikonfirmasi, berisi data donatur, bencana, jumlah/barang, tanggal, data organisasi, dan kode verifikasi",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Refugee"
+                    "Donation"
                 ],
-                "summary": "Get refugee by ID",
+                "summary": "Download donation receipt",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Refugee ID",
+                        "description": "Donation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1899,7 +2154,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1913,16 +2168,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/donations/{id}/trace": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data pengungsi",
+                "description": "Menelusuri donasi barang yang sudah diterima: stok logistik tujuan, jumlah diterima, sisa stok dari batch donasi, dan log distribusi yang memakai barang donasi tersebut",
                 "consumes": [
                     "application/json"
                 ],
@@ -1930,25 +2199,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Refugee"
+                    "Donation"
                 ],
-                "summary": "Update a refugee",
+                "summary": "Trace donated goods",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Refugee ID",
+                        "description": "Donation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Data pengungsi",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.RefugeeInput"
-                        }
                     }
                 ],
                 "responses": {
@@ -1964,6 +2224,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1971,14 +2237,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/emergency_reports": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data pengungsi",
+                "description": "Mendapatkan daftar laporan darurat",
                 "consumes": [
                     "application/json"
                 ],
@@ -1986,16 +2254,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Refugee"
+                    "EmergencyReport"
                 ],
-                "summary": "Delete a refugee",
+                "summary": "Get all emergency reports",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Refugee ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Titik pusat pencarian dalam format lat,lon",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius pencarian dalam kilometer (wajib bersama near)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bounding box dalam format min_lat,min_lon,max_lat,max_lon",
+                        "name": "bbox",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2011,36 +2290,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/shelters": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mendapatkan daftar shelter",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shelter"
-                ],
-                "summary": "Get all shelters",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2059,7 +2310,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Membuat shelter baru",
+                "description": "Mencatat laporan darurat",
                 "consumes": [
                     "application/json"
                 ],
@@ -2067,17 +2318,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shelter"
+                    "EmergencyReport"
                 ],
-                "summary": "Create a shelter",
+                "summary": "Create an emergency report",
                 "parameters": [
                     {
-                        "description": "Data shelter",
+                        "description": "Input data laporan darurat",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.ShelterInput"
+                            "$ref": "#/definitions/structs.EmergencyReportInput"
                         }
                     }
                 ],
@@ -2103,14 +2354,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shelters/{id}": {
+        "/emergency_reports/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan shelter berdasarkan ID",
+                "description": "Mendapatkan laporan darurat berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2118,13 +2369,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shelter"
+                    "EmergencyReport"
                 ],
-                "summary": "Get a shelter by ID",
+                "summary": "Get an emergency report by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID Shelter",
+                        "description": "ID laporan darurat",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2157,7 +2408,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui shelter",
+                "description": "Memperbarui laporan darurat",
                 "consumes": [
                     "application/json"
                 ],
@@ -2165,24 +2416,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shelter"
+                    "EmergencyReport"
                 ],
-                "summary": "Update a shelter",
+                "summary": "Update an emergency report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID Shelter",
+                        "description": "ID laporan darurat",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data shelter",
+                        "description": "Input data laporan darurat",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.ShelterInput"
+                            "$ref": "#/definitions/structs.EmergencyReportInput"
                         }
                     }
                 ],
@@ -2213,7 +2464,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus shelter",
+                "description": "Menghapus laporan darurat",
                 "consumes": [
                     "application/json"
                 ],
@@ -2221,13 +2472,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shelter"
+                    "EmergencyReport"
                 ],
-                "summary": "Delete a shelter",
+                "summary": "Delete an emergency report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID Shelter",
+                        "description": "ID laporan darurat",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2255,28 +2506,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/shelters/{id}/logistics": {
+        "/emergency_reports/{id}/matches": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar bantuan logistik yang tersedia di shelter tertentu",
+                "description": "Memberi peringkat relawan yang cocok untuk laporan darurat. Tanpa parameter skills, keahlian mengikuti kategori triase (immediate: medical dan first_aid, delayed/minor: first_aid, deceased: search_and_rescue)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shelter"
+                    "Skill"
                 ],
-                "summary": "Get logistics by shelter ID",
+                "summary": "Rank volunteers for an emergency report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Shelter ID",
+                        "description": "Emergency Report ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keahlian yang dibutuhkan, dipisah koma",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah relawan (default 10, maksimal 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2307,25 +2570,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/shelters/{id}/refugees": {
+        "/emergency_reports/{id}/triage": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar pengungsi dalam shelter tertentu",
+                "description": "Mendapatkan riwayat triase laporan darurat",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shelter"
+                    "Triage"
                 ],
-                "summary": "Get refugees by shelter ID",
+                "summary": "Get emergency report triage history",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Shelter ID",
+                        "description": "Emergency Report ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2357,73 +2623,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan semua user",
+                "description": "Mencatat tingkat urgensi laporan darurat dengan skala START (immediate, delayed, minor, deceased)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Triage"
+                ],
+                "summary": "Triage an emergency report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Emergency Report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kategori triase",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.TriageInput"
+                        }
+                    }
                 ],
-                "summary": "Get all users",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/structs.User"
-                            }
+                            "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Membuat user baru",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Create new user",
-                "parameters": [
-                    {
-                        "description": "User object",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.UserInput"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2437,14 +2687,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/enable-2fa": {
-            "put": {
+        "/evacuation_routes": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengaktifkan atau menonaktifkan 2FA",
+                "description": "Mendapatkan daftar jalur evakuasi",
                 "consumes": [
                     "application/json"
                 ],
@@ -2452,20 +2702,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Enable 2FA",
-                "parameters": [
-                    {
-                        "description": "User 2FA status",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.Enable2FA"
-                        }
-                    }
+                    "EvacuationRoute"
                 ],
+                "summary": "Get all evacuation routes",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2473,14 +2712,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2492,11 +2725,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/login": {
+            },
             "post": {
-                "description": "Autentikasi user untuk mendapatkan token JWT",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat jalur evakuasi",
                 "consumes": [
                     "application/json"
                 ],
@@ -2504,23 +2740,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "EvacuationRoute"
                 ],
-                "summary": "Login user",
+                "summary": "Create an evacuation route",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "user",
+                        "description": "Input data jalur evakuasi",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.Login"
+                            "$ref": "#/definitions/structs.EvacuationRouteInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2531,12 +2767,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2546,28 +2776,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/verify-otp": {
-            "post": {
-                "description": "Verifikasi OTP untuk mendapatkan token JWT",
-                "consumes": [
-                    "application/json"
+        "/evacuation_routes/plan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Menghitung jalur evakuasi terbaik dari suatu titik ke shelter terdekat yang masih memiliki kapasitas. Jalur 'blocked' diabaikan dan jalur 'risky' diberi penalti.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "EvacuationRoute"
                 ],
-                "summary": "Verify OTP",
+                "summary": "Plan an evacuation path",
                 "parameters": [
                     {
-                        "description": "User OTP verification",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/structs.VerifyOTP"
-                        }
+                        "type": "string",
+                        "description": "Titik awal dalam format lat,lon atau nama lokasi asal jalur",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID bencana",
+                        "name": "disaster_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2583,8 +2820,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2598,25 +2835,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/evacuation_routes/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan user berdasarkan ID",
+                "description": "Mendapatkan jalur evakuasi berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "EvacuationRoute"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Get an evacuation route by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "ID jalur evakuasi",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2626,17 +2866,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/structs.User"
+                            "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2649,7 +2889,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah data user",
+                "description": "Memperbarui jalur evakuasi",
                 "consumes": [
                     "application/json"
                 ],
@@ -2657,24 +2897,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "EvacuationRoute"
                 ],
-                "summary": "Update user",
+                "summary": "Update an evacuation route",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "ID jalur evakuasi",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User object",
-                        "name": "user",
+                        "description": "Input data jalur evakuasi",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.UserInput"
+                            "$ref": "#/definitions/structs.EvacuationRouteInput"
                         }
                     }
                 ],
@@ -2691,12 +2931,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2711,18 +2945,21 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus user berdasarkan ID",
+                "description": "Menghapus jalur evakuasi",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "EvacuationRoute"
                 ],
-                "summary": "Delete user",
+                "summary": "Delete an evacuation route",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "ID jalur evakuasi",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2735,8 +2972,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2750,14 +2987,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/change-role": {
+        "/evacuation_routes/{id}/segments/{sequence}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah role pengguna",
+                "description": "Memperbarui status satu segmen jalur evakuasi (misalnya jembatan putus) tanpa mengubah status jalur secara keseluruhan",
                 "consumes": [
                     "application/json"
                 ],
@@ -2765,24 +3002,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "EvacuationRoute"
                 ],
-                "summary": "Change user role",
+                "summary": "Update an evacuation route segment status",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "ID jalur evakuasi",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "List role: admin, donor, user",
-                        "name": "role",
+                        "type": "integer",
+                        "description": "Urutan segmen (dimulai dari 0)",
+                        "name": "sequence",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status segmen",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.ChangeUserRole"
+                            "$ref": "#/definitions/structs.RouteSegmentInput"
                         }
                     }
                 ],
@@ -2799,8 +3043,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2814,28 +3058,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/donations": {
+        "/exchange_rates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar donasi yang diberikan oleh user tertentu",
+                "description": "Mendapatkan kurs mata uang terhadap mata uang laporan (REPORTING_CURRENCY, default IDR). Rate berarti 1 unit mata uang setara rate unit mata uang dasar",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Donation"
                 ],
-                "summary": "Get donations by user ID",
+                "summary": "Get exchange rates",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Mata uang dasar, default mata uang laporan",
+                        "name": "base",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2845,18 +3088,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2864,16 +3095,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}/edit-info": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah data user tanpa email",
+                "description": "Memperbarui kurs mata uang (kode ISO 4217). Kurs dipakai untuk mengonversi donasi baru ke mata uang laporan, donasi lama tetap memakai kurs saat dicatat",
                 "consumes": [
                     "application/json"
                 ],
@@ -2881,24 +3110,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Donation"
                 ],
-                "summary": "Update user without email",
+                "summary": "Update exchange rates",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User object",
-                        "name": "user",
+                        "description": "Kurs per mata uang, misal {\\",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.UpdateUserInfoWithoutEmail"
+                            "$ref": "#/definitions/structs.ExchangeRateInput"
                         }
                     }
                 ],
@@ -2915,12 +3137,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/structs.APIResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2930,33 +3146,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/emergency-reports": {
+        "/households": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan semua laporan darurat yang dikirim oleh pengguna tertentu",
+                "description": "Mendapatkan daftar keluarga beserta anggota, kebutuhan, dan ukuran jatah",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Household"
                 ],
-                "summary": "Get emergency reports by user ID",
-                "parameters": [
+                "summary": "Get all households",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat data keluarga pengungsi, kepala keluarga otomatis menjadi anggota",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Create a household",
+                "parameters": [
+                    {
+                        "description": "Data keluarga",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.HouseholdInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -2982,14 +3241,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/volunteers": {
+        "/households/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan daftar relawan",
+                "description": "Mendapatkan detail keluarga beserta anggota, kebutuhan, dan ukuran jatah",
                 "consumes": [
                     "application/json"
                 ],
@@ -2997,9 +3256,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Volunteer"
+                    "Household"
+                ],
+                "summary": "Get household by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all volunteers",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3007,6 +3275,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3015,13 +3289,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mencatat relawan",
+                "description": "Memperbarui nama, bencana, atau kepala keluarga",
                 "consumes": [
                     "application/json"
                 ],
@@ -3029,23 +3303,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Volunteer"
+                    "Household"
                 ],
-                "summary": "Create a volunteer",
+                "summary": "Update household",
                 "parameters": [
                     {
-                        "description": "Data relawan",
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data keluarga",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.VolunteerInput"
+                            "$ref": "#/definitions/structs.HouseholdInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
@@ -3056,6 +3337,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3063,16 +3350,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/volunteers/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan relawan berdasarkan ID",
+                "description": "Menghapus data keluarga, anggota tetap tercatat sebagai pengungsi",
                 "consumes": [
                     "application/json"
                 ],
@@ -3080,13 +3365,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Volunteer"
+                    "Household"
                 ],
-                "summary": "Get a volunteer by ID",
+                "summary": "Delete household",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID relawan",
+                        "description": "Household ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3110,16 +3395,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/structs.APIResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/households/{id}/members": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui relawan",
+                "description": "Menambahkan pengungsi ke keluarga, pengungsi dipindahkan ke shelter keluarga bila berbeda",
                 "consumes": [
                     "application/json"
                 ],
@@ -3127,24 +3420,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Volunteer"
+                    "Household"
                 ],
-                "summary": "Update a volunteer",
+                "summary": "Add a household member",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID relawan",
+                        "description": "Household ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data relawan",
+                        "description": "Anggota keluarga",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/structs.VolunteerInput"
+                            "$ref": "#/definitions/structs.HouseholdMemberInput"
                         }
                     }
                 ],
@@ -3161,6 +3454,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3168,14 +3473,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/households/{id}/members/{refugee_id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus relawan",
+                "description": "Mengeluarkan pengungsi dari keluarga",
                 "consumes": [
                     "application/json"
                 ],
@@ -3183,16 +3490,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Volunteer"
+                    "Household"
                 ],
-                "summary": "Delete a volunteer",
+                "summary": "Remove a household member",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID relawan",
+                        "description": "Household ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "refugee_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3208,6 +3522,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/structs.APIResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3216,245 +3536,7059 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "structs.APIResponse": {
+        },
+        "/households/{id}/shelter": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menempatkan seluruh anggota keluarga ke satu shelter secara bersamaan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Assign household to a shelter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shelter tujuan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.HouseholdShelterInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar barang di katalog logistik",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Item"
+                ],
+                "summary": "Get all catalog items",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan barang ke katalog logistik beserta satuan dasar dan konversi satuan lainnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Item"
+                ],
+                "summary": "Create a catalog item",
+                "parameters": [
+                    {
+                        "description": "Data barang",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ItemInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/items/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan detail barang di katalog logistik berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Item"
+                ],
+                "summary": "Get catalog item by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui barang di katalog logistik. Jika units diisi, seluruh konversi satuan akan diganti",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Item"
+                ],
+                "summary": "Update catalog item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data barang",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ItemInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus barang dari katalog logistik",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Item"
+                ],
+                "summary": "Delete catalog item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ledger": {
+            "get": {
+                "description": "Buku besar publik berisi donasi terkonfirmasi dan pengeluaran tanpa data donatur maupun penerima. Setiap entri memuat hash entri sebelumnya sehingga rantai dapat diperiksa ulang oleh siapa pun",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Get public donation ledger",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ledger/verify": {
+            "get": {
+                "description": "Menghitung ulang rantai hash buku besar dan mencocokkannya dengan data donasi dan pengeluaran. Masalah yang dilaporkan: altered (isi entri diubah), broken_link atau sequence_gap (entri dihapus atau disisipkan), source_mismatch (data sumber berubah), dan missing_entry (entri tidak ada)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Verify the donation ledger",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar bantuan logistik",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Get all logistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat bantuan logistik",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Create a logistic",
+                "parameters": [
+                    {
+                        "description": "Data bantuan logistik",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.LogisticInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logistics/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan batch logistik yang akan kedaluwarsa dalam N hari ke depan, termasuk yang sudah kedaluwarsa namun masih tersisa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Get expiring logistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah hari ke depan (default 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logistics/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan bantuan logistik berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Get logistic by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Logistic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui bantuan logistik. Status logistik dihitung dari saldo stok dan tidak bisa diisi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Update logistic",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Logistic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data bantuan logistik",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.LogisticInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus bantuan logistik",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Delete logistic",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Logistic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logistics/{id}/batches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar batch/lot stok logistik beserta tanggal kedaluwarsa, diurutkan sesuai urutan pengeluaran FEFO",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Get logistic batches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Logistic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logistics/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan buku besar pergerakan stok logistik beserta saldo yang dihitung dari seluruh pergerakan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Get stock ledger",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Logistic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat penerimaan (receipt), penyesuaian (adjustment), atau kehilangan (loss) stok logistik. Penerimaan masuk ke batch sesuai batch_number dan expiry_date (DD/MM/YYYY), sedangkan pengurangan diambil dari batch yang paling cepat kedaluwarsa (FEFO) kecuali batch_id diisi. Pengeluaran (dispatch) hanya dicatat melalui log distribusi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistic"
+                ],
+                "summary": "Record a stock movement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Logistic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data pergerakan stok",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.StockMovementInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missing_persons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar laporan orang hilang, dapat difilter berdasarkan status. Kontak pelapor hanya ditampilkan untuk admin, relawan, dan pelapor sendiri",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Get all missing persons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status laporan (missing, found, closed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat laporan orang hilang dan langsung mencocokkannya dengan data pengungsi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Report a missing person",
+                "parameters": [
+                    {
+                        "description": "Data orang hilang, format last_seen_at 'DD/MM/YYYY HH:mm'",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.MissingPersonInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missing_persons/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan detail laporan orang hilang. Kontak pelapor hanya ditampilkan untuk admin, relawan, dan pelapor sendiri",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Get missing person by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Missing Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui laporan orang hilang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Update missing person report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Missing Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data orang hilang",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.MissingPersonInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus laporan orang hilang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Delete missing person report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Missing Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missing_persons/{id}/match": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjalankan ulang pencocokan laporan orang hilang dengan data pengungsi (kemiripan nama, toleransi usia, dan bencana)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Run missing person matcher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Missing Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missing_persons/{id}/matches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan kandidat pengungsi yang cocok dengan laporan orang hilang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Get candidate matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Missing Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missing_persons/{id}/matches/{match_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Relawan mengonfirmasi atau menolak kandidat kecocokan, pelapor diberi tahu lewat email saat dikonfirmasi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MissingPerson"
+                ],
+                "summary": "Confirm or reject a candidate match",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Missing Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Match ID",
+                        "name": "match_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status kecocokan (confirmed atau rejected)",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.MissingPersonMatchInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/need_requests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan detail permintaan kebutuhan shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NeedRequest"
+                ],
+                "summary": "Get need request by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Need Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui permintaan kebutuhan shelter. Status hanya bisa diubah menjadi 'open' atau 'cancelled'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NeedRequest"
+                ],
+                "summary": "Update need request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Need Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data permintaan kebutuhan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.NeedRequestInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus permintaan kebutuhan shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NeedRequest"
+                ],
+                "summary": "Delete need request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Need Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/need_requests/{id}/allocate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerima usulan alokasi untuk permintaan kebutuhan dan mencatatnya sebagai log distribusi yang langsung mengurangi stok. Jika quantity kosong, seluruh sisa kebutuhan dialokasikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NeedRequest"
+                ],
+                "summary": "Accept an allocation proposal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Need Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data alokasi",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.AllocationAcceptInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/mock/{reference}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Halaman pembayaran payment gateway mock (redirect_url) yang menampilkan detail tagihan. Hanya tersedia bila PAYMENT_PROVIDER=mock dan PAYMENT_MOCK_ENABLED=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Mock payment checkout page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referensi pembayaran",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mensimulasikan hasil pembayaran di payment gateway mock. Server menandatangani webhook dengan PAYMENT_WEBHOOK_SECRET lalu memprosesnya melalui jalur verifikasi webhook yang sama. Hanya tersedia bila PAYMENT_PROVIDER=mock dan PAYMENT_MOCK_ENABLED=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Simulate a mock payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referensi pembayaran",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hasil pembayaran: paid, failed, atau expired",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.MockPaymentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook/{provider}": {
+            "post": {
+                "description": "Menerima notifikasi status pembayaran dari payment gateway. Tanda tangan HMAC-SHA256 atas body diverifikasi sebelum status donasi diubah, dan event yang sama hanya diproses sekali",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Payment gateway webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nama payment gateway, misal mock",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/disasters": {
+            "get": {
+                "description": "Daftar bencana yang masih aktif tanpa data pelapor, dapat diakses tanpa login dan boleh disimpan cache",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get active disasters (public)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/evacuation_routes": {
+            "get": {
+                "description": "Daftar jalur evakuasi untuk bencana aktif beserta status jalur dan status tiap segmen (safe, risky, blocked). Dapat diakses tanpa login dan boleh disimpan cache",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get evacuation route statuses (public)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/shelters": {
+            "get": {
+                "description": "Daftar shelter untuk bencana aktif beserta sisa kapasitasnya, tanpa data pengungsi. Dapat diakses tanpa login dan boleh disimpan cache",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get open shelters (public)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya shelter yang masih memiliki sisa kapasitas",
+                        "name": "available",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/verify/{code}": {
+            "get": {
+                "description": "Memeriksa keaslian kuitansi donasi berdasarkan kode verifikasi tanpa login. Nama donatur disamarkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Donation"
+                ],
+                "summary": "Verify a donation receipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode verifikasi kuitansi",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refugees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar pengungsi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Get all refugees",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat data pengungsi baru, respons memuat possible_duplicates berisi data pengungsi yang kemungkinan ganda",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Create a refugee",
+                "parameters": [
+                    {
+                        "description": "Data pengungsi",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.RefugeeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refugees/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan data pengungsi berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Get refugee by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui data pengungsi. Shelter anggota keluarga hanya bisa diubah melalui penempatan keluarga",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Update a refugee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data pengungsi",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.RefugeeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus data pengungsi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Delete a refugee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refugees/{id}/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan data pengungsi yang kemungkinan ganda berdasarkan nama, usia, bencana, dan kedekatan shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Get probable duplicate refugees",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refugees/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menggabungkan data pengungsi ganda ke data utama beserta riwayatnya, data ganda kemudian dihapus",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Merge duplicate refugee records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID pengungsi utama",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID pengungsi ganda",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.RefugeeMergeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refugees/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan riwayat perpindahan pengungsi antar shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Get refugee movement history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refugees/{id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memindahkan pengungsi ke shelter lain dan mencatat riwayat perpindahannya. Anggota keluarga dipindahkan melalui penempatan keluarga",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refugee"
+                ],
+                "summary": "Transfer a refugee to another shelter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shelter tujuan dan alasan perpindahan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.RefugeeTransferInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refugees/{id}/triage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan riwayat triase pengungsi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triage"
+                ],
+                "summary": "Get refugee triage history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat triase atau triase ulang pengungsi dengan skala START (immediate, delayed, minor, deceased)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triage"
+                ],
+                "summary": "Triage a refugee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refugee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kategori triase",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.TriageInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shelters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shelter"
+                ],
+                "summary": "Get all shelters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Titik pusat pencarian dalam format lat,lon",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius pencarian dalam kilometer (wajib bersama near)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bounding box dalam format min_lat,min_lon,max_lat,max_lon",
+                        "name": "bbox",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat shelter baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shelter"
+                ],
+                "summary": "Create a shelter",
+                "parameters": [
+                    {
+                        "description": "Data shelter",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ShelterInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shelters/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan shelter berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shelter"
+                ],
+                "summary": "Get a shelter by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Shelter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shelter"
+                ],
+                "summary": "Update a shelter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Shelter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data shelter",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ShelterInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shelter"
+                ],
+                "summary": "Delete a shelter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Shelter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shelters/{id}/logistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan daftar bantuan logistik yang tersedia di shelter tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shelter"
+                ],
+                "summary": "Get logistics by shelter ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shelter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shelters/{id}/needs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar permintaan kebutuhan shelter, diurutkan dari yang masih terbuka dan paling mendesak",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NeedRequest"
+                ],
+                "summary": "Get shelter need requests",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shelter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat permintaan kebutuhan terstruktur (barang, jumlah, prioritas) untuk shelter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NeedRequest"
+                ],
+                "summary": "Create a shelter need request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shelter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data permintaan kebutuhan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.NeedRequestInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shelters/{id}/refugees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan daftar pengungsi dalam shelter tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shelter"
+                ],
+                "summary": "Get refugees by shelter ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shelter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shelters/{id}/roster": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Jadwal shift relawan di shelter beserta relawan yang bertugas, default hari ini sampai 7 hari ke depan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Get shelter roster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shelter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (DD/MM/YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (DD/MM/YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shelters/{id}/triage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan jumlah pengungsi per kategori triase di shelter tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triage"
+                ],
+                "summary": "Get shelter triage summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shelter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar shift dalam rentang tanggal (default hari ini sampai 7 hari ke depan)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Get volunteer shifts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan shelter",
+                        "name": "shelter_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (DD/MM/YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (DD/MM/YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat shift relawan untuk bencana di lokasi atau shelter tertentu, dengan waktu mulai dan selesai (DD/MM/YYYY HH:mm), keahlian yang dibutuhkan, dan jumlah relawan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Create a volunteer shift",
+                "parameters": [
+                    {
+                        "description": "Data shift",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ShiftInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/conflicts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendeteksi relawan dengan shift yang waktunya bertabrakan (overlap) dan shift mendatang yang kekurangan relawan (understaffed)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Get shift conflicts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan detail shift beserta relawan yang terdaftar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Get shift by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui shift. Perubahan jam ditolak bila membuat relawan yang sudah terdaftar bentrok dengan shift lain, dan jumlah relawan tidak boleh kurang dari yang sudah terdaftar. Perubahan keahlian menurunkan ulang sertifikasi wajib bila required_certifications tidak diisi, dan ditolak (422) bila relawan yang sudah terdaftar tidak memenuhinya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Update a volunteer shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data shift yang diperbarui",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ShiftInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus shift beserta daftar relawannya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Delete a volunteer shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/{id}/assignments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Koordinator menugaskan relawan ke shift dengan pemeriksaan kuota, keahlian, dan bentrok jadwal yang sama seperti pendaftaran mandiri",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Assign a volunteer to a shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relawan yang ditugaskan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ShiftAssignInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/{id}/assignments/{volunteer_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membatalkan pendaftaran relawan dari shift. Relawan hanya bisa membatalkan pendaftarannya sendiri, admin bisa membatalkan siapa pun",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Remove a volunteer from a shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "volunteer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/{id}/signup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Relawan mendaftar sendiri ke shift. Ditolak bila kuota penuh, keahlian tidak sesuai, atau jadwal bentrok dengan shift lain yang sudah diambil",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Sign up for a shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/skills": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar keahlian relawan beserta kategorinya. Kode keahlian dipakai pada keahlian relawan, shift, dan tugas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skill"
+                ],
+                "summary": "Get skill taxonomy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan keahlian baru ke taksonomi. Kode dibuat dari nama bila kosong, kategori default 'lainnya'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skill"
+                ],
+                "summary": "Add a skill to the taxonomy",
+                "parameters": [
+                    {
+                        "description": "Data keahlian",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.SkillInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat tugas lapangan untuk bencana, opsional ditautkan ke laporan darurat, shelter, atau log distribusi. Bencana diambil dari tautan bila disaster_id kosong. Tenggat memakai format DD/MM/YYYY HH:mm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Create a field task",
+                "parameters": [
+                    {
+                        "description": "Data tugas",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.TaskInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/mine": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan tugas yang ditugaskan ke relawan milik pengguna yang login, diurutkan dari prioritas tertinggi dan tenggat terdekat. Tugas selesai dan dibatalkan hanya ditampilkan dengan include_closed=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get my tasks",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan tugas yang sudah selesai atau dibatalkan",
+                        "name": "include_closed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan detail tugas beserta relawan yang ditugaskan, komentar, dan riwayat status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get task by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui judul, deskripsi, prioritas, keahlian yang dibutuhkan, atau tenggat tugas yang belum selesai. Perubahan keahlian menurunkan ulang sertifikasi wajib bila required_certifications tidak diisi, dan ditolak (422) bila relawan yang sudah ditugaskan tidak memenuhinya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Update a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data tugas yang diperbarui",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.TaskInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus tugas beserta penugasan, komentar, dan riwayat statusnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assignees": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menugaskan relawan ke tugas. Tugas berstatus open otomatis menjadi assigned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Assign a volunteer to a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relawan yang ditugaskan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.TaskAssignInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assignees/{volunteer_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melepas relawan dari tugas. Tugas berstatus assigned kembali menjadi open bila tidak ada relawan tersisa",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Remove a volunteer from a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "volunteer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/comments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan komentar atau laporan perkembangan pada tugas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Comment on a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Isi komentar",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.TaskCommentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/matches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memberi peringkat relawan yang cocok untuk tugas berdasarkan kecocokan keahlian, jarak, status, dan beban kerja. Relawan yang sudah ditugaskan tidak ikut diperingkat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skill"
+                ],
+                "summary": "Rank volunteers for a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keahlian pengganti, dipisah koma (default keahlian yang dibutuhkan tugas)",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah relawan (default 10, maksimal 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah status tugas oleh admin atau relawan yang ditugaskan. Status assigned dan open diatur otomatis saat relawan ditambah atau dilepas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Update task status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status baru: in_progress, blocked, done, atau cancelled",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.TaskStatusInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan semua user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/structs.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Membuat user baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create new user",
+                "parameters": [
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/enable-2fa": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengaktifkan atau menonaktifkan 2FA",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Enable 2FA",
+                "parameters": [
+                    {
+                        "description": "User 2FA status",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.Enable2FA"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Autentikasi user untuk mendapatkan token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.Login"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verify-otp": {
+            "post": {
+                "description": "Verifikasi OTP untuk mendapatkan token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify OTP",
+                "parameters": [
+                    {
+                        "description": "User OTP verification",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.VerifyOTP"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan user berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus user berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/change-role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah role pengguna",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change user role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List role: admin, donor, user",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.ChangeUserRole"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/donations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan daftar donasi yang diberikan oleh user tertentu beserta total donasi uang terkonfirmasi per mata uang dan dalam mata uang laporan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get donations by user ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/edit-info": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data user tanpa email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user without email",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.UpdateUserInfoWithoutEmail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/emergency-reports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan semua laporan darurat yang dikirim oleh pengguna tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get emergency reports by user ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan daftar relawan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Volunteer"
+                ],
+                "summary": "Get all volunteers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Titik pusat pencarian dalam format lat,lon",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius pencarian dalam kilometer (wajib bersama near)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bounding box dalam format min_lat,min_lon,max_lat,max_lon",
+                        "name": "bbox",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat relawan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Volunteer"
+                ],
+                "summary": "Create a volunteer",
+                "parameters": [
+                    {
+                        "description": "Data relawan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.VolunteerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/on-site": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar relawan yang sedang check-in dan belum check-out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Get volunteers currently on site",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan relawan berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Volunteer"
+                ],
+                "summary": "Get a volunteer by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID relawan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui relawan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Volunteer"
+                ],
+                "summary": "Update a volunteer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID relawan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data relawan",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.VolunteerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus relawan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Volunteer"
+                ],
+                "summary": "Delete a volunteer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID relawan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/attendances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Riwayat check-in dan check-out relawan, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Get volunteer attendance history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/certificate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh sertifikat pengabdian relawan (PDF) berisi total jam layanan dan rincian per bencana",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Download volunteer service certificate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sertifikat untuk satu bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/certifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar sertifikasi relawan beserta status verifikasi, sisa hari sebelum kedaluwarsa, dan tanda expiring_soon bila kedaluwarsa dalam CERTIFICATION_WARNING_DAYS hari (default 30)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Get volunteer certifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan sertifikasi atau lisensi relawan beserta dokumen pendukung (gambar atau PDF maksimal 5MB). Sertifikasi berstatus pending sampai diverifikasi admin. Tanggal memakai format DD/MM/YYYY",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Add a volunteer certification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jenis sertifikasi, misal medical_license atau sar_certificate",
+                        "name": "certification_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Penerbit sertifikasi",
+                        "name": "issuer",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nomor sertifikasi",
+                        "name": "certificate_number",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal terbit (DD/MM/YYYY)",
+                        "name": "issued_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal kedaluwarsa (DD/MM/YYYY)",
+                        "name": "expires_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Dokumen sertifikasi",
+                        "name": "document",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/certifications/{certification_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus sertifikasi relawan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Delete a volunteer certification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Certification ID",
+                        "name": "certification_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/certifications/{certification_id}/document": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh dokumen pendukung sertifikasi relawan",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Download certification document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Certification ID",
+                        "name": "certification_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/check-in": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat check-in relawan di lokasi, opsional untuk shift tertentu. Status relawan menjadi on_mission sampai check-out. Lokasi default dari shift atau lokasi relawan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Check in a volunteer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data check-in",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.CheckInInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/check-out": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menutup check-in relawan dan mengembalikan status relawan seperti sebelum check-in. Admin dapat mengisi checked_out_at (DD/MM/YYYY HH:mm) untuk check-in yang terlupa ditutup",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Check out a volunteer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data check-out",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/structs.CheckOutInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/hours": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total jam layanan relawan dari sesi yang sudah check-out, dirinci per bencana",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Get volunteer service hours",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter berdasarkan bencana",
+                        "name": "disaster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/roster": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Jadwal shift yang diambil relawan, default hari ini sampai 7 hari ke depan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Get volunteer roster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (DD/MM/YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (DD/MM/YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volunteers/{id}/skills": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan keahlian relawan beserta tingkat kemahirannya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skill"
+                ],
+                "summary": "Get volunteer skills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti seluruh keahlian relawan. Keahlian ditulis sebagai kode atau nama dari taksonomi, tingkat kemahiran default 'intermediate'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skill"
+                ],
+                "summary": "Replace volunteer skills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Daftar keahlian",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/structs.VolunteerSkillInput"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/structs.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "structs.APIResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "result": {}
+            }
+        },
+        "structs.AllocationAcceptInput": {
+            "type": "object",
+            "required": [
+                "logistic_id"
+            ],
+            "properties": {
+                "logistic_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "recipient_name": {
+                    "type": "string"
+                },
+                "sender_name": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.CertificationReviewInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.ChangeUserRole": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.CheckInInput": {
+            "type": "object",
+            "properties": {
+                "disaster_id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "shift_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "structs.CheckOutInput": {
+            "type": "object",
+            "properties": {
+                "checked_out_at": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.Disaster": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "location_accuracy": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "reported_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.DisasterInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "location_accuracy": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "reported_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.DisbursementInput": {
+            "type": "object",
+            "required": [
+                "amount",
+                "purpose",
+                "recipient"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "disaster_id": {
+                    "type": "integer"
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.DistributionLogInput": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "logistic_id": {
+                    "type": "integer"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "quantity_sent": {
+                    "type": "integer"
+                },
+                "recipient_name": {
+                    "type": "string"
+                },
+                "sender_name": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.DistributionStatusInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.DonationInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "disaster_id": {
+                    "type": "integer"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "item_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "structs.EmergencyReportInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "disaster_id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "location_accuracy": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "structs.Enable2FA": {
+            "type": "object",
+            "properties": {
+                "is_2fa": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "structs.EvacuationRouteInput": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string"
+                },
+                "disaster_id": {
+                    "type": "integer"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "geometry": {
+                    "$ref": "#/definitions/structs.LineStringInput"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "polyline": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.ExchangeRateInput": {
+            "type": "object",
+            "required": [
+                "rates"
+            ],
+            "properties": {
+                "base_currency": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "structs.GeoJSONFeature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "$ref": "#/definitions/structs.GeoJSONGeometry"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.GeoJSONFeatureCollection": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/structs.GeoJSONFeature"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.GeoJSONGeometry": {
+            "type": "object",
+            "properties": {
+                "coordinates": {},
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.HouseholdInput": {
+            "type": "object",
+            "properties": {
+                "disaster_id": {
+                    "type": "integer"
+                },
+                "head_refugee_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.HouseholdMemberInput": {
+            "type": "object",
+            "required": [
+                "refugee_id"
+            ],
+            "properties": {
+                "refugee_id": {
+                    "type": "integer"
+                },
+                "relationship": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.HouseholdShelterInput": {
+            "type": "object",
+            "required": [
+                "shelter_id"
+            ],
+            "properties": {
+                "override_capacity": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "shelter_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "structs.ItemInput": {
+            "type": "object",
+            "properties": {
+                "base_unit": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/structs.ItemUnit"
+                    }
+                }
+            }
+        },
+        "structs.ItemUnit": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.LineStringInput": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
                 },
-                "message": {
+                "type": {
                     "type": "string"
-                },
-                "result": {}
+                }
             }
         },
-        "structs.ChangeUserRole": {
+        "structs.Login": {
             "type": "object",
             "properties": {
-                "role": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "structs.Disaster": {
+        "structs.LogisticInput": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "batch_number": {
                     "type": "string"
                 },
-                "description": {
+                "disaster_id": {
+                    "type": "integer"
+                },
+                "expiry_date": {
                     "type": "string"
                 },
-                "id": {
+                "item_id": {
                     "type": "integer"
                 },
+                "latitude": {
+                    "type": "number"
+                },
                 "location": {
                     "type": "string"
                 },
-                "reported_by": {
-                    "type": "integer"
+                "longitude": {
+                    "type": "number"
                 },
-                "status": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
                 },
                 "type": {
                     "type": "string"
                 },
-                "updated_at": {
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "structs.DisasterInput": {
+        "structs.MissingPersonInput": {
             "type": "object",
             "properties": {
-                "description": {
+                "age_max": {
+                    "type": "integer"
+                },
+                "age_min": {
+                    "type": "integer"
+                },
+                "contact_email": {
                     "type": "string"
                 },
-                "location": {
+                "contact_name": {
                     "type": "string"
                 },
-                "reported_by": {
+                "contact_phone": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "disaster_id": {
                     "type": "integer"
                 },
-                "status": {
+                "last_seen_at": {
                     "type": "string"
                 },
-                "type": {
+                "last_seen_location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "structs.DistributionLogInput": {
+        "structs.MissingPersonMatchInput": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "destination": {
+                "status": {
                     "type": "string"
-                },
-                "distance": {
-                    "type": "number"
-                },
-                "logistic_id": {
+                }
+            }
+        },
+        "structs.MockPaymentInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.NeedRequestInput": {
+            "type": "object",
+            "properties": {
+                "item_id": {
                     "type": "integer"
                 },
-                "origin": {
+                "item_name": {
                     "type": "string"
                 },
-                "quantity_sent": {
-                    "type": "integer"
+                "note": {
+                    "type": "string"
                 },
-                "recipient_name": {
+                "priority": {
                     "type": "string"
                 },
-                "sender_name": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
-                "sent_at": {
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "structs.DonationInput": {
+        "structs.RefugeeInput": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "age": {
+                    "type": "integer"
+                },
+                "condition": {
+                    "type": "string"
                 },
                 "disaster_id": {
                     "type": "integer"
                 },
-                "item_name": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
+                "needs": {
                     "type": "string"
                 },
-                "user_id": {
+                "override_capacity": {
+                    "type": "boolean"
+                },
+                "shelter_id": {
                     "type": "integer"
                 }
             }
         },
-        "structs.EmergencyReportInput": {
+        "structs.RefugeeMergeInput": {
             "type": "object",
+            "required": [
+                "duplicate_id"
+            ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "disaster_id": {
+                "duplicate_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "structs.RefugeeTransferInput": {
+            "type": "object",
+            "required": [
+                "reason",
+                "shelter_id"
+            ],
+            "properties": {
+                "override_capacity": {
+                    "type": "boolean"
                 },
-                "location": {
+                "reason": {
                     "type": "string"
                 },
-                "user_id": {
+                "shelter_id": {
                     "type": "integer"
                 }
             }
         },
-        "structs.Enable2FA": {
+        "structs.RouteSegmentInput": {
             "type": "object",
             "properties": {
-                "is_2fa": {
-                    "type": "boolean"
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "structs.EvacuationRouteInput": {
+        "structs.ShelterInput": {
             "type": "object",
             "properties": {
-                "destination": {
-                    "type": "string"
+                "capacity_total": {
+                    "type": "integer"
                 },
                 "disaster_id": {
                     "type": "integer"
                 },
-                "distance": {
+                "emergency_needs": {
+                    "type": "string"
+                },
+                "latitude": {
                     "type": "number"
                 },
-                "origin": {
+                "location": {
                     "type": "string"
                 },
-                "route": {
-                    "type": "string"
+                "location_accuracy": {
+                    "type": "number"
                 },
-                "status": {
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
+                },
+                "override_capacity": {
+                    "type": "boolean"
                 }
             }
         },
-        "structs.Login": {
+        "structs.ShiftAssignInput": {
             "type": "object",
+            "required": [
+                "volunteer_id"
+            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
+                "volunteer_id": {
+                    "type": "integer"
                 }
             }
         },
-        "structs.LogisticInput": {
+        "structs.ShiftInput": {
             "type": "object",
             "properties": {
                 "disaster_id": {
                     "type": "integer"
                 },
-                "quantity": {
+                "end_time": {
+                    "type": "string"
+                },
+                "headcount": {
                     "type": "integer"
                 },
-                "status": {
+                "location": {
                     "type": "string"
                 },
-                "type": {
+                "note": {
+                    "type": "string"
+                },
+                "required_certifications": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shelter_id": {
+                    "type": "integer"
+                },
+                "start_time": {
                     "type": "string"
                 }
             }
         },
-        "structs.RefugeeInput": {
+        "structs.SkillInput": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "age": {
-                    "type": "integer"
+                "category": {
+                    "type": "string"
                 },
-                "condition": {
+                "code": {
                     "type": "string"
                 },
-                "disaster_id": {
+                "name": {
+                    "type": "string"
+                },
+                "required_certification": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.StockMovementInput": {
+            "type": "object",
+            "required": [
+                "movement_type",
+                "quantity"
+            ],
+            "properties": {
+                "batch_id": {
                     "type": "integer"
                 },
-                "name": {
+                "batch_number": {
                     "type": "string"
                 },
-                "needs": {
+                "expiry_date": {
                     "type": "string"
                 },
-                "shelter_id": {
+                "movement_type": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "quantity": {
                     "type": "integer"
+                },
+                "unit": {
+                    "type": "string"
                 }
             }
         },
-        "structs.ShelterInput": {
+        "structs.TaskAssignInput": {
             "type": "object",
+            "required": [
+                "volunteer_id"
+            ],
             "properties": {
-                "capacity_remaining": {
+                "volunteer_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "structs.TaskCommentInput": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.TaskInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
                 },
-                "capacity_total": {
+                "disaster_id": {
                     "type": "integer"
                 },
-                "disaster_id": {
+                "distribution_log_id": {
                     "type": "integer"
                 },
-                "emergency_needs": {
+                "due_at": {
                     "type": "string"
                 },
-                "location": {
+                "emergency_report_id": {
+                    "type": "integer"
+                },
+                "priority": {
                     "type": "string"
                 },
-                "name": {
+                "required_certifications": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shelter_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.TaskStatusInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.TriageInput": {
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "note": {
                     "type": "string"
                 }
             }
@@ -3539,9 +10673,18 @@ const docTemplate = `{
                 "disaster_id": {
                     "type": "integer"
                 },
+                "latitude": {
+                    "type": "number"
+                },
                 "location": {
                     "type": "string"
                 },
+                "location_accuracy": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
                 "skill": {
                     "type": "string"
                 },
@@ -3552,6 +10695,20 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "structs.VolunteerSkillInput": {
+            "type": "object",
+            "required": [
+                "skill"
+            ],
+            "properties": {
+                "proficiency": {
+                    "type": "string"
+                },
+                "skill": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -8,12 +8,15 @@ import (
 	"RescueHub/database"
 	"RescueHub/controllers"
 	"RescueHub/middlewares"
+	"RescueHub/payment"
+	"RescueHub/repository"
 	"github.com/swaggo/gin-swagger"
 	"github.com/swaggo/files"
 	"github.com/gin-contrib/cors"
 	"time"
 	_ "RescueHub/docs"
 	"os"
+	"strings"
 
 	_ "github.com/lib/pq"
 )
@@ -75,8 +78,29 @@ func main() {
 
 	database.DBMigrate(DB)
 
+	if path := os.Getenv("EXCHANGE_RATES_FILE"); path != "" {
+		if err := repository.LoadExchangeRatesFile(DB, path); err != nil {
+			fmt.Println("Gagal memuat file kurs:", err)
+		}
+	}
+
+	if err := repository.BootstrapLedger(DB); err != nil {
+		fmt.Println("Gagal menyiapkan buku besar donasi:", err)
+	}
+
 	router := gin.Default()
 
+	// IP klien untuk rate limit hanya diambil dari X-Forwarded-For bila permintaan datang dari proxy di TRUSTED_PROXIES
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		panic(err)
+	}
+
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
@@ -118,6 +142,24 @@ func main() {
 			disasterRoutes.GET("/:id/shelters", controllers.GetSheltersByDisasterID)
 			disasterRoutes.GET("/:id/emergency-reports", controllers.GetEmergencyReportsByDisasterID)
 			disasterRoutes.GET("/:id/evacuation-routes", controllers.GetEvacuationRoutesByDisasterID)
+			disasterRoutes.GET("/:id/map.geojson", controllers.GetDisasterMapGeoJSON)
+			disasterRoutes.GET("/:id/map.kml", controllers.GetDisasterMapKML)
+			disasterRoutes.GET("/:id/triage", controllers.GetDisasterTriageSummary)
+
+			disasterRoutes.GET("/:id/tasks", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat papan tugas",
+				"admin",
+			), controllers.GetTaskBoard)
+
+			disasterRoutes.GET("/:id/hours", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat jam layanan relawan",
+				"admin",
+			), controllers.GetDisasterServiceHours)
+
+			disasterRoutes.GET("/:id/donations", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin dan donatur yang bisa melihat donasi bencana",
+				"admin", "donor",
+			), controllers.GetDonationsByDisasterID)
 
 			disasterRoutes.POST("/", middlewares.RequireVolunteerOrRole(
 				"Akses ditolak, hanya admin dan relawan yang bisa melaporkan bencana",
@@ -143,6 +185,11 @@ func main() {
 				"Akses ditolak, hanya admin dan relawan yang bisa melihat daftar logistik",
 				"admin",
 			), controllers.GetLogisticsByDisasterID)
+
+			disasterRoutes.GET("/:id/allocations", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat usulan alokasi",
+				"admin",
+			), controllers.GetAllocationProposals)
 		}
 
 
@@ -152,6 +199,18 @@ func main() {
 			shelterRoutes.GET("/:id", controllers.GetShelterByID)
 			shelterRoutes.GET("/:id/refugees", controllers.GetRefugeesByShelterID)
 			shelterRoutes.GET("/:id/logistics", controllers.GetLogisticsByShelterID)
+			shelterRoutes.GET("/:id/triage", controllers.GetShelterTriageSummary)
+			shelterRoutes.GET("/:id/needs", controllers.GetNeedRequestsByShelterID)
+
+			shelterRoutes.GET("/:id/roster", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat jadwal shelter",
+				"admin",
+			), controllers.GetShelterRoster)
+
+			shelterRoutes.POST("/:id/needs", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mencatat kebutuhan shelter",
+				"admin",
+			), controllers.CreateNeedRequest)
 
 			shelterRoutes.POST("/", middlewares.RequireVolunteerOrRole(
 				"Akses ditolak, hanya admin dan relawan yang bisa menambahkan shelter",
@@ -173,6 +232,13 @@ func main() {
 		{
 		refugeeRoutes.GET("/", controllers.GetAllRefugees)
 		refugeeRoutes.GET("/:id", controllers.GetRefugeeByID)
+		refugeeRoutes.GET("/:id/movements", controllers.GetRefugeeMovements)
+		refugeeRoutes.GET("/:id/triage", controllers.GetRefugeeTriageHistory)
+
+		refugeeRoutes.POST("/:id/triage", middlewares.RequireVolunteerOrRole(
+			"Akses ditolak, hanya admin dan relawan yang bisa mencatat triase",
+			"admin",
+		), controllers.TriageRefugee)
 
 		refugeeRoutes.POST("/", middlewares.RequireVolunteerOrRole(
 			"Akses ditolak, hanya admin dan relawan yang bisa mencatat pengungsi",
@@ -184,6 +250,21 @@ func main() {
 			"admin",
 		), controllers.UpdateRefugee)
 
+		refugeeRoutes.GET("/:id/duplicates", middlewares.RequireVolunteerOrRole(
+			"Akses ditolak, hanya admin dan relawan yang bisa melihat data pengungsi ganda",
+			"admin",
+		), controllers.GetRefugeeDuplicates)
+
+		refugeeRoutes.POST("/:id/merge", middlewares.RequireRoles(
+			"Akses ditolak, hanya admin yang bisa menggabungkan data pengungsi",
+			"admin",
+		), controllers.MergeRefugees)
+
+		refugeeRoutes.POST("/:id/transfer", middlewares.RequireVolunteerOrRole(
+			"Akses ditolak, hanya admin dan relawan yang bisa memindahkan pengungsi",
+			"admin",
+		), controllers.TransferRefugee)
+
 		refugeeRoutes.DELETE("/:id", middlewares.RequireRoles(
 			"Akses ditolak, hanya admin yang bisa menghapus data pengungsi",
 			"admin",
@@ -191,10 +272,123 @@ func main() {
 		}
 
 
+		householdRoutes := api.Group("/households", middlewares.JWTAuthMiddleware())
+		{
+			householdRoutes.GET("/", controllers.GetAllHouseholds)
+			householdRoutes.GET("/:id", controllers.GetHouseholdByID)
+
+			householdRoutes.POST("/", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mencatat keluarga",
+				"admin",
+			), controllers.CreateHousehold)
+
+			householdRoutes.PUT("/:id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengedit data keluarga",
+				"admin",
+			), controllers.UpdateHousehold)
+
+			householdRoutes.DELETE("/:id", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menghapus data keluarga",
+				"admin",
+			), controllers.DeleteHousehold)
+
+			householdRoutes.POST("/:id/members", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengubah anggota keluarga",
+				"admin",
+			), controllers.AddHouseholdMember)
+
+			householdRoutes.DELETE("/:id/members/:refugee_id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengubah anggota keluarga",
+				"admin",
+			), controllers.RemoveHouseholdMember)
+
+			householdRoutes.PUT("/:id/shelter", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa menempatkan keluarga",
+				"admin",
+			), controllers.AssignHouseholdShelter)
+		}
+
+		missingPersonRoutes := api.Group("/missing_persons", middlewares.JWTAuthMiddleware())
+		{
+			missingPersonRoutes.GET("/", controllers.GetAllMissingPersons)
+			missingPersonRoutes.GET("/:id", controllers.GetMissingPersonByID)
+			missingPersonRoutes.POST("/", controllers.CreateMissingPerson)
+
+			missingPersonRoutes.PUT("/:id", middlewares.RequireSelfForRelatedEntities(
+				"Akses ditolak, hanya pelapor atau admin yang bisa mengedit laporan orang hilang",
+				"missing_persons",
+				"reported_by",
+			), controllers.UpdateMissingPerson)
+
+			missingPersonRoutes.DELETE("/:id", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menghapus laporan orang hilang",
+				"admin",
+			), controllers.DeleteMissingPerson)
+
+			missingPersonRoutes.POST("/:id/match", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa menjalankan pencocokan",
+				"admin",
+			), controllers.RunMissingPersonMatcher)
+
+			missingPersonRoutes.GET("/:id/matches", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat kandidat kecocokan",
+				"admin",
+			), controllers.GetMissingPersonMatches)
+
+			missingPersonRoutes.PUT("/:id/matches/:match_id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengonfirmasi kecocokan",
+				"admin",
+			), controllers.ReviewMissingPersonMatch)
+		}
+
+		needRequestRoutes := api.Group("/need_requests", middlewares.JWTAuthMiddleware())
+		{
+			needRequestRoutes.GET("/:id", controllers.GetNeedRequestByID)
+
+			needRequestRoutes.PUT("/:id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengedit kebutuhan shelter",
+				"admin",
+			), controllers.UpdateNeedRequest)
+
+			needRequestRoutes.DELETE("/:id", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menghapus kebutuhan shelter",
+				"admin",
+			), controllers.DeleteNeedRequest)
+
+			needRequestRoutes.POST("/:id/allocate", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa menerima usulan alokasi",
+				"admin",
+			), controllers.AcceptAllocation)
+		}
+
+		itemRoutes := api.Group("/items", middlewares.JWTAuthMiddleware())
+		{
+			itemRoutes.GET("/", controllers.GetAllItems)
+			itemRoutes.GET("/:id", controllers.GetItemByID)
+
+			itemRoutes.POST("/", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa menambahkan barang ke katalog",
+				"admin",
+			), controllers.CreateItem)
+
+			itemRoutes.PUT("/:id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengedit katalog barang",
+				"admin",
+			), controllers.UpdateItem)
+
+			itemRoutes.DELETE("/:id", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menghapus barang dari katalog",
+				"admin",
+			), controllers.DeleteItem)
+		}
+
 		logisticRoutes := api.Group("/logistics", middlewares.JWTAuthMiddleware()) 
 		{
 			logisticRoutes.GET("/", controllers.GetAllLogistics)
+			logisticRoutes.GET("/expiring", controllers.GetExpiringLogistics)
 			logisticRoutes.GET("/:id", controllers.GetLogisticByID)
+			logisticRoutes.GET("/:id/movements", controllers.GetStockLedger)
+			logisticRoutes.GET("/:id/batches", controllers.GetLogisticBatches)
 
 			logisticRoutes.POST("/", middlewares.RequireVolunteerOrRole(
 				"Akses ditolak, hanya admin dan relawan yang bisa mencatat logistik",
@@ -206,6 +400,11 @@ func main() {
 				"admin",
 			), controllers.UpdateLogistic)
 
+			logisticRoutes.POST("/:id/movements", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mencatat pergerakan stok",
+				"admin",
+			), controllers.RecordStockMovement)
+
 			logisticRoutes.DELETE("/:id", middlewares.RequireRoles(
 				"Akses ditolak, hanya admin yang bisa menghapus data logistik",
 				"admin",
@@ -216,6 +415,14 @@ func main() {
 		{
 			distributionLogRoutes.GET("/", controllers.GetAllDistributionLogs)
 			distributionLogRoutes.GET("/:id", controllers.GetDistributionLogByID)
+			distributionLogRoutes.GET("/:id/delivery", controllers.GetDistributionDelivery)
+			distributionLogRoutes.GET("/:id/proofs/:proof_id", controllers.GetDistributionProof)
+			distributionLogRoutes.POST("/:id/confirm", controllers.ConfirmDelivery)
+
+			distributionLogRoutes.PUT("/:id/status", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa memperbarui status distribusi",
+				"admin",
+			), controllers.UpdateDistributionStatus)
 
 			distributionLogRoutes.POST("/", middlewares.RequireVolunteerOrRole(
 				"Akses ditolak, hanya admin dan relawan yang bisa mencatat distribusi bantuan",
@@ -237,6 +444,7 @@ func main() {
 		evacuationRouteRoutes := api.Group("/evacuation_routes", middlewares.JWTAuthMiddleware()) 
 		{
 			evacuationRouteRoutes.GET("/", controllers.GetAllEvacuationRoutes)
+			evacuationRouteRoutes.GET("/plan", controllers.PlanEvacuationRoute)
 			evacuationRouteRoutes.GET("/:id", controllers.GetEvacuationRouteByID)
 
 			evacuationRouteRoutes.POST("/", middlewares.RequireVolunteerOrRole(
@@ -249,6 +457,11 @@ func main() {
 				"admin",
 			), controllers.UpdateEvacuationRoute)
 
+			evacuationRouteRoutes.PUT("/:id/segments/:sequence", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa memperbarui status segmen jalur evakuasi",
+				"admin",
+			), controllers.UpdateEvacuationRouteSegment)
+
 			evacuationRouteRoutes.DELETE("/:id", middlewares.RequireRoles(
 				"Akses ditolak, hanya admin yang bisa menghapus jalur evakuasi",
 				"admin",
@@ -282,6 +495,66 @@ func main() {
 				"emergency_reports",
 				"user_id",
 			), controllers.DeleteEmergencyReport)
+
+			emergencyReportRoutes.GET("/:id/triage", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat riwayat triase",
+				"admin",
+			), controllers.GetEmergencyReportTriageHistory)
+
+			emergencyReportRoutes.POST("/:id/triage", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mencatat triase",
+				"admin",
+			), controllers.TriageEmergencyReport)
+
+			emergencyReportRoutes.GET("/:id/matches", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mencari relawan untuk laporan darurat",
+				"admin",
+			), controllers.GetEmergencyReportMatches)
+		}
+
+		// Endpoint tanpa login berbagi satu batas permintaan per IP
+		publicRateLimit := middlewares.PublicRateLimit()
+
+		api.GET("/receipts/verify/:code", publicRateLimit, controllers.VerifyDonationReceipt)
+
+		publicRoutes := api.Group("/public", publicRateLimit)
+		{
+			publicRoutes.GET("/disasters", controllers.GetPublicDisasters)
+			publicRoutes.GET("/shelters", controllers.GetPublicShelters)
+			publicRoutes.GET("/evacuation_routes", controllers.GetPublicEvacuationRoutes)
+		}
+
+		api.GET("/ledger", publicRateLimit, controllers.GetLedger)
+		api.GET("/ledger/verify", publicRateLimit, controllers.VerifyLedger)
+
+		disbursementRoutes := api.Group("/disbursements", middlewares.JWTAuthMiddleware(), middlewares.RequireRoles(
+			"Akses ditolak, hanya admin yang bisa mengelola pengeluaran",
+			"admin",
+		))
+		{
+			disbursementRoutes.GET("/", controllers.GetAllDisbursements)
+			disbursementRoutes.POST("/", controllers.CreateDisbursement)
+		}
+
+		exchangeRateRoutes := api.Group("/exchange_rates", middlewares.JWTAuthMiddleware())
+		{
+			exchangeRateRoutes.GET("/", controllers.GetExchangeRates)
+
+			exchangeRateRoutes.PUT("/", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa memperbarui kurs",
+				"admin",
+			), controllers.UpdateExchangeRates)
+		}
+
+		paymentRoutes := api.Group("/payments")
+		{
+			paymentRoutes.POST("/webhook/:provider", controllers.PaymentWebhook)
+
+			// Halaman checkout mock hanya tersedia untuk pengembangan lokal
+			if payment.MockEnabled() {
+				paymentRoutes.GET("/mock/:reference", middlewares.JWTAuthMiddleware(), controllers.GetMockPayment)
+				paymentRoutes.POST("/mock/:reference", middlewares.JWTAuthMiddleware(), controllers.PayMockPayment)
+			}
 		}
 
 		donationRoutes := api.Group("/donations", middlewares.JWTAuthMiddleware()) 
@@ -299,6 +572,24 @@ func main() {
 				"donor_id",
 			), controllers.GetDonationByID)
 
+			donationRoutes.GET("/:id/trace", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa menelusuri donasi Anda sendiri",
+				"donations",
+				"donor_id",
+			), controllers.GetDonationTrace)
+
+			donationRoutes.GET("/:id/receipt", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa mengunduh kuitansi donasi Anda sendiri",
+				"donations",
+				"donor_id",
+			), controllers.GetDonationReceipt)
+
+			donationRoutes.POST("/:id/pay", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa membayar donasi Anda sendiri",
+				"donations",
+				"donor_id",
+			), controllers.CreateDonationPayment)
+
 			donationRoutes.PUT("/:id", middlewares.RequireSelfForRelatedEntities(
 				"Anda hanya bisa mengedit donasi Anda sendiri",
 				"donations",
@@ -316,6 +607,11 @@ func main() {
 		{
 			volunteerRoutes.POST("/", controllers.CreateVolunteer)
 
+			volunteerRoutes.GET("/on-site", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat relawan di lokasi",
+				"admin",
+			), controllers.GetOnSiteVolunteers)
+
 			volunteerRoutes.GET("/", middlewares.RequireVolunteerOrRole(
 				"Akses ditolak, hanya admin dan relawan yang bisa melihat semua relawan",
 				"admin",
@@ -338,6 +634,184 @@ func main() {
 				"volunteers",
 				"user_id",
 			), controllers.DeleteVolunteer)
+
+			volunteerRoutes.GET("/:id/roster", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa melihat jadwal relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.GetVolunteerRoster)
+
+			volunteerRoutes.GET("/:id/skills", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa melihat keahlian relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.GetVolunteerSkills)
+
+			volunteerRoutes.PUT("/:id/skills", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa mengedit keahlian relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.SetVolunteerSkills)
+
+			volunteerRoutes.POST("/:id/check-in", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa check-in sebagai relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.CheckInVolunteer)
+
+			volunteerRoutes.POST("/:id/check-out", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa check-out sebagai relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.CheckOutVolunteer)
+
+			volunteerRoutes.GET("/:id/attendances", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa melihat riwayat kehadiran relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.GetVolunteerAttendances)
+
+			volunteerRoutes.GET("/:id/hours", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa melihat jam layanan relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.GetVolunteerServiceHours)
+
+			volunteerRoutes.GET("/:id/certificate", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa mengunduh sertifikat relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.GetServiceCertificate)
+
+			volunteerRoutes.GET("/:id/certifications", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa melihat sertifikasi relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.GetVolunteerCertifications)
+
+			volunteerRoutes.POST("/:id/certifications", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa menambahkan sertifikasi relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.CreateCertification)
+
+			volunteerRoutes.GET("/:id/certifications/:certification_id/document", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa mengunduh dokumen sertifikasi relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.GetCertificationDocument)
+
+			volunteerRoutes.DELETE("/:id/certifications/:certification_id", middlewares.RequireSelfForRelatedEntities(
+				"Anda hanya bisa menghapus sertifikasi relawan Anda sendiri",
+				"volunteers",
+				"user_id",
+			), controllers.DeleteCertification)
+		}
+
+		certificationRoutes := api.Group("/certifications", middlewares.JWTAuthMiddleware(), middlewares.RequireRoles(
+			"Akses ditolak, hanya admin yang bisa memverifikasi sertifikasi relawan",
+			"admin",
+		))
+		{
+			certificationRoutes.GET("/", controllers.GetCertifications)
+			certificationRoutes.GET("/expiring", controllers.GetExpiringCertifications)
+			certificationRoutes.PUT("/:id/review", controllers.ReviewCertification)
+		}
+
+		skillRoutes := api.Group("/skills", middlewares.JWTAuthMiddleware())
+		{
+			skillRoutes.GET("/", controllers.GetSkills)
+
+			skillRoutes.POST("/", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menambahkan keahlian",
+				"admin",
+			), controllers.CreateSkill)
+		}
+
+		shiftRoutes := api.Group("/shifts", middlewares.JWTAuthMiddleware())
+		{
+			shiftRoutes.GET("/", controllers.GetShifts)
+
+			shiftRoutes.GET("/conflicts", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa memeriksa bentrok shift",
+				"admin",
+			), controllers.GetShiftConflicts)
+
+			shiftRoutes.GET("/:id", controllers.GetShiftByID)
+
+			shiftRoutes.POST("/", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa membuat shift",
+				"admin",
+			), controllers.CreateShift)
+
+			shiftRoutes.PUT("/:id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengedit shift",
+				"admin",
+			), controllers.UpdateShift)
+
+			shiftRoutes.DELETE("/:id", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menghapus shift",
+				"admin",
+			), controllers.DeleteShift)
+
+			shiftRoutes.POST("/:id/signup", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya relawan yang bisa mendaftar ke shift",
+				"admin",
+			), controllers.SignUpForShift)
+
+			shiftRoutes.POST("/:id/assignments", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menugaskan relawan ke shift",
+				"admin",
+			), controllers.AssignVolunteerToShift)
+
+			shiftRoutes.DELETE("/:id/assignments/:volunteer_id", controllers.RemoveShiftAssignment)
+		}
+
+		taskRoutes := api.Group("/tasks", middlewares.JWTAuthMiddleware())
+		{
+			taskRoutes.GET("/mine", controllers.GetMyTasks)
+
+			taskRoutes.GET("/:id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melihat tugas",
+				"admin",
+			), controllers.GetTaskByID)
+
+			taskRoutes.GET("/:id/matches", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mencari relawan untuk tugas",
+				"admin",
+			), controllers.GetTaskMatches)
+
+			taskRoutes.POST("/", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa membuat tugas",
+				"admin",
+			), controllers.CreateTask)
+
+			taskRoutes.PUT("/:id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengedit tugas",
+				"admin",
+			), controllers.UpdateTask)
+
+			taskRoutes.DELETE("/:id", middlewares.RequireRoles(
+				"Akses ditolak, hanya admin yang bisa menghapus tugas",
+				"admin",
+			), controllers.DeleteTask)
+
+			taskRoutes.PUT("/:id/status", controllers.UpdateTaskStatus)
+
+			taskRoutes.POST("/:id/assignees", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa menugaskan relawan",
+				"admin",
+			), controllers.AssignTask)
+
+			taskRoutes.DELETE("/:id/assignees/:volunteer_id", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa melepas relawan dari tugas",
+				"admin",
+			), controllers.UnassignTask)
+
+			taskRoutes.POST("/:id/comments", middlewares.RequireVolunteerOrRole(
+				"Akses ditolak, hanya admin dan relawan yang bisa mengomentari tugas",
+				"admin",
+			), controllers.AddTaskComment)
 		}
 	}
 
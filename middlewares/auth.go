@@ -3,9 +3,6 @@ package middlewares
 import (
 	"crypto/rand"
 	"fmt"
-	"net/smtp"
-
-	"github.com/jordan-wright/email"
 )
 
 func GenerateOTP() string {
@@ -20,12 +17,7 @@ func GenerateOTP() string {
 }
 
 func SendOTPToEmail(toEmail, otp string) error {
-	e := email.NewEmail()
-	e.From = "RescueHub <bemysample.id@gmail.com>"
-	e.To = []string{toEmail}
-	e.Subject = "Kode OTP Anda"
-
-	e.HTML = []byte(fmt.Sprintf(`
+	e := newEmail(toEmail, "Kode OTP Anda", fmt.Sprintf(`
 		<!DOCTYPE html>
 		<html>
 		<head>
@@ -65,8 +57,5 @@ func SendOTPToEmail(toEmail, otp string) error {
 		</html>
 	`, otp))
 
-	err := e.Send("smtp.gmail.com:587", smtp.PlainAuth("", "bemysample.id@gmail.com", "uhse oyou lalj konk", "smtp.gmail.com"))
-	return err
-
-	// Get the password from Google App Passwords
+	return sendEmail(e)
 }
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"errors"
+	"net/smtp"
+	"os"
+
+	"github.com/jordan-wright/email"
+)
+
+// Pengaturan SMTP dibaca dari SMTP_HOST (default smtp.gmail.com), SMTP_PORT (default 587), SMTP_USERNAME, SMTP_PASSWORD, dan SMTP_FROM (default SMTP_USERNAME)
+func newEmail(toEmail, subject, body string) *email.Email {
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_USERNAME")
+	}
+
+	e := email.NewEmail()
+	e.From = "RescueHub <" + from + ">"
+	e.To = []string{toEmail}
+	e.Subject = subject
+	e.HTML = []byte(body)
+	return e
+}
+
+func sendEmail(e *email.Email) error {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		host = "smtp.gmail.com"
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	username := os.Getenv("SMTP_USERNAME")
+	if username == "" {
+		return errors.New("smtp not configured")
+	}
+
+	return e.Send(host+":"+port, smtp.PlainAuth("", username, os.Getenv("SMTP_PASSWORD"), host))
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"fmt"
+	"html"
+)
+
+func SendMissingPersonFoundEmail(toEmail, personName, location string) error {
+	e := newEmail(toEmail, "Orang Hilang Telah Ditemukan", fmt.Sprintf(`
+		<!DOCTYPE html>
+		<html>
+		<head>
+			<meta charset="UTF-8">
+			<title>Orang Hilang Telah Ditemukan</title>
+		</head>
+		<body style="font-family: Arial, sans-serif;">
+			<h2>Kabar dari RescueHub</h2>
+			<p>Relawan kami telah mengonfirmasi bahwa <strong>%s</strong> terdaftar sebagai pengungsi.</p>
+			<p>Lokasi saat ini: <strong>%s</strong></p>
+			<p>Silakan hubungi petugas shelter untuk proses pertemuan keluarga.</p>
+		</body>
+		</html>
+	`, html.EscapeString(personName), html.EscapeString(location)))
+
+	return sendEmail(e)
+}
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimit membatasi jumlah permintaan per alamat IP dalam satu jendela waktu, setiap pemanggilan memiliki hitungan sendiri
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	clients := make(map[string]*rateWindow)
+	lastSweep := time.Now()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		if now.Sub(lastSweep) > window {
+			for key, client := range clients {
+				if now.Sub(client.start) > window {
+					delete(clients, key)
+				}
+			}
+			lastSweep = now
+		}
+
+		client, ok := clients[ip]
+		if !ok || now.Sub(client.start) > window {
+			client = &rateWindow{start: now}
+			clients[ip] = client
+		}
+		client.count++
+		count, reset := client.count, client.start.Add(window)
+		mu.Unlock()
+
+		remaining := limit - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if count > limit {
+			c.Header("Retry-After", strconv.Itoa(int(reset.Sub(now).Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Terlalu banyak permintaan, silakan coba lagi nanti",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// PublicRateLimit memakai batas dari environment variable PUBLIC_RATE_LIMIT (permintaan per menit per IP, default 60)
+func PublicRateLimit() gin.HandlerFunc {
+	limit, err := strconv.Atoi(os.Getenv("PUBLIC_RATE_LIMIT"))
+	if err != nil || limit <= 0 {
+		limit = 60
+	}
+	return RateLimit(limit, time.Minute)
+}
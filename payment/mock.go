@@ -0,0 +1,79 @@
+package payment
+
+import (
+	"RescueHub/structs"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const MockSignatureHeader = "X-Mock-Signature"
+
+// MockProvider mensimulasikan payment gateway secara lokal dengan webhook bertanda tangan HMAC-SHA256
+type MockProvider struct{}
+
+func (p *MockProvider) Name() string {
+	return "mock"
+}
+
+// Provider mock menolak semua tagihan dan webhook bila belum diaktifkan secara eksplisit
+func (p *MockProvider) secret() ([]byte, error) {
+	secret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if secret == "" || !MockEnabled() {
+		return nil, errors.New("payment provider not configured")
+	}
+	return []byte(secret), nil
+}
+
+func (p *MockProvider) Sign(body []byte) (string, error) {
+	secret, err := p.secret()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (p *MockProvider) CreatePaymentIntent(intent *structs.PaymentIntent) error {
+	if _, err := p.secret(); err != nil {
+		return err
+	}
+
+	reference := make([]byte, 8)
+	if _, err := rand.Read(reference); err != nil {
+		return err
+	}
+	intent.Provider = p.Name()
+	intent.ProviderReference = "MOCK-" + strings.ToUpper(hex.EncodeToString(reference))
+	intent.RedirectURL = strings.TrimSuffix(os.Getenv("PAYMENT_MOCK_CHECKOUT_URL"), "/") + "/api/payments/mock/" + intent.ProviderReference
+	return nil
+}
+
+func (p *MockProvider) VerifyWebhook(header http.Header, body []byte) (structs.PaymentWebhookEvent, error) {
+	var event structs.PaymentWebhookEvent
+	expected, err := p.Sign(body)
+	if err != nil {
+		return event, err
+	}
+
+	signature := strings.TrimPrefix(header.Get(MockSignatureHeader), "sha256=")
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return event, errors.New("invalid signature")
+	}
+
+	if err := json.Unmarshal(body, &event); err != nil {
+		return event, errors.New("invalid webhook payload")
+	}
+	if event.EventID == "" || event.ProviderReference == "" {
+		return event, errors.New("invalid webhook payload")
+	}
+	event.Payload = string(body)
+	return event, nil
+}
@@ -0,0 +1,45 @@
+package payment
+
+import (
+	"RescueHub/structs"
+	"net/http"
+	"os"
+)
+
+// Provider adalah payment gateway yang membuat tagihan donasi dan mengirim status pembayaran lewat webhook
+type Provider interface {
+	Name() string
+	// CreatePaymentIntent mengisi ProviderReference dan RedirectURL untuk halaman pembayaran
+	CreatePaymentIntent(intent *structs.PaymentIntent) error
+	// VerifyWebhook memeriksa tanda tangan HMAC webhook sebelum isinya dipercaya
+	VerifyWebhook(header http.Header, body []byte) (structs.PaymentWebhookEvent, error)
+}
+
+var providers = map[string]Provider{}
+
+func Register(provider Provider) {
+	providers[provider.Name()] = provider
+}
+
+func Get(name string) (Provider, bool) {
+	provider, ok := providers[name]
+	return provider, ok
+}
+
+// Active mengembalikan provider dari environment variable PAYMENT_PROVIDER, tanpa provider default
+func Active() (Provider, bool) {
+	name := os.Getenv("PAYMENT_PROVIDER")
+	if name == "" || (name == "mock" && !MockEnabled()) {
+		return nil, false
+	}
+	return Get(name)
+}
+
+// MockEnabled bernilai true hanya bila PAYMENT_PROVIDER=mock dan PAYMENT_MOCK_ENABLED=true untuk pengembangan lokal
+func MockEnabled() bool {
+	return os.Getenv("PAYMENT_PROVIDER") == "mock" && os.Getenv("PAYMENT_MOCK_ENABLED") == "true"
+}
+
+func init() {
+	Register(&MockProvider{})
+}
@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+)
+
+// Jam layanan hanya dihitung dari sesi yang sudah check-out
+const attendanceHoursSQL = `ROUND((EXTRACT(EPOCH FROM (a.check_out_at - a.check_in_at)) / 3600)::NUMERIC, 2)::FLOAT8`
+
+const attendanceSelectQuery = `SELECT a.id, a.volunteer_id, COALESCE(u.name, ''), a.disaster_id, a.shift_id, a.check_in_at, a.check_in_location, a.check_in_latitude, a.check_in_longitude,
+                                      a.check_out_at, COALESCE(a.check_out_location, ''), a.check_out_latitude, a.check_out_longitude,
+                                      COALESCE(` + attendanceHoursSQL + `, 0), COALESCE(a.note, ''), a.created_at, a.updated_at
+                               FROM volunteer_attendances a
+                               JOIN volunteers v ON v.id = a.volunteer_id
+                               LEFT JOIN users u ON u.id = v.user_id`
+
+func scanAttendances(rows *sql.Rows) ([]structs.VolunteerAttendance, error) {
+	attendances := []structs.VolunteerAttendance{}
+	for rows.Next() {
+		var attendance structs.VolunteerAttendance
+		err := rows.Scan(&attendance.ID, &attendance.VolunteerID, &attendance.VolunteerName, &attendance.DisasterID, &attendance.ShiftID,
+			&attendance.CheckInAt, &attendance.CheckInLocation, &attendance.CheckInLatitude, &attendance.CheckInLongitude,
+			&attendance.CheckOutAt, &attendance.CheckOutLocation, &attendance.CheckOutLatitude, &attendance.CheckOutLongitude,
+			&attendance.Hours, &attendance.Note, &attendance.CreatedAt, &attendance.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attendances = append(attendances, attendance)
+	}
+	return attendances, rows.Err()
+}
+
+func getAttendanceByID(db *sql.DB, id int) (structs.VolunteerAttendance, error) {
+	rows, err := db.Query(attendanceSelectQuery+` WHERE a.id = $1`, id)
+	if err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+	defer rows.Close()
+
+	attendances, err := scanAttendances(rows)
+	if err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+	if len(attendances) == 0 {
+		return structs.VolunteerAttendance{}, errors.New("attendance not found")
+	}
+	return attendances[0], nil
+}
+
+// Check-in mengubah status relawan menjadi on_mission sampai check-out
+func CheckInVolunteer(db *sql.DB, volunteerID int, input structs.CheckInInput) (structs.VolunteerAttendance, error) {
+	if err := validateLocation(input.Latitude, input.Longitude, nil); err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+	defer tx.Rollback()
+
+	var status, volunteerLocation string
+	var disasterID *int
+	err = tx.QueryRow(`SELECT status, disaster_id, location FROM volunteers WHERE id = $1 FOR UPDATE`, volunteerID).
+		Scan(&status, &disasterID, &volunteerLocation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return structs.VolunteerAttendance{}, errors.New("volunteer not found")
+		}
+		return structs.VolunteerAttendance{}, err
+	}
+	if status == "completed" {
+		return structs.VolunteerAttendance{}, errors.New("volunteer completed")
+	}
+
+	var checkedIn bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM volunteer_attendances WHERE volunteer_id = $1 AND check_out_at IS NULL)`, volunteerID).Scan(&checkedIn); err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+	if checkedIn {
+		return structs.VolunteerAttendance{}, errors.New("volunteer already checked in")
+	}
+
+	location := input.Location
+	if input.ShiftID != nil {
+		// Check-in shift dibuka satu jam sebelum mulai sampai shift berakhir
+		var shiftDisasterID int
+		var shiftLocation string
+		var active, assigned bool
+		err := tx.QueryRow(`SELECT s.disaster_id, s.location, NOW() >= s.start_time - INTERVAL '1 hour' AND NOW() < s.end_time,
+		                           EXISTS(SELECT 1 FROM shift_assignments WHERE shift_id = s.id AND volunteer_id = $2)
+		                    FROM volunteer_shifts s WHERE s.id = $1`, *input.ShiftID, volunteerID).
+			Scan(&shiftDisasterID, &shiftLocation, &active, &assigned)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return structs.VolunteerAttendance{}, errors.New("shift not found")
+			}
+			return structs.VolunteerAttendance{}, err
+		}
+		if !assigned {
+			return structs.VolunteerAttendance{}, errors.New("shift assignment not found")
+		}
+		if !active {
+			return structs.VolunteerAttendance{}, errors.New("shift not active")
+		}
+		disasterID = &shiftDisasterID
+		if location == "" {
+			location = shiftLocation
+		}
+	} else if input.DisasterID != nil {
+		if !isDisasterExists(db, *input.DisasterID) {
+			return structs.VolunteerAttendance{}, errors.New("disaster not found")
+		}
+		disasterID = input.DisasterID
+	}
+	if location == "" {
+		location = volunteerLocation
+	}
+
+	var id int
+	err = tx.QueryRow(`INSERT INTO volunteer_attendances (volunteer_id, disaster_id, shift_id, check_in_at, check_in_location, check_in_latitude, check_in_longitude, previous_status, note, created_at, updated_at)
+	                   VALUES ($1, $2, $3, NOW(), $4, $5, $6, $7, NULLIF($8, ''), NOW(), NOW()) RETURNING id`,
+		volunteerID, disasterID, input.ShiftID, location, input.Latitude, input.Longitude, status, input.Note).Scan(&id)
+	if err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+
+	if _, err := tx.Exec(`UPDATE volunteers SET status = 'on_mission', updated_at = NOW() WHERE id = $1`, volunteerID); err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+	return getAttendanceByID(db, id)
+}
+
+// Waktu check-out bisa diisi admin untuk menutup check-in yang terlupa
+func CheckOutVolunteer(db *sql.DB, volunteerID int, input structs.CheckOutInput, checkedOutAt *time.Time) (structs.VolunteerAttendance, error) {
+	if err := validateLocation(input.Latitude, input.Longitude, nil); err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(`SELECT id FROM volunteers WHERE id = $1 FOR UPDATE`, volunteerID).Scan(&volunteerID); err != nil {
+		if err == sql.ErrNoRows {
+			return structs.VolunteerAttendance{}, errors.New("volunteer not found")
+		}
+		return structs.VolunteerAttendance{}, err
+	}
+
+	var id int
+	var validTime bool
+	err = tx.QueryRow(`SELECT id, COALESCE($2::TIMESTAMP, NOW()) BETWEEN check_in_at AND NOW()
+	                   FROM volunteer_attendances WHERE volunteer_id = $1 AND check_out_at IS NULL FOR UPDATE`, volunteerID, checkedOutAt).
+		Scan(&id, &validTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return structs.VolunteerAttendance{}, errors.New("volunteer not checked in")
+		}
+		return structs.VolunteerAttendance{}, err
+	}
+	if !validTime {
+		return structs.VolunteerAttendance{}, errors.New("invalid check-out time")
+	}
+
+	_, err = tx.Exec(`UPDATE volunteer_attendances
+	                  SET check_out_at = COALESCE($2::TIMESTAMP, NOW()), check_out_location = NULLIF($3, ''), check_out_latitude = $4, check_out_longitude = $5,
+	                      note = COALESCE(NULLIF($6, ''), note), updated_at = NOW()
+	                  WHERE id = $1`, id, checkedOutAt, input.Location, input.Latitude, input.Longitude, input.Note)
+	if err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+
+	// Status dikembalikan hanya bila tidak diubah selama relawan di lokasi
+	_, err = tx.Exec(`UPDATE volunteers v SET status = a.previous_status, updated_at = NOW()
+	                  FROM volunteer_attendances a
+	                  WHERE a.id = $1 AND v.id = a.volunteer_id AND v.status = 'on_mission'`, id)
+	if err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return structs.VolunteerAttendance{}, err
+	}
+	return getAttendanceByID(db, id)
+}
+
+func GetVolunteerAttendances(db *sql.DB, volunteerID int, disasterID *int) ([]structs.VolunteerAttendance, error) {
+	rows, err := db.Query(attendanceSelectQuery+`
+	                       WHERE a.volunteer_id = $1 AND ($2::INT IS NULL OR a.disaster_id = $2)
+	                       ORDER BY a.check_in_at DESC, a.id DESC`, volunteerID, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAttendances(rows)
+}
+
+// Relawan yang sedang berada di lokasi, yaitu yang belum check-out
+func GetOnSiteVolunteers(db *sql.DB, disasterID *int) ([]structs.VolunteerAttendance, error) {
+	rows, err := db.Query(attendanceSelectQuery+`
+	                       WHERE a.check_out_at IS NULL AND ($1::INT IS NULL OR a.disaster_id = $1)
+	                       ORDER BY a.check_in_at, a.id`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAttendances(rows)
+}
+
+const serviceHoursSelect = `COUNT(*), COALESCE(SUM(` + attendanceHoursSQL + `), 0)::FLOAT8, MIN(a.check_in_at), MAX(a.check_out_at)`
+
+func GetVolunteerServiceHours(db *sql.DB, volunteerID int, disasterID *int) (structs.VolunteerServiceSummary, error) {
+	summary := structs.VolunteerServiceSummary{VolunteerID: volunteerID, Disasters: []structs.ServiceHours{}}
+	err := db.QueryRow(`SELECT COALESCE(u.name, ''), EXISTS(SELECT 1 FROM volunteer_attendances WHERE volunteer_id = v.id AND check_out_at IS NULL)
+	                    FROM volunteers v LEFT JOIN users u ON u.id = v.user_id WHERE v.id = $1`, volunteerID).
+		Scan(&summary.VolunteerName, &summary.OnSite)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return summary, errors.New("volunteer not found")
+		}
+		return summary, err
+	}
+
+	rows, err := db.Query(`SELECT a.disaster_id, COALESCE(d.type || ' - ' || d.location, ''), `+serviceHoursSelect+`
+	                       FROM volunteer_attendances a
+	                       LEFT JOIN disasters d ON d.id = a.disaster_id
+	                       WHERE a.volunteer_id = $1 AND a.check_out_at IS NOT NULL AND ($2::INT IS NULL OR a.disaster_id = $2)
+	                       GROUP BY a.disaster_id, d.type, d.location
+	                       ORDER BY MIN(a.check_in_at)`, volunteerID, disasterID)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hours structs.ServiceHours
+		if err := rows.Scan(&hours.DisasterID, &hours.DisasterName, &hours.Sessions, &hours.Hours, &hours.FirstCheckIn, &hours.LastCheckOut); err != nil {
+			return summary, err
+		}
+		summary.Sessions += hours.Sessions
+		summary.TotalHours += hours.Hours
+		summary.Disasters = append(summary.Disasters, hours)
+	}
+	summary.TotalHours = math.Round(summary.TotalHours*100) / 100
+	return summary, rows.Err()
+}
+
+func GetDisasterServiceHours(db *sql.DB, disasterID int) (structs.DisasterServiceSummary, error) {
+	summary := structs.DisasterServiceSummary{DisasterID: disasterID, Volunteers: []structs.ServiceHours{}}
+	err := db.QueryRow(`SELECT d.type || ' - ' || d.location,
+	                           (SELECT COUNT(*) FROM volunteer_attendances WHERE disaster_id = d.id AND check_out_at IS NULL)
+	                    FROM disasters d WHERE d.id = $1`, disasterID).
+		Scan(&summary.DisasterName, &summary.OnSite)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return summary, errors.New("disaster not found")
+		}
+		return summary, err
+	}
+
+	rows, err := db.Query(`SELECT a.volunteer_id, COALESCE(u.name, ''), `+serviceHoursSelect+`
+	                       FROM volunteer_attendances a
+	                       JOIN volunteers v ON v.id = a.volunteer_id
+	                       LEFT JOIN users u ON u.id = v.user_id
+	                       WHERE a.disaster_id = $1 AND a.check_out_at IS NOT NULL
+	                       GROUP BY a.volunteer_id, u.name
+	                       ORDER BY 4 DESC, a.volunteer_id`, disasterID)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hours structs.ServiceHours
+		if err := rows.Scan(&hours.VolunteerID, &hours.VolunteerName, &hours.Sessions, &hours.Hours, &hours.FirstCheckIn, &hours.LastCheckOut); err != nil {
+			return summary, err
+		}
+		summary.Sessions += hours.Sessions
+		summary.TotalHours += hours.Hours
+		summary.Volunteers = append(summary.Volunteers, hours)
+	}
+	summary.TotalHours = math.Round(summary.TotalHours*100) / 100
+	return summary, rows.Err()
+}
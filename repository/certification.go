@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Sertifikasi dianggap berlaku bila sudah diverifikasi dan belum kedaluwarsa pada tanggal di parameter dateParam
+func validCertificationCondition(dateParam int) string {
+	return `c.status = 'verified' AND (c.expires_at IS NULL OR c.expires_at >= $` + strconv.Itoa(dateParam) + `::DATE)`
+}
+
+type CertificationMissingError struct {
+	VolunteerID    int
+	Certifications []string
+}
+
+func (e *CertificationMissingError) Error() string {
+	return "certification missing"
+}
+
+// CertificationWarningDays adalah jumlah hari sebelum kedaluwarsa untuk peringatan, dari CERTIFICATION_WARNING_DAYS (default 30)
+func CertificationWarningDays() int {
+	days, err := strconv.Atoi(os.Getenv("CERTIFICATION_WARNING_DAYS"))
+	if err != nil || days <= 0 {
+		return 30
+	}
+	return days
+}
+
+func normalizeCertificationTypes(types []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, certificationType := range types {
+		certificationType = skillCode(certificationType)
+		if certificationType != "" && !seen[certificationType] {
+			seen[certificationType] = true
+			normalized = append(normalized, certificationType)
+		}
+	}
+	return normalized
+}
+
+// Tanpa daftar sertifikasi, sertifikasi wajib diambil dari keahlian yang mewajibkan lisensi
+func resolveRequiredCertifications(db *sql.DB, certifications, skills []string) ([]string, error) {
+	if certifications != nil {
+		return normalizeCertificationTypes(certifications), nil
+	}
+
+	required := []string{}
+	if len(skills) == 0 {
+		return required, nil
+	}
+	rows, err := db.Query(`SELECT DISTINCT required_certification FROM skills
+	                       WHERE code = ANY($1) AND required_certification IS NOT NULL ORDER BY 1`, pq.Array(skills))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var certification string
+		if err := rows.Scan(&certification); err != nil {
+			return nil, err
+		}
+		required = append(required, certification)
+	}
+	return required, rows.Err()
+}
+
+// Sertifikasi wajib yang tidak dimiliki relawan atau sudah kedaluwarsa pada validUntil
+func missingCertifications(tx *sql.Tx, volunteerID int, required []string, validUntil time.Time) ([]string, error) {
+	missing := []string{}
+	if len(required) == 0 {
+		return missing, nil
+	}
+
+	rows, err := tx.Query(`SELECT r FROM UNNEST($2::TEXT[]) r
+	                       WHERE NOT EXISTS (SELECT 1 FROM volunteer_certifications c
+	                                         WHERE c.volunteer_id = $1 AND c.certification_type = r AND `+validCertificationCondition(3)+`)
+	                       ORDER BY r`, volunteerID, pq.Array(required), validUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var certification string
+		if err := rows.Scan(&certification); err != nil {
+			return nil, err
+		}
+		missing = append(missing, certification)
+	}
+	return missing, rows.Err()
+}
+
+func checkRequiredCertifications(tx *sql.Tx, volunteerID int, required []string, validUntil time.Time) error {
+	missing, err := missingCertifications(tx, volunteerID, required, validUntil)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return &CertificationMissingError{Certifications: missing}
+	}
+	return nil
+}
+
+// Relawan yang sudah ditugaskan harus tetap memenuhi sertifikasi wajib setelah persyaratan atau jadwal berubah
+func checkAssigneeCertifications(tx *sql.Tx, volunteerIDs []int, required []string, validUntil time.Time) error {
+	for _, volunteerID := range volunteerIDs {
+		missing, err := missingCertifications(tx, volunteerID, required, validUntil)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			return &CertificationMissingError{VolunteerID: volunteerID, Certifications: missing}
+		}
+	}
+	return nil
+}
+
+const certificationSelectQuery = `SELECT c.id, c.volunteer_id, COALESCE(u.name, ''), c.certification_type, c.issuer, c.certificate_number, c.issued_at, c.expires_at,
+                                         COALESCE(c.document_name, ''), COALESCE(c.document_content_type, ''), c.document IS NOT NULL, c.status,
+                                         c.verified_by, c.verified_at, COALESCE(c.review_note, ''), c.expires_at - CURRENT_DATE, c.created_at, c.updated_at
+                                  FROM volunteer_certifications c
+                                  JOIN volunteers v ON v.id = c.volunteer_id
+                                  LEFT JOIN users u ON u.id = v.user_id`
+
+func scanCertifications(rows *sql.Rows) ([]structs.Certification, error) {
+	warningDays := CertificationWarningDays()
+	certifications := []structs.Certification{}
+	for rows.Next() {
+		var certification structs.Certification
+		err := rows.Scan(&certification.ID, &certification.VolunteerID, &certification.VolunteerName, &certification.CertificationType,
+			&certification.Issuer, &certification.CertificateNumber, &certification.IssuedAt, &certification.ExpiresAt,
+			&certification.DocumentName, &certification.DocumentContentType, &certification.HasDocument, &certification.Status,
+			&certification.VerifiedBy, &certification.VerifiedAt, &certification.ReviewNote, &certification.DaysUntilExpiry,
+			&certification.CreatedAt, &certification.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if days := certification.DaysUntilExpiry; days != nil {
+			certification.Expired = *days < 0
+			certification.ExpiringSoon = *days >= 0 && *days <= warningDays
+		}
+		certifications = append(certifications, certification)
+	}
+	return certifications, rows.Err()
+}
+
+func queryCertifications(db *sql.DB, query string, args ...interface{}) ([]structs.Certification, error) {
+	rows, err := db.Query(certificationSelectQuery+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCertifications(rows)
+}
+
+func getCertificationByID(db *sql.DB, id int) (structs.Certification, error) {
+	certifications, err := queryCertifications(db, ` WHERE c.id = $1`, id)
+	if err != nil {
+		return structs.Certification{}, err
+	}
+	if len(certifications) == 0 {
+		return structs.Certification{}, errors.New("certification not found")
+	}
+	return certifications[0], nil
+}
+
+func CreateCertification(db *sql.DB, certification *structs.Certification) error {
+	certification.CertificationType = skillCode(certification.CertificationType)
+	if certification.CertificationType == "" {
+		return errors.New("certification type required")
+	}
+	certification.Issuer = strings.TrimSpace(certification.Issuer)
+	certification.CertificateNumber = strings.TrimSpace(certification.CertificateNumber)
+	if certification.IssuedAt != nil && certification.ExpiresAt != nil && certification.ExpiresAt.Before(*certification.IssuedAt) {
+		return errors.New("invalid certification dates")
+	}
+
+	var exists bool
+	if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM volunteers WHERE id = $1)`, certification.VolunteerID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("volunteer not found")
+	}
+
+	var id int
+	err := db.QueryRow(`INSERT INTO volunteer_certifications (volunteer_id, certification_type, issuer, certificate_number, issued_at, expires_at,
+	                                                          document_name, document_content_type, document, status, created_at, updated_at)
+	                    VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, 'pending', NOW(), NOW())
+	                    ON CONFLICT (certification_type, issuer, certificate_number) DO NOTHING RETURNING id`,
+		certification.VolunteerID, certification.CertificationType, certification.Issuer, certification.CertificateNumber,
+		certification.IssuedAt, certification.ExpiresAt, certification.DocumentName, certification.DocumentContentType, certification.Document).
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("certification already exists")
+		}
+		return err
+	}
+
+	created, err := getCertificationByID(db, id)
+	if err != nil {
+		return err
+	}
+	*certification = created
+	return nil
+}
+
+func GetVolunteerCertifications(db *sql.DB, volunteerID int) ([]structs.Certification, error) {
+	return queryCertifications(db, ` WHERE c.volunteer_id = $1 ORDER BY c.certification_type, c.expires_at DESC NULLS FIRST, c.id`, volunteerID)
+}
+
+func GetCertifications(db *sql.DB, status string) ([]structs.Certification, error) {
+	if status != "" && status != "pending" && status != "verified" && status != "rejected" {
+		return nil, errors.New("invalid certification status")
+	}
+	return queryCertifications(db, ` WHERE ($1 = '' OR c.status::TEXT = $1) ORDER BY c.created_at, c.id`, status)
+}
+
+// Sertifikasi terverifikasi yang akan kedaluwarsa dalam jumlah hari tertentu
+func GetExpiringCertifications(db *sql.DB, days int) ([]structs.Certification, error) {
+	return queryCertifications(db, ` WHERE c.status = 'verified' AND c.expires_at BETWEEN CURRENT_DATE AND CURRENT_DATE + $1::INT
+	                                 ORDER BY c.expires_at, c.id`, days)
+}
+
+func GetCertificationDocument(db *sql.DB, volunteerID, id int) (structs.Certification, error) {
+	var certification structs.Certification
+	err := db.QueryRow(`SELECT id, volunteer_id, COALESCE(document_name, ''), COALESCE(document_content_type, ''), document
+	                    FROM volunteer_certifications WHERE id = $1 AND volunteer_id = $2`, id, volunteerID).
+		Scan(&certification.ID, &certification.VolunteerID, &certification.DocumentName, &certification.DocumentContentType, &certification.Document)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return certification, errors.New("certification not found")
+		}
+		return certification, err
+	}
+	if certification.Document == nil {
+		return certification, errors.New("certification document not found")
+	}
+	return certification, nil
+}
+
+// Sertifikasi yang sudah kedaluwarsa tidak bisa diverifikasi
+func ReviewCertification(db *sql.DB, id int, status, note string, reviewerID int) (structs.Certification, error) {
+	if status != "verified" && status != "rejected" {
+		return structs.Certification{}, errors.New("invalid certification status")
+	}
+
+	var expired bool
+	err := db.QueryRow(`SELECT COALESCE(expires_at < CURRENT_DATE, false) FROM volunteer_certifications WHERE id = $1`, id).Scan(&expired)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return structs.Certification{}, errors.New("certification not found")
+		}
+		return structs.Certification{}, err
+	}
+	if status == "verified" && expired {
+		return structs.Certification{}, errors.New("certification expired")
+	}
+
+	_, err = db.Exec(`UPDATE volunteer_certifications
+	                  SET status = $2, review_note = NULLIF($3, ''), verified_by = $4, verified_at = NOW(), updated_at = NOW()
+	                  WHERE id = $1`, id, status, note, reviewerID)
+	if err != nil {
+		return structs.Certification{}, err
+	}
+	return getCertificationByID(db, id)
+}
+
+func DeleteCertification(db *sql.DB, volunteerID, id int) error {
+	result, err := db.Exec(`DELETE FROM volunteer_certifications WHERE id = $1 AND volunteer_id = $2`, id, volunteerID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("certification not found")
+	}
+	return nil
+}
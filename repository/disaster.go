@@ -34,10 +34,14 @@ func CreateDisaster(db *sql.DB, disaster *structs.Disaster) error {
 		return errors.New("disaster already exists")
 	}
 
-	sqlQuery := `INSERT INTO disasters (type, location, description, status, reported_by, created_at, updated_at)
-							VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	if err := validateLocation(disaster.Latitude, disaster.Longitude, disaster.LocationAccuracy); err != nil {
+		return err
+	}
+
+	sqlQuery := `INSERT INTO disasters (type, location, description, status, reported_by, latitude, longitude, location_accuracy, created_at, updated_at)
+							VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()) RETURNING id, created_at, updated_at`
 	
-	err := db.QueryRow(sqlQuery, disaster.Type, disaster.Location, disaster.Description, disaster.Status, disaster.ReportedBy).
+	err := db.QueryRow(sqlQuery, disaster.Type, disaster.Location, disaster.Description, disaster.Status, disaster.ReportedBy, disaster.Latitude, disaster.Longitude, disaster.LocationAccuracy).
 			Scan(&disaster.ID, &disaster.CreatedAt, &disaster.UpdatedAt)
 
 	if err != nil {
@@ -48,7 +52,7 @@ func CreateDisaster(db *sql.DB, disaster *structs.Disaster) error {
 }
 
 func GetAllDisasters(db *sql.DB) ([]structs.Disaster, error) {
-	query := `SELECT id, type, location, description, status, reported_by, created_at, updated_at FROM disasters`
+	query := `SELECT id, type, location, description, status, reported_by, latitude, longitude, location_accuracy, created_at, updated_at FROM disasters`
 	rows, err := db.Query(query)
 
 	if err != nil {
@@ -59,7 +63,30 @@ func GetAllDisasters(db *sql.DB) ([]structs.Disaster, error) {
 	var disasters []structs.Disaster
 	for rows.Next() {
 		var disaster structs.Disaster
-		err := rows.Scan(&disaster.ID, &disaster.Type, &disaster.Location, &disaster.Description, &disaster.Status, &disaster.ReportedBy, &disaster.CreatedAt, &disaster.UpdatedAt)
+		err := rows.Scan(&disaster.ID, &disaster.Type, &disaster.Location, &disaster.Description, &disaster.Status, &disaster.ReportedBy, &disaster.Latitude, &disaster.Longitude, &disaster.LocationAccuracy, &disaster.CreatedAt, &disaster.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		disasters = append(disasters, disaster)
+	}
+	return disasters, nil
+}
+
+func GetDisastersByArea(db *sql.DB, filter structs.GeoFilter) ([]structs.Disaster, error) {
+	distanceColumn, where, order, values := buildGeoQuery(filter)
+	query := `SELECT id, type, location, description, status, reported_by, latitude, longitude, location_accuracy, ` + distanceColumn + ` AS distance_km, created_at, updated_at
+	          FROM disasters WHERE ` + where + ` ORDER BY ` + order
+	rows, err := db.Query(query, values...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var disasters []structs.Disaster
+	for rows.Next() {
+		var disaster structs.Disaster
+		err := rows.Scan(&disaster.ID, &disaster.Type, &disaster.Location, &disaster.Description, &disaster.Status, &disaster.ReportedBy, &disaster.Latitude, &disaster.Longitude, &disaster.LocationAccuracy, &disaster.DistanceKm, &disaster.CreatedAt, &disaster.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -69,9 +96,9 @@ func GetAllDisasters(db *sql.DB) ([]structs.Disaster, error) {
 }
 
 func GetDisasterByID(db *sql.DB, id int) (structs.Disaster, error) {
-	query := `SELECT id, type, location, description, status, reported_by, created_at, updated_at FROM disasters WHERE id = $1`
+	query := `SELECT id, type, location, description, status, reported_by, latitude, longitude, location_accuracy, created_at, updated_at FROM disasters WHERE id = $1`
 	var disaster structs.Disaster
-	err := db.QueryRow(query, id).Scan(&disaster.ID, &disaster.Type, &disaster.Location, &disaster.Description, &disaster.Status, &disaster.ReportedBy, &disaster.CreatedAt, &disaster.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&disaster.ID, &disaster.Type, &disaster.Location, &disaster.Description, &disaster.Status, &disaster.ReportedBy, &disaster.Latitude, &disaster.Longitude, &disaster.LocationAccuracy, &disaster.CreatedAt, &disaster.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -91,6 +118,10 @@ func UpdateDisaster(db *sql.DB, disaster structs.Disaster) error {
 		return errors.New("disaster not found")
 	}
 
+	if err := validateLocation(disaster.Latitude, disaster.Longitude, disaster.LocationAccuracy); err != nil {
+		return err
+	}
+
 	var updateFields []string
 	var values []interface{}
 	counter := 1
@@ -120,6 +151,16 @@ func UpdateDisaster(db *sql.DB, disaster structs.Disaster) error {
 		values = append(values, disaster.ReportedBy)
 		counter++
 	}
+	if disaster.Latitude != nil && disaster.Longitude != nil {
+		updateFields = append(updateFields, "latitude = $"+strconv.Itoa(counter), "longitude = $"+strconv.Itoa(counter+1))
+		values = append(values, disaster.Latitude, disaster.Longitude)
+		counter += 2
+	}
+	if disaster.LocationAccuracy != nil {
+		updateFields = append(updateFields, "location_accuracy = $"+strconv.Itoa(counter))
+		values = append(values, disaster.LocationAccuracy)
+		counter++
+	}
 
 	if len(updateFields) == 0 {
 		return errors.New("tidak ada field yang dapat diperbarui")
@@ -147,7 +188,7 @@ func DeleteDisaster(db *sql.DB, id int) error {
 }
 
 func GetSheltersByDisasterID(db *sql.DB, disasterID int) ([]structs.Shelter, error) {
-	query := `SELECT id, disaster_id, name, location, capacity_total, capacity_remaining, emergency_needs, created_at, updated_at FROM shelters WHERE disaster_id = $1`
+	query := `SELECT id, disaster_id, name, location, capacity_total, capacity_remaining, (SELECT COUNT(*) FROM refugees r WHERE r.shelter_id = shelters.id) AS occupancy, emergency_needs, latitude, longitude, location_accuracy, created_at, updated_at FROM shelters WHERE disaster_id = $1`
 	rows, err := db.Query(query, disasterID)
 
 	if err != nil {
@@ -159,10 +200,11 @@ func GetSheltersByDisasterID(db *sql.DB, disasterID int) ([]structs.Shelter, err
 	var shelters []structs.Shelter
 	for rows.Next() {
 		var shelter structs.Shelter
-		err := rows.Scan(&shelter.ID, &shelter.DisasterID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining, &shelter.EmergencyNeeds, &shelter.CreatedAt, &shelter.UpdatedAt)
+		err := rows.Scan(&shelter.ID, &shelter.DisasterID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining, &shelter.Occupancy, &shelter.EmergencyNeeds, &shelter.Latitude, &shelter.Longitude, &shelter.LocationAccuracy, &shelter.CreatedAt, &shelter.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
+		setOccupancyPercentage(&shelter)
 		shelters = append(shelters, shelter)
 	}
 	return shelters, nil
@@ -170,7 +212,7 @@ func GetSheltersByDisasterID(db *sql.DB, disasterID int) ([]structs.Shelter, err
 
 func GetVolunteersByDisasterID(db *sql.DB, disasterID int) ([]structs.Volunteer, error) {
 	var volunteers []structs.Volunteer
-	query := `SELECT id, donor_id, disaster_id, skill, location, status, created_at, updated_at 
+	query := `SELECT id, user_id, disaster_id, skill, location, status, latitude, longitude, location_accuracy, created_at, updated_at 
 	          FROM volunteers WHERE disaster_id = $1`
 	rows, err := db.Query(query, disasterID)
 	if err != nil {
@@ -180,7 +222,7 @@ func GetVolunteersByDisasterID(db *sql.DB, disasterID int) ([]structs.Volunteer,
 
 	for rows.Next() {
 		var volunteer structs.Volunteer
-		err := rows.Scan(&volunteer.ID, &volunteer.UserID, &volunteer.DisasterID, &volunteer.Skill, &volunteer.Location, &volunteer.Status, &volunteer.CreatedAt, &volunteer.UpdatedAt)
+		err := rows.Scan(&volunteer.ID, &volunteer.UserID, &volunteer.DisasterID, &volunteer.Skill, &volunteer.Location, &volunteer.Status, &volunteer.Latitude, &volunteer.Longitude, &volunteer.LocationAccuracy, &volunteer.CreatedAt, &volunteer.UpdatedAt)
 		if err != nil {
 			return volunteers, err
 		}
@@ -191,7 +233,7 @@ func GetVolunteersByDisasterID(db *sql.DB, disasterID int) ([]structs.Volunteer,
 
 func GetLogisticsByDisasterID(db *sql.DB, disasterID int) ([]structs.Logistic, error) {
 	var logistics []structs.Logistic
-	query := `SELECT id, type, quantity, status, disaster_id, created_at, updated_at 
+	query := `SELECT id, type, quantity, status, disaster_id, item_id, COALESCE(unit, ''), COALESCE(location, ''), latitude, longitude, created_at, updated_at 
 	          FROM logistics WHERE disaster_id = $1`
 	rows, err := db.Query(query, disasterID)
 	if err != nil {
@@ -201,7 +243,7 @@ func GetLogisticsByDisasterID(db *sql.DB, disasterID int) ([]structs.Logistic, e
 
 	for rows.Next() {
 		var logistic structs.Logistic
-		err := rows.Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.CreatedAt, &logistic.UpdatedAt)
+		err := rows.Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.ItemID, &logistic.Unit, &logistic.Location, &logistic.Latitude, &logistic.Longitude, &logistic.CreatedAt, &logistic.UpdatedAt)
 		if err != nil {
 			return logistics, err
 		}
@@ -212,7 +254,7 @@ func GetLogisticsByDisasterID(db *sql.DB, disasterID int) ([]structs.Logistic, e
 
 func GetEmergencyReportsByDisasterID(db *sql.DB, disasterID int) ([]structs.EmergencyReport, error) {
 	var reports []structs.EmergencyReport
-	query := `SELECT id, user_id, disaster_id, description, location, created_at, updated_at 
+	query := `SELECT id, user_id, disaster_id, description, location, latitude, longitude, location_accuracy, COALESCE(triage_category::TEXT, ''), triaged_at, created_at, updated_at 
 	          FROM emergency_reports WHERE disaster_id = $1`
 	rows, err := db.Query(query, disasterID)
 	if err != nil {
@@ -222,7 +264,7 @@ func GetEmergencyReportsByDisasterID(db *sql.DB, disasterID int) ([]structs.Emer
 
 	for rows.Next() {
 		var report structs.EmergencyReport
-		err := rows.Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.CreatedAt, &report.UpdatedAt)
+		err := rows.Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.Latitude, &report.Longitude, &report.LocationAccuracy, &report.TriageCategory, &report.TriagedAt, &report.CreatedAt, &report.UpdatedAt)
 		if err != nil {
 			return reports, err
 		}
@@ -249,6 +291,10 @@ func GetEvacuationRoutesByDisasterID(db *sql.DB, disasterID int) ([]structs.Evac
 		}
 		routes = append(routes, route)
 	}
+
+	if err := attachRouteGeometry(db, routes); err != nil {
+		return routes, err
+	}
 	return routes, nil
 }
 
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var distributionTransitions = map[string][]string{
+	"planned":    {"dispatched", "failed"},
+	"dispatched": {"in_transit", "delivered", "failed"},
+	"in_transit": {"delivered", "failed"},
+	"delivered":  {"confirmed", "returned"},
+	"failed":     {"returned"},
+}
+
+func isValidDistributionTransition(from, to string) bool {
+	for _, next := range distributionTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func generateCode(length int) (string, error) {
+	code := make([]byte, length)
+	size := big.NewInt(int64(len(codeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		code[i] = codeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+func recordDistributionStatus(tx *sql.Tx, distributionLogID int, status, note string, changedBy *int) error {
+	_, err := tx.Exec(`INSERT INTO distribution_status_history (distribution_log_id, status, note, changed_by, changed_at)
+	                   VALUES ($1, $2, NULLIF($3, ''), $4, NOW())`, distributionLogID, status, note, changedBy)
+	return err
+}
+
+func UpdateDistributionStatus(db *sql.DB, id int, status, note string, changedBy int) error {
+	if status == "confirmed" {
+		return errors.New("confirmation requires proof")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	var logisticID, needRequestID *int
+	err = tx.QueryRow(`SELECT status, logistic_id, need_request_id FROM distribution_logs WHERE id = $1 FOR UPDATE`, id).Scan(&current, &logisticID, &needRequestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("distribution log not found")
+		}
+		return err
+	}
+	if !isValidDistributionTransition(current, status) {
+		return errors.New("invalid status transition")
+	}
+
+	query := `UPDATE distribution_logs SET status = $1, updated_at = NOW() WHERE id = $2`
+	switch {
+	case current == "planned" && status == "dispatched":
+		query = `UPDATE distribution_logs SET status = $1, sent_at = NOW(), updated_at = NOW() WHERE id = $2`
+	case status == "delivered":
+		query = `UPDATE distribution_logs SET status = $1, delivered_at = NOW(), updated_at = NOW() WHERE id = $2`
+	}
+	if _, err := tx.Exec(query, status, id); err != nil {
+		return err
+	}
+
+	// Barang yang gagal dikirim atau dikembalikan masuk lagi ke stok batch asalnya
+	if (status == "failed" || status == "returned") && logisticID != nil {
+		if err := lockLogistics(tx, []int{*logisticID}); err != nil {
+			return err
+		}
+		label := "Distribusi gagal #"
+		if status == "returned" {
+			label = "Retur distribusi #"
+		}
+		if err := restoreDistributionStock(tx, id, *logisticID, label+strconv.Itoa(id), &changedBy); err != nil {
+			return err
+		}
+	}
+
+	if err := recordDistributionStatus(tx, id, status, note, &changedBy); err != nil {
+		return err
+	}
+
+	if needRequestID != nil {
+		if err := refreshNeedRequestFulfillment(tx, *needRequestID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func ConfirmDelivery(db *sql.DB, id int, input structs.DeliveryConfirmationInput, proofs []structs.DistributionProof, confirmedBy int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status, code string
+	var quantitySent int
+	var needRequestID *int
+	err = tx.QueryRow(`SELECT status, COALESCE(confirmation_code, ''), quantity_sent, need_request_id FROM distribution_logs WHERE id = $1 FOR UPDATE`, id).
+		Scan(&status, &code, &quantitySent, &needRequestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("distribution log not found")
+		}
+		return err
+	}
+	if status != "dispatched" && status != "in_transit" && status != "delivered" {
+		return errors.New("invalid status transition")
+	}
+
+	given := strings.ToUpper(strings.TrimSpace(input.ConfirmationCode))
+	if code == "" || subtle.ConstantTimeCompare([]byte(given), []byte(code)) != 1 {
+		return errors.New("invalid confirmation code")
+	}
+	if input.QuantityReceived == nil || *input.QuantityReceived < 0 || *input.QuantityReceived > quantitySent {
+		return errors.New("invalid quantity received")
+	}
+	if *input.QuantityReceived != quantitySent && strings.TrimSpace(input.DiscrepancyReason) == "" {
+		return errors.New("discrepancy reason required")
+	}
+	if len(proofs) == 0 {
+		return errors.New("proof of delivery required")
+	}
+
+	for _, proof := range proofs {
+		_, err := tx.Exec(`INSERT INTO distribution_proofs (distribution_log_id, kind, file_name, content_type, data, uploaded_by, uploaded_at)
+		                   VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, NOW())`, id, proof.Kind, proof.FileName, proof.ContentType, proof.Data, confirmedBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Konfirmasi langsung dari status dikirim tetap mencatat waktu barang diterima
+	if status != "delivered" {
+		if err := recordDistributionStatus(tx, id, "delivered", "", &confirmedBy); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE distribution_logs SET status = 'confirmed', quantity_received = $1, discrepancy_reason = NULLIF($2, ''),
+	                  delivered_at = COALESCE(delivered_at, NOW()), confirmed_at = NOW(), updated_at = NOW() WHERE id = $3`,
+		*input.QuantityReceived, strings.TrimSpace(input.DiscrepancyReason), id)
+	if err != nil {
+		return err
+	}
+
+	if err := recordDistributionStatus(tx, id, "confirmed", input.DiscrepancyReason, &confirmedBy); err != nil {
+		return err
+	}
+
+	if needRequestID != nil {
+		if err := refreshNeedRequestFulfillment(tx, *needRequestID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func GetDistributionDelivery(db *sql.DB, id int) (structs.DistributionDelivery, error) {
+	var delivery structs.DistributionDelivery
+	log, err := GetDistributionLogByID(db, id)
+	if err != nil {
+		return delivery, err
+	}
+	delivery.DistributionLog = log
+	delivery.History = []structs.DistributionStatusChange{}
+	delivery.Proofs = []structs.DistributionProof{}
+
+	rows, err := db.Query(`SELECT id, status, COALESCE(note, ''), changed_by, changed_at FROM distribution_status_history
+	                       WHERE distribution_log_id = $1 ORDER BY changed_at, id`, id)
+	if err != nil {
+		return delivery, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change structs.DistributionStatusChange
+		if err := rows.Scan(&change.ID, &change.Status, &change.Note, &change.ChangedBy, &change.ChangedAt); err != nil {
+			return delivery, err
+		}
+		delivery.History = append(delivery.History, change)
+	}
+
+	proofRows, err := db.Query(`SELECT id, kind, COALESCE(file_name, ''), content_type, uploaded_by, uploaded_at FROM distribution_proofs
+	                            WHERE distribution_log_id = $1 ORDER BY id`, id)
+	if err != nil {
+		return delivery, err
+	}
+	defer proofRows.Close()
+
+	for proofRows.Next() {
+		var proof structs.DistributionProof
+		if err := proofRows.Scan(&proof.ID, &proof.Kind, &proof.FileName, &proof.ContentType, &proof.UploadedBy, &proof.UploadedAt); err != nil {
+			return delivery, err
+		}
+		delivery.Proofs = append(delivery.Proofs, proof)
+	}
+
+	return delivery, nil
+}
+
+func GetDistributionProof(db *sql.DB, distributionLogID, proofID int) (structs.DistributionProof, error) {
+	var proof structs.DistributionProof
+	err := db.QueryRow(`SELECT id, kind, COALESCE(file_name, ''), content_type, data, uploaded_by, uploaded_at FROM distribution_proofs
+	                    WHERE id = $1 AND distribution_log_id = $2`, proofID, distributionLogID).
+		Scan(&proof.ID, &proof.Kind, &proof.FileName, &proof.ContentType, &proof.Data, &proof.UploadedBy, &proof.UploadedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return proof, errors.New("proof not found")
+		}
+		return proof, err
+	}
+	return proof, nil
+}
@@ -8,21 +8,62 @@ import (
 	"strconv"
 )
 
-func CreateDistributionLog(db *sql.DB, log *structs.DistributionLog) error {
-	sqlQuery := `INSERT INTO distribution_logs (logistic_id, origin, destination, distance, sender_name, recipient_name, quantity_sent, sent_at, created_at, updated_at)
-							VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, log.LogisticID, log.Origin, log.Destination, log.Distance, log.SenderName, log.RecipientName, log.QuantitySent, log.SentAt).
+func insertDistributionLog(tx *sql.Tx, log *structs.DistributionLog, recordedBy int) error {
+	if log.QuantitySent <= 0 {
+		return errors.New("invalid stock quantity")
+	}
+	if log.Status == "" {
+		log.Status = "dispatched"
+	}
+	if log.Status != "planned" && log.Status != "dispatched" {
+		return errors.New("invalid distribution status")
+	}
+
+	code, err := generateCode(6)
+	if err != nil {
+		return err
+	}
+	log.ConfirmationCode = code
+
+	sqlQuery := `INSERT INTO distribution_logs (logistic_id, origin, destination, distance, sender_name, recipient_name, quantity_sent, need_request_id, status, confirmation_code, sent_at, created_at, updated_at)
+							VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	err = tx.QueryRow(sqlQuery, log.LogisticID, log.Origin, log.Destination, log.Distance, log.SenderName, log.RecipientName, log.QuantitySent, log.NeedRequestID, log.Status, log.ConfirmationCode, log.SentAt).
 		Scan(&log.ID, &log.CreatedAt, &log.UpdatedAt)
 
 	if err != nil {
 		return err
 	}
 
+	if err := recordDistributionStatus(tx, log.ID, log.Status, "", &recordedBy); err != nil {
+		return err
+	}
+
+	if log.LogisticID != nil {
+		_, err = consumeStock(tx, *log.LogisticID, "dispatch", log.QuantitySent, nil, &log.ID, "Distribusi ke "+log.Destination, &recordedBy)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func CreateDistributionLog(db *sql.DB, log *structs.DistributionLog, recordedBy int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertDistributionLog(tx, log, recordedBy); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func GetAllDistributionLogs(db *sql.DB) ([]structs.DistributionLog, error) {
-	query := `SELECT id, logistic_id, origin, destination, distance, sender_name, recipient_name, quantity_sent, sent_at, created_at, updated_at FROM distribution_logs`
+	query := `SELECT id, logistic_id, origin, destination, distance, sender_name, recipient_name, quantity_sent, need_request_id, status, quantity_received, COALESCE(discrepancy_reason, ''), COALESCE(confirmation_code, ''), sent_at, delivered_at, confirmed_at, created_at, updated_at FROM distribution_logs`
 	rows, err := db.Query(query)
 
 	if err != nil {
@@ -33,7 +74,7 @@ func GetAllDistributionLogs(db *sql.DB) ([]structs.DistributionLog, error) {
 	var logs []structs.DistributionLog
 	for rows.Next() {
 		var log structs.DistributionLog
-		err := rows.Scan(&log.ID, &log.LogisticID, &log.Origin, &log.Destination, &log.Distance, &log.SenderName, &log.RecipientName, &log.QuantitySent, &log.SentAt, &log.CreatedAt, &log.UpdatedAt)
+		err := rows.Scan(&log.ID, &log.LogisticID, &log.Origin, &log.Destination, &log.Distance, &log.SenderName, &log.RecipientName, &log.QuantitySent, &log.NeedRequestID, &log.Status, &log.QuantityReceived, &log.DiscrepancyReason, &log.ConfirmationCode, &log.SentAt, &log.DeliveredAt, &log.ConfirmedAt, &log.CreatedAt, &log.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -43,9 +84,9 @@ func GetAllDistributionLogs(db *sql.DB) ([]structs.DistributionLog, error) {
 }
 
 func GetDistributionLogByID(db *sql.DB, id int) (structs.DistributionLog, error) {
-	query := `SELECT id, logistic_id, origin, destination, distance, sender_name, recipient_name, quantity_sent, sent_at, created_at, updated_at FROM distribution_logs WHERE id = $1`
+	query := `SELECT id, logistic_id, origin, destination, distance, sender_name, recipient_name, quantity_sent, need_request_id, status, quantity_received, COALESCE(discrepancy_reason, ''), COALESCE(confirmation_code, ''), sent_at, delivered_at, confirmed_at, created_at, updated_at FROM distribution_logs WHERE id = $1`
 	var log structs.DistributionLog
-	err := db.QueryRow(query, id).Scan(&log.ID, &log.LogisticID, &log.Origin, &log.Destination, &log.Distance, &log.SenderName, &log.RecipientName, &log.QuantitySent, &log.SentAt, &log.CreatedAt, &log.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&log.ID, &log.LogisticID, &log.Origin, &log.Destination, &log.Distance, &log.SenderName, &log.RecipientName, &log.QuantitySent, &log.NeedRequestID, &log.Status, &log.QuantityReceived, &log.DiscrepancyReason, &log.ConfirmationCode, &log.SentAt, &log.DeliveredAt, &log.ConfirmedAt, &log.CreatedAt, &log.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -56,19 +97,66 @@ func GetDistributionLogByID(db *sql.DB, id int) (structs.DistributionLog, error)
 	return log, nil
 }
 
-func isDistributionLogExists(db *sql.DB, id int) bool {
-	query := `SELECT EXISTS(SELECT 1 FROM distribution_logs WHERE id = $1)`
-	var exists bool
-	err := db.QueryRow(query, id).Scan(&exists)
+func UpdateDistributionLog(db *sql.DB, log structs.DistributionLog, recordedBy int) error {
+	if log.QuantitySent < 0 {
+		return errors.New("invalid stock quantity")
+	}
+
+	tx, err := db.Begin()
 	if err != nil {
-		return false
+		return err
+	}
+	defer tx.Rollback()
+
+	var previousLogisticID, needRequestID *int
+	var previousQuantity int
+	var status string
+	err = tx.QueryRow(`SELECT logistic_id, quantity_sent, need_request_id, status FROM distribution_logs WHERE id = $1 FOR UPDATE`, log.ID).Scan(&previousLogisticID, &previousQuantity, &needRequestID, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("distribution log not found")
+		}
+		return err
 	}
-	return exists
-}
 
-func UpdateDistributionLog(db *sql.DB, log structs.DistributionLog) error {
-	if !isDistributionLogExists(db, log.ID) {
-		return errors.New("distribution log not found")
+	logisticID, quantity := previousLogisticID, previousQuantity
+	if log.LogisticID != nil {
+		logisticID = log.LogisticID
+	}
+	if log.QuantitySent != 0 {
+		quantity = log.QuantitySent
+	}
+
+	// Perubahan logistik atau jumlah dicatat sebagai koreksi lalu pengiriman ulang agar buku besar tetap append-only
+	logisticChanged := (logisticID == nil) != (previousLogisticID == nil) || (logisticID != nil && *logisticID != *previousLogisticID)
+	if logisticChanged || quantity != previousQuantity {
+		// Barang yang sudah dalam perjalanan atau diterima tidak bisa dikoreksi lagi
+		if status != "planned" && status != "dispatched" {
+			return errors.New("distribution locked")
+		}
+
+		var logisticIDs []int
+		for _, id := range []*int{previousLogisticID, logisticID} {
+			if id != nil {
+				logisticIDs = append(logisticIDs, *id)
+			}
+		}
+		if err := lockLogistics(tx, logisticIDs); err != nil {
+			return err
+		}
+
+		note := "Koreksi distribusi #" + strconv.Itoa(log.ID)
+		if previousLogisticID != nil {
+			if err := restoreDistributionStock(tx, log.ID, *previousLogisticID, note, &recordedBy); err != nil {
+				return err
+			}
+		}
+		if logisticID != nil {
+			_, err = consumeStock(tx, *logisticID, "dispatch", quantity, nil, &log.ID, note, &recordedBy)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	var updateFields []string
@@ -124,19 +212,84 @@ func UpdateDistributionLog(db *sql.DB, log structs.DistributionLog) error {
 	query := "UPDATE distribution_logs SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
 	values = append(values, log.ID)
 
-	_, err := db.Exec(query, values...)
+	_, err = tx.Exec(query, values...)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if needRequestID != nil {
+		if err := refreshNeedRequestFulfillment(tx, *needRequestID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 
 func DeleteDistributionLog(db *sql.DB, id int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var needRequestID *int
+	var status string
+	err = tx.QueryRow(`SELECT need_request_id, status FROM distribution_logs WHERE id = $1 FOR UPDATE`, id).Scan(&needRequestID, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("distribution log not found")
+		}
+		return err
+	}
+
+	// Distribusi yang sudah dalam perjalanan atau diterima menyimpan jejak serah terima sehingga tidak bisa dihapus
+	if status != "planned" && status != "dispatched" {
+		return errors.New("distribution locked")
+	}
+
+	// Log yang sudah tercatat di buku stok dibatalkan lewat status failed agar stok kembali dengan entri penyesuaian
+	var hasMovements bool
+	err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM stock_movements WHERE distribution_log_id = $1)`, id).Scan(&hasMovements)
+	if err != nil {
+		return err
+	}
+	if hasMovements {
+		return errors.New("distribution has stock movements")
+	}
+
 	sqlQuery := `DELETE FROM distribution_logs WHERE id=$1`
-	_, err := db.Exec(sqlQuery, id)
+	_, err = tx.Exec(sqlQuery, id)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if needRequestID != nil {
+		if err := refreshNeedRequestFulfillment(tx, *needRequestID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func GetDistributionLogsByDisasterID(db *sql.DB, disasterID int) ([]structs.DistributionLog, error) {
+	query := `SELECT d.id, d.logistic_id, d.origin, d.destination, d.distance, d.sender_name, d.recipient_name, d.quantity_sent, d.need_request_id, d.status, d.quantity_received, COALESCE(d.discrepancy_reason, ''), COALESCE(d.confirmation_code, ''), d.sent_at, d.delivered_at, d.confirmed_at, d.created_at, d.updated_at
+	          FROM distribution_logs d JOIN logistics l ON l.id = d.logistic_id WHERE l.disaster_id = $1`
+	rows, err := db.Query(query, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []structs.DistributionLog
+	for rows.Next() {
+		var log structs.DistributionLog
+		err := rows.Scan(&log.ID, &log.LogisticID, &log.Origin, &log.Destination, &log.Distance, &log.SenderName, &log.RecipientName, &log.QuantitySent, &log.NeedRequestID, &log.Status, &log.QuantityReceived, &log.DiscrepancyReason, &log.ConfirmationCode, &log.SentAt, &log.DeliveredAt, &log.ConfirmedAt, &log.CreatedAt, &log.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
 }
@@ -4,6 +4,7 @@ import (
 	"RescueHub/structs"
 	"database/sql"
 	"errors"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -18,41 +19,161 @@ func isValidDonationStatus(status string) bool {
 	return false
 }
 
-func CreateDonation(db *sql.DB, donation *structs.Donation) error {
-	if !isValidDonationStatus(donation.Status) {
-		return errors.New("invalid donation status")
+func IsInKindDonation(donation structs.Donation) bool {
+	return donation.ItemName != "" || donation.ItemID != nil
+}
+
+func receiveDonationStock(tx *sql.Tx, donation *structs.Donation, recordedBy int) error {
+	if donation.DisasterID == nil {
+		return errors.New("donation disaster required")
+	}
+	if donation.Amount <= 0 || donation.Amount != math.Trunc(donation.Amount) {
+		return errors.New("invalid donation quantity")
 	}
 
-	sqlQuery := `INSERT INTO donations (donor_id, disaster_id, amount, item_name, status, created_at, updated_at)
-	             VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, donation.DonorID, donation.DisasterID, donation.Amount, donation.ItemName, donation.Status).
-		Scan(&donation.ID, &donation.CreatedAt, &donation.UpdatedAt)
+	// Cari stok logistik yang sama di bencana tersebut, buat baru bila belum ada
+	var logisticID int
+	var err error
+	if donation.ItemID != nil {
+		err = tx.QueryRow(`SELECT id FROM logistics WHERE disaster_id = $1 AND item_id = $2 ORDER BY id LIMIT 1`, *donation.DisasterID, *donation.ItemID).Scan(&logisticID)
+	} else {
+		err = tx.QueryRow(`SELECT id FROM logistics WHERE disaster_id = $1 AND item_id IS NULL AND LOWER(type) = LOWER($2) ORDER BY id LIMIT 1`, *donation.DisasterID, donation.ItemName).Scan(&logisticID)
+	}
 
+	if err == sql.ErrNoRows {
+		unit := donation.Unit
+		if donation.ItemID != nil {
+			if err := tx.QueryRow(`SELECT base_unit FROM items WHERE id = $1`, *donation.ItemID).Scan(&unit); err != nil {
+				if err == sql.ErrNoRows {
+					return errors.New("item not found")
+				}
+				return err
+			}
+		}
+
+		err = tx.QueryRow(`INSERT INTO logistics (type, quantity, status, disaster_id, item_id, unit, created_at, updated_at)
+		                   VALUES ($1, 0, 'out_of_stock', $2, $3, NULLIF($4, ''), NOW(), NOW()) RETURNING id`,
+			donation.ItemName, donation.DisasterID, donation.ItemID, unit).Scan(&logisticID)
+	}
+	if err != nil {
+		return err
+	}
+
+	quantity, err := convertLogisticQuantity(tx, logisticID, donation.Unit, int(donation.Amount))
+	if err != nil {
+		return err
+	}
+
+	batch := structs.StockBatchInput{
+		BatchNumber: "DONASI-" + strconv.Itoa(donation.ID),
+		ExpiryDate:  donation.ExpiryDate,
+	}
+	movement, err := receiveStock(tx, logisticID, "receipt", quantity, batch, nil, "Donasi #"+strconv.Itoa(donation.ID), &recordedBy)
 	if err != nil {
 		return err
 	}
 
+	if _, err := tx.Exec(`UPDATE logistic_batches SET donation_id = $1 WHERE id = $2`, donation.ID, movement.BatchID); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`UPDATE donations SET logistic_id = $1, received_at = NOW() WHERE id = $2 RETURNING received_at`, logisticID, donation.ID).Scan(&donation.ReceivedAt)
+	if err != nil {
+		return err
+	}
+	donation.LogisticID = &logisticID
 	return nil
 }
 
-func GetAllDonations(db *sql.DB) ([]structs.Donation, error) {
-	query := `SELECT id, donor_id, disaster_id, amount, item_name, status, created_at, updated_at FROM donations`
-	rows, err := db.Query(query)
+func CreateDonation(db *sql.DB, donation *structs.Donation, recordedBy int) error {
+	if !isValidDonationStatus(donation.Status) {
+		return errors.New("invalid donation status")
+	}
+	// Donasi uang hanya dikonfirmasi oleh webhook payment gateway yang terverifikasi
+	if donation.Status == "confirmed" && !IsInKindDonation(*donation) {
+		return errors.New("donation confirmation requires payment")
+	}
 
+	tx, err := db.Begin()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
+	if donation.ItemID != nil && donation.ItemName == "" {
+		if err := tx.QueryRow(`SELECT name FROM items WHERE id = $1`, *donation.ItemID).Scan(&donation.ItemName); err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("item not found")
+			}
+			return err
+		}
+	}
+
+	// Donasi uang dikonversi ke mata uang laporan memakai kurs saat donasi dicatat
+	if !IsInKindDonation(*donation) {
+		if err := convertToReportingCurrency(tx, donation); err != nil {
+			return err
+		}
+	}
+
+	sqlQuery := `INSERT INTO donations (donor_id, disaster_id, amount, currency, exchange_rate, reporting_currency, amount_reporting, item_name, item_id, unit, expiry_date, status, created_at, updated_at)
+	             VALUES ($1, $2, $3, NULLIF($4, ''), $5, NULLIF($6, ''), $7, $8, $9, NULLIF($10, ''), $11, $12, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	err = tx.QueryRow(sqlQuery, donation.DonorID, donation.DisasterID, donation.Amount, donation.Currency, donation.ExchangeRate, donation.ReportingCurrency, donation.AmountReporting,
+		donation.ItemName, donation.ItemID, donation.Unit, donation.ExpiryDate, donation.Status).
+		Scan(&donation.ID, &donation.CreatedAt, &donation.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	// Donasi barang yang langsung dikonfirmasi masuk ke stok logistik bencana
+	if donation.Status == "confirmed" && IsInKindDonation(*donation) {
+		if err := receiveDonationStock(tx, donation, recordedBy); err != nil {
+			return err
+		}
+	}
+	if donation.Status == "confirmed" {
+		if err := issueDonationReceipt(tx, donation.ID); err != nil {
+			return err
+		}
+		if err := appendDonationLedger(tx, donation.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+const donationSelectQuery = `SELECT id, donor_id, disaster_id, amount, COALESCE(currency, ''), exchange_rate, COALESCE(reporting_currency, ''), amount_reporting,
+                                     COALESCE(item_name, ''), item_id, COALESCE(unit, ''), expiry_date, status, logistic_id, received_at, created_at, updated_at
+                              FROM donations`
+
+func scanDonations(rows *sql.Rows) ([]structs.Donation, error) {
 	var donations []structs.Donation
 	for rows.Next() {
 		var donation structs.Donation
-		err := rows.Scan(&donation.ID, &donation.DonorID, &donation.DisasterID, &donation.Amount, &donation.ItemName, &donation.Status, &donation.CreatedAt, &donation.UpdatedAt)
+		err := rows.Scan(&donation.ID, &donation.DonorID, &donation.DisasterID, &donation.Amount, &donation.Currency, &donation.ExchangeRate, &donation.ReportingCurrency, &donation.AmountReporting,
+			&donation.ItemName, &donation.ItemID, &donation.Unit, &donation.ExpiryDate, &donation.Status, &donation.LogisticID, &donation.ReceivedAt, &donation.CreatedAt, &donation.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
 		donations = append(donations, donation)
 	}
+	return donations, nil
+}
+
+func GetAllDonations(db *sql.DB) ([]structs.Donation, error) {
+	rows, err := db.Query(donationSelectQuery)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	donations, err := scanDonations(rows)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(donations) == 0 {
 		return nil, errors.New("tidak ada daftar donasi yang tersedia")
@@ -62,24 +183,105 @@ func GetAllDonations(db *sql.DB) ([]structs.Donation, error) {
 }
 
 func GetDonationByID(db *sql.DB, id int) (structs.Donation, error) {
-	query := `SELECT id, donor_id, disaster_id, amount, item_name, status, created_at, updated_at FROM donations WHERE id = $1`
 	var donation structs.Donation
-	err := db.QueryRow(query, id).Scan(&donation.ID, &donation.DonorID, &donation.DisasterID, &donation.Amount, &donation.ItemName, &donation.Status, &donation.CreatedAt, &donation.UpdatedAt)
+	rows, err := db.Query(donationSelectQuery+` WHERE id = $1`, id)
+	if err != nil {
+		return donation, err
+	}
+	defer rows.Close()
 
+	donations, err := scanDonations(rows)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return donation, errors.New("donation not found")
-		}
 		return donation, err
 	}
-	return donation, nil
+	if len(donations) == 0 {
+		return donation, errors.New("donation not found")
+	}
+	return donations[0], nil
 }
 
-func UpdateDonation(db *sql.DB, donation structs.Donation) error {
+func GetDonationsByDisasterID(db *sql.DB, disasterID int) ([]structs.Donation, error) {
+	if !isDisasterExists(db, disasterID) {
+		return nil, errors.New("disaster not found")
+	}
+
+	rows, err := db.Query(donationSelectQuery+` WHERE disaster_id = $1 ORDER BY created_at DESC`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDonations(rows)
+}
+
+func UpdateDonation(db *sql.DB, donation structs.Donation, recordedBy int) error {
 	if donation.Status != "" && !isValidDonationStatus(donation.Status) {
 		return errors.New("invalid donation status")
 	}
 
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previous structs.Donation
+	err = tx.QueryRow(`SELECT status, COALESCE(item_name, ''), item_id, amount, COALESCE(currency, '') FROM donations WHERE id = $1 FOR UPDATE`, donation.ID).
+		Scan(&previous.Status, &previous.ItemName, &previous.ItemID, &previous.Amount, &previous.Currency)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("donation not found")
+		}
+		return err
+	}
+
+	// Donasi terkonfirmasi sudah tercatat di buku besar publik dan kuitansinya sudah terbit, sehingga tidak boleh diubah
+	if previous.Status == "confirmed" {
+		return errors.New("donation already confirmed")
+	}
+
+	// Jenis donasi ditentukan dari data tersimpan, donasi uang tidak bisa diubah menjadi donasi barang atau sebaliknya
+	inKind := IsInKindDonation(previous)
+	if inKind && donation.Currency != "" {
+		return errors.New("donation kind locked")
+	}
+	if !inKind && (IsInKindDonation(donation) || donation.Unit != "" || donation.ExpiryDate != nil) {
+		return errors.New("donation kind locked")
+	}
+
+	if !inKind {
+		if donation.Status == "confirmed" {
+			return errors.New("donation confirmation requires payment")
+		}
+
+		// Nominal tidak boleh berubah selama tagihan pembayaran masih berjalan
+		pending, err := hasPendingPayment(tx, donation.ID)
+		if err != nil {
+			return err
+		}
+		if pending && (donation.Amount != 0 || donation.Currency != "" || donation.Status != "") {
+			return errors.New("donation payment in progress")
+		}
+
+		// Perubahan nominal atau mata uang dihitung ulang dengan kurs terbaru
+		if donation.Amount != 0 || donation.Currency != "" {
+			converted := structs.Donation{Amount: previous.Amount, Currency: previous.Currency}
+			if donation.Amount != 0 {
+				converted.Amount = donation.Amount
+			}
+			if donation.Currency != "" {
+				converted.Currency = donation.Currency
+			}
+			if err := convertToReportingCurrency(tx, &converted); err != nil {
+				return err
+			}
+			donation.Currency = converted.Currency
+			donation.ExchangeRate = converted.ExchangeRate
+			donation.ReportingCurrency = converted.ReportingCurrency
+			donation.AmountReporting = converted.AmountReporting
+		}
+	}
+
 	var updateFields []string
 	var values []interface{}
 	counter := 1
@@ -99,11 +301,36 @@ func UpdateDonation(db *sql.DB, donation structs.Donation) error {
 		values = append(values, donation.Amount)
 		counter++
 	}
+	if donation.Currency != "" {
+		updateFields = append(updateFields, "currency = $"+strconv.Itoa(counter))
+		values = append(values, donation.Currency)
+		counter++
+	}
+	if donation.AmountReporting != nil {
+		updateFields = append(updateFields, "exchange_rate = $"+strconv.Itoa(counter), "reporting_currency = $"+strconv.Itoa(counter+1), "amount_reporting = $"+strconv.Itoa(counter+2))
+		values = append(values, donation.ExchangeRate, donation.ReportingCurrency, donation.AmountReporting)
+		counter += 3
+	}
 	if donation.ItemName != "" {
 		updateFields = append(updateFields, "item_name = $"+strconv.Itoa(counter))
 		values = append(values, donation.ItemName)
 		counter++
 	}
+	if donation.ItemID != nil {
+		updateFields = append(updateFields, "item_id = $"+strconv.Itoa(counter))
+		values = append(values, donation.ItemID)
+		counter++
+	}
+	if donation.Unit != "" {
+		updateFields = append(updateFields, "unit = $"+strconv.Itoa(counter))
+		values = append(values, donation.Unit)
+		counter++
+	}
+	if donation.ExpiryDate != nil {
+		updateFields = append(updateFields, "expiry_date = $"+strconv.Itoa(counter))
+		values = append(values, donation.ExpiryDate)
+		counter++
+	}
 	if donation.Status != "" {
 		updateFields = append(updateFields, "status = $"+strconv.Itoa(counter))
 		values = append(values, donation.Status)
@@ -118,17 +345,94 @@ func UpdateDonation(db *sql.DB, donation structs.Donation) error {
 	query := "UPDATE donations SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
 	values = append(values, donation.ID)
 
-	_, err := db.Exec(query, values...)
+	_, err = tx.Exec(query, values...)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if donation.Status == "confirmed" {
+		var current structs.Donation
+		err = tx.QueryRow(`SELECT id, disaster_id, amount, COALESCE(item_name, ''), item_id, COALESCE(unit, ''), expiry_date FROM donations WHERE id = $1`, donation.ID).
+			Scan(&current.ID, &current.DisasterID, &current.Amount, &current.ItemName, &current.ItemID, &current.Unit, &current.ExpiryDate)
+		if err != nil {
+			return err
+		}
+		if IsInKindDonation(current) {
+			if err := receiveDonationStock(tx, &current, recordedBy); err != nil {
+				return err
+			}
+		}
+		if err := issueDonationReceipt(tx, donation.ID); err != nil {
+			return err
+		}
+		if err := appendDonationLedger(tx, donation.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func GetDonationTrace(db *sql.DB, id int) (structs.DonationTrace, error) {
+	trace := structs.DonationTrace{DonationID: id, Distributions: []structs.DonationDistribution{}}
+
+	var logisticID *int
+	err := db.QueryRow(`SELECT d.logistic_id, COALESCE(SUM(b.quantity_received), 0), COALESCE(SUM(b.quantity_remaining), 0)
+	                    FROM donations d LEFT JOIN logistic_batches b ON b.donation_id = d.id
+	                    WHERE d.id = $1 GROUP BY d.id`, id).Scan(&logisticID, &trace.QuantityReceived, &trace.QuantityRemaining)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return trace, errors.New("donation not found")
+		}
+		return trace, err
+	}
+	if logisticID == nil {
+		return trace, errors.New("donation not received")
+	}
+	trace.LogisticID = *logisticID
+
+	// Pengeluaran dari batch donasi per log distribusi, distribusi yang sudah diretur tidak ikut dihitung
+	rows, err := db.Query(`SELECT l.id, l.destination, l.recipient_name, l.status, -SUM(m.quantity), l.sent_at
+	                       FROM stock_movements m
+	                       JOIN logistic_batches b ON b.id = m.batch_id
+	                       JOIN distribution_logs l ON l.id = m.distribution_log_id
+	                       WHERE b.donation_id = $1
+	                       GROUP BY l.id HAVING SUM(m.quantity) < 0
+	                       ORDER BY l.sent_at, l.id`, id)
+	if err != nil {
+		return trace, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var distribution structs.DonationDistribution
+		err := rows.Scan(&distribution.DistributionLogID, &distribution.Destination, &distribution.RecipientName, &distribution.Status, &distribution.Quantity, &distribution.SentAt)
+		if err != nil {
+			return trace, err
+		}
+		trace.QuantityDispatched += distribution.Quantity
+		trace.Distributions = append(trace.Distributions, distribution)
+	}
+	return trace, nil
 }
 
 
 func DeleteDonation(db *sql.DB, id int) error {
+	// Donasi terkonfirmasi sudah tercatat di buku besar publik sehingga tidak boleh dihapus
+	var status string
+	err := db.QueryRow(`SELECT status FROM donations WHERE id = $1`, id).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("donation not found")
+		}
+		return err
+	}
+	if status == "confirmed" {
+		return errors.New("donation recorded in ledger")
+	}
+
 	sqlQuery := `DELETE FROM donations WHERE id=$1`
-	_, err := db.Exec(sqlQuery, id)
+	_, err = db.Exec(sqlQuery, id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+func issueDonationReceipt(tx *sql.Tx, donationID int) error {
+	code, err := generateCode(12)
+	if err != nil {
+		return err
+	}
+
+	// Nomor kuitansi berurutan per tahun terbit: RH/<tahun>/<nomor urut>, nominal dan barang disalin dari donasi saat kuitansi terbit
+	_, err = tx.Exec(`INSERT INTO donation_receipts (donation_id, receipt_number, verification_code, amount, currency, item_name, unit, issued_at)
+	                  SELECT id, 'RH/' || TO_CHAR(NOW(), 'YYYY') || '/' || LPAD(NEXTVAL('donation_receipt_number_seq')::TEXT, 6, '0'), $2, amount, currency, item_name, unit, NOW()
+	                  FROM donations WHERE id = $1
+	                  ON CONFLICT (donation_id) DO NOTHING`, donationID, code)
+	return err
+}
+
+const donationReceiptQuery = `SELECT r.id, r.donation_id, r.receipt_number, r.verification_code, COALESCE(u.name, 'Anonim'), COALESCE(u.email, ''),
+                                     COALESCE(ds.type || ' - ' || ds.location, ''), r.amount, COALESCE(r.currency, ''), COALESCE(r.item_name, ''), COALESCE(r.unit, ''), d.status, d.created_at, r.issued_at
+                              FROM donation_receipts r
+                              JOIN donations d ON d.id = r.donation_id
+                              LEFT JOIN users u ON u.id = d.donor_id
+                              LEFT JOIN disasters ds ON ds.id = d.disaster_id`
+
+func scanDonationReceipt(row *sql.Row) (structs.DonationReceipt, error) {
+	var receipt structs.DonationReceipt
+	err := row.Scan(&receipt.ID, &receipt.DonationID, &receipt.ReceiptNumber, &receipt.VerificationCode, &receipt.DonorName, &receipt.DonorEmail,
+		&receipt.DisasterName, &receipt.Amount, &receipt.Currency, &receipt.ItemName, &receipt.Unit, &receipt.Status, &receipt.DonatedAt, &receipt.IssuedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return receipt, errors.New("receipt not found")
+		}
+		return receipt, err
+	}
+	return receipt, nil
+}
+
+func GetDonationReceipt(db *sql.DB, donationID int) (structs.DonationReceipt, error) {
+	return scanDonationReceipt(db.QueryRow(donationReceiptQuery+` WHERE r.donation_id = $1`, donationID))
+}
+
+func VerifyDonationReceipt(db *sql.DB, code string) (structs.DonationReceipt, error) {
+	return scanDonationReceipt(db.QueryRow(donationReceiptQuery+` WHERE r.verification_code = $1`, strings.ToUpper(strings.TrimSpace(code))))
+}
@@ -9,9 +9,13 @@ import (
 )
 
 func CreateEmergencyReport(db *sql.DB, report *structs.EmergencyReport) error {
-	sqlQuery := `INSERT INTO emergency_reports (user_id, disaster_id, description, location, created_at, updated_at)
-							VALUES ($1, $2, $3, $4, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, report.UserID, report.DisasterID, report.Description, report.Location).
+	if err := validateLocation(report.Latitude, report.Longitude, report.LocationAccuracy); err != nil {
+		return err
+	}
+
+	sqlQuery := `INSERT INTO emergency_reports (user_id, disaster_id, description, location, latitude, longitude, location_accuracy, created_at, updated_at)
+							VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	err := db.QueryRow(sqlQuery, report.UserID, report.DisasterID, report.Description, report.Location, report.Latitude, report.Longitude, report.LocationAccuracy).
 		Scan(&report.ID, &report.CreatedAt, &report.UpdatedAt)
 
 	if err != nil {
@@ -22,7 +26,7 @@ func CreateEmergencyReport(db *sql.DB, report *structs.EmergencyReport) error {
 }
 
 func GetAllEmergencyReports(db *sql.DB) ([]structs.EmergencyReport, error) {
-	query := `SELECT id, user_id, disaster_id, description, location, created_at, updated_at FROM emergency_reports`
+	query := `SELECT id, user_id, disaster_id, description, location, latitude, longitude, location_accuracy, COALESCE(triage_category::TEXT, ''), triaged_at, created_at, updated_at FROM emergency_reports`
 	rows, err := db.Query(query)
 
 	if err != nil {
@@ -33,7 +37,30 @@ func GetAllEmergencyReports(db *sql.DB) ([]structs.EmergencyReport, error) {
 	var reports []structs.EmergencyReport
 	for rows.Next() {
 		var report structs.EmergencyReport
-		err := rows.Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.CreatedAt, &report.UpdatedAt)
+		err := rows.Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.Latitude, &report.Longitude, &report.LocationAccuracy, &report.TriageCategory, &report.TriagedAt, &report.CreatedAt, &report.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func GetEmergencyReportsByArea(db *sql.DB, filter structs.GeoFilter) ([]structs.EmergencyReport, error) {
+	distanceColumn, where, order, values := buildGeoQuery(filter)
+	query := `SELECT id, user_id, disaster_id, description, location, latitude, longitude, location_accuracy, COALESCE(triage_category::TEXT, ''), triaged_at, ` + distanceColumn + ` AS distance_km, created_at, updated_at
+	          FROM emergency_reports WHERE ` + where + ` ORDER BY ` + order
+	rows, err := db.Query(query, values...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []structs.EmergencyReport
+	for rows.Next() {
+		var report structs.EmergencyReport
+		err := rows.Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.Latitude, &report.Longitude, &report.LocationAccuracy, &report.TriageCategory, &report.TriagedAt, &report.DistanceKm, &report.CreatedAt, &report.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -43,9 +70,9 @@ func GetAllEmergencyReports(db *sql.DB) ([]structs.EmergencyReport, error) {
 }
 
 func GetEmergencyReportByID(db *sql.DB, id int) (structs.EmergencyReport, error) {
-	query := `SELECT id, user_id, disaster_id, description, location, created_at, updated_at FROM emergency_reports WHERE id = $1`
+	query := `SELECT id, user_id, disaster_id, description, location, latitude, longitude, location_accuracy, COALESCE(triage_category::TEXT, ''), triaged_at, created_at, updated_at FROM emergency_reports WHERE id = $1`
 	var report structs.EmergencyReport
-	err := db.QueryRow(query, id).Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.CreatedAt, &report.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.Latitude, &report.Longitude, &report.LocationAccuracy, &report.TriageCategory, &report.TriagedAt, &report.CreatedAt, &report.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -68,6 +95,10 @@ func UpdateEmergencyReport(db *sql.DB, report structs.EmergencyReport) error {
 		return errors.New("emergency report not found")
 	}
 
+	if err := validateLocation(report.Latitude, report.Longitude, report.LocationAccuracy); err != nil {
+		return err
+	}
+
 	var updateFields []string
 	var values []interface{}
 	counter := 1
@@ -92,6 +123,16 @@ func UpdateEmergencyReport(db *sql.DB, report structs.EmergencyReport) error {
 		values = append(values, report.Location)
 		counter++
 	}
+	if report.Latitude != nil && report.Longitude != nil {
+		updateFields = append(updateFields, "latitude = $"+strconv.Itoa(counter), "longitude = $"+strconv.Itoa(counter+1))
+		values = append(values, report.Latitude, report.Longitude)
+		counter += 2
+	}
+	if report.LocationAccuracy != nil {
+		updateFields = append(updateFields, "location_accuracy = $"+strconv.Itoa(counter))
+		values = append(values, report.LocationAccuracy)
+		counter++
+	}
 
 	if len(updateFields) == 0 {
 		return errors.New("tidak ada field yang dapat diperbarui")
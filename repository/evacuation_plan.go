@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"container/heap"
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	riskyRoutePenalty    = 1.5
+	maxAccessDistanceKm  = 2.0
+	shelterMatchRadiusKm = 0.5
+)
+
+type planNode struct {
+	name      string
+	latitude  *float64
+	longitude *float64
+}
+
+type planEdge struct {
+	route  structs.EvacuationRoute
+	from   string
+	to     string
+	status string
+	cost   float64
+}
+
+type planQueueItem struct {
+	node string
+	cost float64
+}
+
+type planQueue []planQueueItem
+
+func (q planQueue) Len() int            { return len(q) }
+func (q planQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q planQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *planQueue) Push(x interface{}) { *q = append(*q, x.(planQueueItem)) }
+func (q *planQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func normalizeNodeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func routeEffectiveStatus(route structs.EvacuationRoute) string {
+	status := route.Status
+	for _, segment := range route.Segments {
+		if segment.Status == "blocked" {
+			return "blocked"
+		}
+		if segment.Status == "risky" {
+			status = "risky"
+		}
+	}
+	return status
+}
+
+func PlanEvacuation(db *sql.DB, disasterID int, fromName string, fromLatitude, fromLongitude *float64) (structs.EvacuationPlan, error) {
+	plan := structs.EvacuationPlan{DisasterID: disasterID, Legs: []structs.EvacuationPlanLeg{}}
+
+	if !isDisasterExists(db, disasterID) {
+		return plan, errors.New("disaster not found")
+	}
+
+	routes, err := GetEvacuationRoutesByDisasterID(db, disasterID)
+	if err != nil {
+		return plan, err
+	}
+
+	shelters, err := GetSheltersByDisasterID(db, disasterID)
+	if err != nil {
+		return plan, err
+	}
+
+	nodes := make(map[string]*planNode)
+	addNode := func(name string, latitude, longitude *float64) string {
+		key := normalizeNodeName(name)
+		node, exists := nodes[key]
+		if !exists {
+			node = &planNode{name: strings.TrimSpace(name)}
+			nodes[key] = node
+		}
+		if node.latitude == nil && latitude != nil && longitude != nil {
+			node.latitude, node.longitude = latitude, longitude
+		}
+		return key
+	}
+
+	adjacency := make(map[string][]planEdge)
+	for _, route := range routes {
+		var originLatitude, originLongitude, destinationLatitude, destinationLongitude *float64
+		if len(route.Waypoints) >= 2 {
+			first, last := route.Waypoints[0], route.Waypoints[len(route.Waypoints)-1]
+			originLatitude, originLongitude = &first.Latitude, &first.Longitude
+			destinationLatitude, destinationLongitude = &last.Latitude, &last.Longitude
+		}
+		origin := addNode(route.Origin, originLatitude, originLongitude)
+		destination := addNode(route.Destination, destinationLatitude, destinationLongitude)
+
+		status := routeEffectiveStatus(route)
+		if status == "blocked" {
+			continue
+		}
+
+		cost := route.Distance
+		if status == "risky" {
+			cost *= riskyRoutePenalty
+		}
+
+		adjacency[origin] = append(adjacency[origin], planEdge{route: route, from: origin, to: destination, status: status, cost: cost})
+		adjacency[destination] = append(adjacency[destination], planEdge{route: route, from: destination, to: origin, status: status, cost: cost})
+	}
+
+	targets := make(map[string]structs.Shelter)
+	for _, shelter := range shelters {
+		if key := normalizeNodeName(shelter.Name); nodes[key] != nil {
+			addNode(shelter.Name, shelter.Latitude, shelter.Longitude)
+		}
+	}
+	for _, shelter := range shelters {
+		if shelter.CapacityRemaining <= 0 {
+			continue
+		}
+		if key := normalizeNodeName(shelter.Name); nodes[key] != nil {
+			targets[key] = shelter
+		}
+		if shelter.Latitude == nil || shelter.Longitude == nil {
+			continue
+		}
+		for key, node := range nodes {
+			if _, exists := targets[key]; exists || node.latitude == nil {
+				continue
+			}
+			if HaversineKm(*shelter.Latitude, *shelter.Longitude, *node.latitude, *node.longitude) <= shelterMatchRadiusKm {
+				targets[key] = shelter
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return plan, errors.New("no shelter available")
+	}
+
+	sources := make(map[string]float64)
+	if fromLatitude != nil && fromLongitude != nil {
+		nearestKey, nearestDistance := "", math.Inf(1)
+		for key, node := range nodes {
+			if node.latitude == nil {
+				continue
+			}
+			distance := HaversineKm(*fromLatitude, *fromLongitude, *node.latitude, *node.longitude)
+			if distance <= maxAccessDistanceKm {
+				sources[key] = distance
+			}
+			if distance < nearestDistance {
+				nearestKey, nearestDistance = key, distance
+			}
+		}
+		if len(sources) == 0 && nearestKey != "" {
+			sources[nearestKey] = nearestDistance
+		}
+	} else if key := normalizeNodeName(fromName); nodes[key] != nil {
+		sources[key] = 0
+	}
+
+	if len(sources) == 0 {
+		return plan, errors.New("start point not found")
+	}
+
+	costs := make(map[string]float64)
+	previous := make(map[string]planEdge)
+	queue := &planQueue{}
+	for key, access := range sources {
+		costs[key] = access
+		heap.Push(queue, planQueueItem{node: key, cost: access})
+	}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(planQueueItem)
+		if item.cost > costs[item.node] {
+			continue
+		}
+		for _, edge := range adjacency[item.node] {
+			next := item.cost + edge.cost
+			if current, visited := costs[edge.to]; !visited || next < current {
+				costs[edge.to] = next
+				previous[edge.to] = edge
+				heap.Push(queue, planQueueItem{node: edge.to, cost: next})
+			}
+		}
+	}
+
+	bestKey, bestCost := "", math.Inf(1)
+	for key := range targets {
+		if cost, reached := costs[key]; reached && cost < bestCost {
+			bestKey, bestCost = key, cost
+		}
+	}
+
+	if bestKey == "" {
+		return plan, errors.New("no evacuation path")
+	}
+
+	var legs []structs.EvacuationPlanLeg
+	current := bestKey
+	for {
+		edge, exists := previous[current]
+		if !exists {
+			break
+		}
+		legs = append([]structs.EvacuationPlanLeg{{
+			RouteID:  edge.route.ID,
+			From:     nodes[edge.from].name,
+			To:       nodes[edge.to].name,
+			Distance: edge.route.Distance,
+			Status:   edge.status,
+			Cost:     math.Round(edge.cost*100) / 100,
+		}}, legs...)
+		current = edge.from
+	}
+
+	plan.StartNode = nodes[current].name
+	plan.AccessDistance = math.Round(sources[current]*100) / 100
+	plan.Shelter = targets[bestKey]
+	plan.TotalDistance = plan.AccessDistance
+	for _, leg := range legs {
+		plan.TotalDistance += leg.Distance
+	}
+	plan.TotalDistance = math.Round(plan.TotalDistance*100) / 100
+	plan.TotalCost = math.Round(bestCost*100) / 100
+	if legs != nil {
+		plan.Legs = legs
+	}
+
+	return plan, nil
+}
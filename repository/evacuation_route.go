@@ -4,8 +4,11 @@ import (
 	"RescueHub/structs"
 	"database/sql"
 	"errors"
+	"math"
 	"strconv"
 	"strings"
+
+	"github.com/lib/pq"
 )
 
 func isValidEvacuationStatus(status string) bool {
@@ -18,21 +21,135 @@ func isValidEvacuationStatus(status string) bool {
 	return false
 }
 
+func validateRouteWaypoints(waypoints []structs.RouteWaypoint) error {
+	if waypoints == nil {
+		return nil
+	}
+	if len(waypoints) < 2 {
+		return errors.New("invalid route geometry")
+	}
+	for _, waypoint := range waypoints {
+		if !isValidCoordinates(&waypoint.Latitude, &waypoint.Longitude) {
+			return errors.New("invalid route geometry")
+		}
+	}
+	return nil
+}
+
+func insertRouteGeometry(tx *sql.Tx, routeID int, waypoints []structs.RouteWaypoint) ([]structs.RouteSegment, error) {
+	for i := range waypoints {
+		waypoints[i].Sequence = i
+		_, err := tx.Exec(`INSERT INTO evacuation_route_waypoints (route_id, sequence, latitude, longitude) VALUES ($1, $2, $3, $4)`,
+			routeID, i, waypoints[i].Latitude, waypoints[i].Longitude)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var segments []structs.RouteSegment
+	for i := 1; i < len(waypoints); i++ {
+		segment := structs.RouteSegment{
+			RouteID:  routeID,
+			Sequence: i - 1,
+			Distance: math.Round(HaversineKm(waypoints[i-1].Latitude, waypoints[i-1].Longitude, waypoints[i].Latitude, waypoints[i].Longitude)*1000) / 1000,
+			Status:   "safe",
+		}
+		err := tx.QueryRow(`INSERT INTO evacuation_route_segments (route_id, sequence, distance, status, created_at, updated_at)
+		                    VALUES ($1, $2, $3, $4, NOW(), NOW()) RETURNING id, created_at, updated_at`,
+			segment.RouteID, segment.Sequence, segment.Distance, segment.Status).
+			Scan(&segment.ID, &segment.CreatedAt, &segment.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+func attachRouteGeometry(db *sql.DB, routes []structs.EvacuationRoute) error {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	positions := make(map[int]int)
+	ids := make([]int64, 0, len(routes))
+	for i, route := range routes {
+		positions[route.ID] = i
+		ids = append(ids, int64(route.ID))
+	}
+
+	rows, err := db.Query(`SELECT route_id, sequence, latitude, longitude FROM evacuation_route_waypoints
+	                       WHERE route_id = ANY($1) ORDER BY route_id, sequence`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var routeID int
+		var waypoint structs.RouteWaypoint
+		if err := rows.Scan(&routeID, &waypoint.Sequence, &waypoint.Latitude, &waypoint.Longitude); err != nil {
+			return err
+		}
+		position := positions[routeID]
+		routes[position].Waypoints = append(routes[position].Waypoints, waypoint)
+	}
+
+	segmentRows, err := db.Query(`SELECT id, route_id, sequence, distance, status, COALESCE(note, ''), created_at, updated_at
+	                              FROM evacuation_route_segments WHERE route_id = ANY($1) ORDER BY route_id, sequence`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer segmentRows.Close()
+
+	for segmentRows.Next() {
+		var segment structs.RouteSegment
+		err := segmentRows.Scan(&segment.ID, &segment.RouteID, &segment.Sequence, &segment.Distance, &segment.Status, &segment.Note, &segment.CreatedAt, &segment.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		position := positions[segment.RouteID]
+		routes[position].Segments = append(routes[position].Segments, segment)
+	}
+	return nil
+}
+
 func CreateEvacuationRoute(db *sql.DB, route *structs.EvacuationRoute) error {
 	if !isValidEvacuationStatus(route.Status) {
 		return errors.New("invalid evacuation route status")
 	}
 
+	if err := validateRouteWaypoints(route.Waypoints); err != nil {
+		return err
+	}
+
+	if len(route.Waypoints) > 0 {
+		route.Distance = RouteDistanceKm(route.Waypoints)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	sqlQuery := `INSERT INTO evacuation_routes (disaster_id, origin, destination, distance, route, status, created_at, updated_at)
 							VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, route.DisasterID, route.Origin, route.Destination, route.Distance, route.Route, route.Status).
+	err = tx.QueryRow(sqlQuery, route.DisasterID, route.Origin, route.Destination, route.Distance, route.Route, route.Status).
 		Scan(&route.ID, &route.CreatedAt, &route.UpdatedAt)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if len(route.Waypoints) > 0 {
+		route.Segments, err = insertRouteGeometry(tx, route.ID, route.Waypoints)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func GetAllEvacuationRoutes(db *sql.DB) ([]structs.EvacuationRoute, error) {
@@ -53,6 +170,10 @@ func GetAllEvacuationRoutes(db *sql.DB) ([]structs.EvacuationRoute, error) {
 		}
 		routes = append(routes, route)
 	}
+
+	if err := attachRouteGeometry(db, routes); err != nil {
+		return nil, err
+	}
 	return routes, nil
 }
 
@@ -67,7 +188,12 @@ func GetEvacuationRouteByID(db *sql.DB, id int) (structs.EvacuationRoute, error)
 		}
 		return route, err
 	}
-	return route, nil
+
+	routes := []structs.EvacuationRoute{route}
+	if err := attachRouteGeometry(db, routes); err != nil {
+		return route, err
+	}
+	return routes[0], nil
 }
 
 func isEvacuationRouteExists(db *sql.DB, id int) bool {
@@ -86,6 +212,14 @@ func UpdateEvacuationRoute(db *sql.DB, route structs.EvacuationRoute) error {
 		return errors.New("invalid evacuation route status")
 	}
 
+	if err := validateRouteWaypoints(route.Waypoints); err != nil {
+		return err
+	}
+
+	if len(route.Waypoints) > 0 {
+		route.Distance = RouteDistanceKm(route.Waypoints)
+	}
+
 	var updateFields []string
 	var values []interface{}
 	counter := 1
@@ -129,11 +263,51 @@ func UpdateEvacuationRoute(db *sql.DB, route structs.EvacuationRoute) error {
 	query := "UPDATE evacuation_routes SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
 	values = append(values, route.ID)
 
-	_, err := db.Exec(query, values...)
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query, values...)
+	if err != nil {
+		return err
+	}
+
+	if len(route.Waypoints) > 0 {
+		if _, err := tx.Exec(`DELETE FROM evacuation_route_segments WHERE route_id = $1`, route.ID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM evacuation_route_waypoints WHERE route_id = $1`, route.ID); err != nil {
+			return err
+		}
+		if _, err := insertRouteGeometry(tx, route.ID, route.Waypoints); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func UpdateRouteSegment(db *sql.DB, routeID, sequence int, input structs.RouteSegmentInput) (structs.RouteSegment, error) {
+	var segment structs.RouteSegment
+	if !isValidEvacuationStatus(input.Status) {
+		return segment, errors.New("invalid evacuation route status")
+	}
+
+	query := `UPDATE evacuation_route_segments SET status = $1, note = $2, updated_at = NOW()
+	          WHERE route_id = $3 AND sequence = $4
+	          RETURNING id, route_id, sequence, distance, status, COALESCE(note, ''), created_at, updated_at`
+	err := db.QueryRow(query, input.Status, input.Note, routeID, sequence).
+		Scan(&segment.ID, &segment.RouteID, &segment.Sequence, &segment.Distance, &segment.Status, &segment.Note, &segment.CreatedAt, &segment.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return segment, errors.New("route segment not found")
+		}
+		return segment, err
+	}
+	return segment, nil
 }
 
 
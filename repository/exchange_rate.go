@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"strings"
+)
+
+// Kode mata uang aktif ISO 4217
+var iso4217Currencies = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true, "AUD": true, "AWG": true, "AZN": true,
+	"BAM": true, "BBD": true, "BDT": true, "BGN": true, "BHD": true, "BIF": true, "BMD": true, "BND": true, "BOB": true, "BRL": true,
+	"BSD": true, "BTN": true, "BWP": true, "BYN": true, "BZD": true, "CAD": true, "CDF": true, "CHF": true, "CLP": true, "CNY": true,
+	"COP": true, "CRC": true, "CUP": true, "CVE": true, "CZK": true, "DJF": true, "DKK": true, "DOP": true, "DZD": true, "EGP": true,
+	"ERN": true, "ETB": true, "EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true, "GIP": true, "GMD": true,
+	"GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true, "HTG": true, "HUF": true, "IDR": true, "ILS": true, "INR": true,
+	"IQD": true, "IRR": true, "ISK": true, "JMD": true, "JOD": true, "JPY": true, "KES": true, "KGS": true, "KHR": true, "KMF": true,
+	"KPW": true, "KRW": true, "KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true, "LRD": true, "LSL": true,
+	"LYD": true, "MAD": true, "MDL": true, "MGA": true, "MKD": true, "MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true,
+	"MVR": true, "MWK": true, "MXN": true, "MYR": true, "MZN": true, "NAD": true, "NGN": true, "NIO": true, "NOK": true, "NPR": true,
+	"NZD": true, "OMR": true, "PAB": true, "PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true,
+	"RON": true, "RSD": true, "RUB": true, "RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true,
+	"SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true, "SVC": true, "SYP": true, "SZL": true, "THB": true,
+	"TJS": true, "TMT": true, "TND": true, "TOP": true, "TRY": true, "TTD": true, "TWD": true, "TZS": true, "UAH": true, "UGX": true,
+	"USD": true, "UYU": true, "UZS": true, "VES": true, "VND": true, "VUV": true, "WST": true, "XAF": true, "XCD": true, "XOF": true,
+	"XPF": true, "YER": true, "ZAR": true, "ZMW": true, "ZWL": true,
+}
+
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func IsValidCurrency(code string) bool {
+	return iso4217Currencies[code]
+}
+
+// ReportingCurrency adalah mata uang laporan dari environment variable REPORTING_CURRENCY, default IDR
+func ReportingCurrency() string {
+	currency := NormalizeCurrency(os.Getenv("REPORTING_CURRENCY"))
+	if !IsValidCurrency(currency) {
+		return "IDR"
+	}
+	return currency
+}
+
+func convertToReportingCurrency(tx *sql.Tx, donation *structs.Donation) error {
+	donation.Currency = NormalizeCurrency(donation.Currency)
+	if donation.Currency == "" {
+		donation.Currency = "IDR"
+	}
+	if !IsValidCurrency(donation.Currency) {
+		return errors.New("invalid currency")
+	}
+
+	reporting := ReportingCurrency()
+	rate := 1.0
+	if donation.Currency != reporting {
+		err := tx.QueryRow(`SELECT rate FROM exchange_rates WHERE base_currency = $1 AND currency = $2`, reporting, donation.Currency).Scan(&rate)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("exchange rate not found")
+			}
+			return err
+		}
+	}
+
+	converted := math.Round(donation.Amount*rate*100) / 100
+	donation.ExchangeRate = &rate
+	donation.ReportingCurrency = reporting
+	donation.AmountReporting = &converted
+	return nil
+}
+
+func UpsertExchangeRates(db *sql.DB, baseCurrency string, rates map[string]float64, source string, updatedBy *int) error {
+	baseCurrency = NormalizeCurrency(baseCurrency)
+	if baseCurrency == "" {
+		baseCurrency = ReportingCurrency()
+	}
+	if !IsValidCurrency(baseCurrency) {
+		return errors.New("invalid currency")
+	}
+	if len(rates) == 0 {
+		return errors.New("invalid exchange rate")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rates[baseCurrency] = 1
+	for currency, rate := range rates {
+		currency = NormalizeCurrency(currency)
+		if !IsValidCurrency(currency) {
+			return errors.New("invalid currency")
+		}
+		if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return errors.New("invalid exchange rate")
+		}
+
+		_, err := tx.Exec(`INSERT INTO exchange_rates (base_currency, currency, rate, source, updated_by, updated_at)
+		                   VALUES ($1, $2, $3, NULLIF($4, ''), $5, NOW())
+		                   ON CONFLICT (base_currency, currency) DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+			baseCurrency, currency, rate, source, updatedBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func GetExchangeRates(db *sql.DB, baseCurrency string) ([]structs.ExchangeRate, error) {
+	rows, err := db.Query(`SELECT base_currency, currency, rate, COALESCE(source, ''), updated_by, updated_at
+	                       FROM exchange_rates WHERE base_currency = $1 ORDER BY currency`, NormalizeCurrency(baseCurrency))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []structs.ExchangeRate{}
+	for rows.Next() {
+		var rate structs.ExchangeRate
+		if err := rows.Scan(&rate.BaseCurrency, &rate.Currency, &rate.Rate, &rate.Source, &rate.UpdatedBy, &rate.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// LoadExchangeRatesFile memuat kurs dari file JSON berformat {"base_currency": "IDR", "rates": {"USD": 16250}}
+func LoadExchangeRatesFile(db *sql.DB, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var input structs.ExchangeRateInput
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+	return UpsertExchangeRates(db, input.BaseCurrency, input.Rates, "file", nil)
+}
+
+func getDonationTotals(db *sql.DB, column string, id int) (structs.DonationTotals, error) {
+	totals := structs.DonationTotals{ReportingCurrency: ReportingCurrency(), ByCurrency: []structs.CurrencyTotal{}}
+
+	// Hanya donasi uang yang sudah dikonfirmasi, dijumlahkan per mata uang asal dan dalam mata uang laporan
+	rows, err := db.Query(`SELECT currency, COUNT(*), SUM(amount), COALESCE(SUM(amount_reporting) FILTER (WHERE reporting_currency = $2), 0)
+	                       FROM donations WHERE `+column+` = $1 AND status = 'confirmed' AND currency IS NOT NULL
+	                       GROUP BY currency ORDER BY currency`, id, totals.ReportingCurrency)
+	if err != nil {
+		return totals, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var total structs.CurrencyTotal
+		if err := rows.Scan(&total.Currency, &total.Count, &total.Amount, &total.AmountReporting); err != nil {
+			return totals, err
+		}
+		totals.TotalReporting += total.AmountReporting
+		totals.ByCurrency = append(totals.ByCurrency, total)
+	}
+	totals.TotalReporting = math.Round(totals.TotalReporting*100) / 100
+	return totals, nil
+}
+
+func GetDonationTotalsByDisasterID(db *sql.DB, disasterID int) (structs.DonationTotals, error) {
+	return getDonationTotals(db, "disaster_id", disasterID)
+}
+
+func GetDonationTotalsByDonorID(db *sql.DB, donorID int) (structs.DonationTotals, error) {
+	return getDonationTotals(db, "donor_id", donorID)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"errors"
+	"math"
+	"strconv"
+)
+
+const earthRadiusKm = 6371.0
+
+func HaversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func isValidCoordinates(latitude, longitude *float64) bool {
+	if latitude == nil && longitude == nil {
+		return true
+	}
+	if latitude == nil || longitude == nil {
+		return false
+	}
+	return *latitude >= -90 && *latitude <= 90 && *longitude >= -180 && *longitude <= 180
+}
+
+func validateLocation(latitude, longitude, accuracy *float64) error {
+	if !isValidCoordinates(latitude, longitude) {
+		return errors.New("invalid coordinates")
+	}
+	if accuracy != nil && *accuracy < 0 {
+		return errors.New("invalid coordinates")
+	}
+	return nil
+}
+
+func distanceSQL(latParam, lonParam int) string {
+	lat := "$" + strconv.Itoa(latParam)
+	lon := "$" + strconv.Itoa(lonParam)
+	return "(" + strconv.FormatFloat(earthRadiusKm, 'f', 1, 64) + " * 2 * ASIN(LEAST(1, SQRT(" +
+		"POWER(SIN(RADIANS(latitude - " + lat + ") / 2), 2) + " +
+		"COS(RADIANS(" + lat + ")) * COS(RADIANS(latitude)) * " +
+		"POWER(SIN(RADIANS(longitude - " + lon + ") / 2), 2)))))"
+}
+
+func buildGeoQuery(filter structs.GeoFilter) (string, string, string, []interface{}) {
+	distanceColumn := "NULL::DOUBLE PRECISION"
+	where := "latitude IS NOT NULL AND longitude IS NOT NULL"
+	order := "id"
+	var values []interface{}
+	counter := 1
+
+	if filter.Latitude != nil && filter.Longitude != nil {
+		distanceColumn = distanceSQL(counter, counter+1)
+		values = append(values, *filter.Latitude, *filter.Longitude)
+		counter += 2
+		if filter.RadiusKm > 0 {
+			where += " AND " + distanceColumn + " <= $" + strconv.Itoa(counter)
+			values = append(values, filter.RadiusKm)
+			counter++
+		}
+		order = "distance_km"
+	}
+
+	if filter.BoundingBox != nil {
+		where += " AND latitude BETWEEN $" + strconv.Itoa(counter) + " AND $" + strconv.Itoa(counter+1) +
+			" AND longitude BETWEEN $" + strconv.Itoa(counter+2) + " AND $" + strconv.Itoa(counter+3)
+		values = append(values, filter.BoundingBox.MinLatitude, filter.BoundingBox.MaxLatitude,
+			filter.BoundingBox.MinLongitude, filter.BoundingBox.MaxLongitude)
+	}
+
+	return distanceColumn, where, order, values
+}
+
+func DecodePolyline(encoded string) ([]structs.RouteWaypoint, error) {
+	var waypoints []structs.RouteWaypoint
+	index, latitude, longitude := 0, 0, 0
+
+	for index < len(encoded) {
+		for _, target := range []*int{&latitude, &longitude} {
+			result, shift := 0, 0
+			for {
+				if index >= len(encoded) {
+					return nil, errors.New("invalid polyline")
+				}
+				b := int(encoded[index]) - 63
+				index++
+				if b < 0 || b > 63 {
+					return nil, errors.New("invalid polyline")
+				}
+				result |= (b & 0x1f) << shift
+				shift += 5
+				if b < 0x20 {
+					break
+				}
+			}
+			if result&1 != 0 {
+				*target += ^(result >> 1)
+			} else {
+				*target += result >> 1
+			}
+		}
+
+		waypoints = append(waypoints, structs.RouteWaypoint{
+			Sequence:  len(waypoints),
+			Latitude:  float64(latitude) / 1e5,
+			Longitude: float64(longitude) / 1e5,
+		})
+	}
+
+	return waypoints, nil
+}
+
+func RouteDistanceKm(waypoints []structs.RouteWaypoint) float64 {
+	total := 0.0
+	for i := 1; i < len(waypoints); i++ {
+		total += HaversineKm(waypoints[i-1].Latitude, waypoints[i-1].Longitude, waypoints[i].Latitude, waypoints[i].Longitude)
+	}
+	return math.Round(total*100) / 100
+}
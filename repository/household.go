@@ -0,0 +1,404 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	infantRationUnit = 0.5
+	childRationUnit  = 0.75
+	adultRationUnit  = 1.0
+)
+
+func rationUnit(age int) float64 {
+	if age < 5 {
+		return infantRationUnit
+	}
+	if age < 18 {
+		return childRationUnit
+	}
+	return adultRationUnit
+}
+
+func splitNeeds(needs string) []string {
+	var parts []string
+	for _, need := range strings.FieldsFunc(needs, func(r rune) bool { return r == ',' || r == ';' }) {
+		if need = strings.TrimSpace(need); need != "" {
+			parts = append(parts, need)
+		}
+	}
+	return parts
+}
+
+func summarizeHousehold(household *structs.Household) {
+	household.MemberCount = len(household.Members)
+	household.RationSize = 0
+	household.Needs = nil
+
+	seen := make(map[string]bool)
+	for _, member := range household.Members {
+		household.RationSize += rationUnit(member.Age)
+		for _, need := range splitNeeds(member.Needs) {
+			key := strings.ToLower(need)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			household.Needs = append(household.Needs, need)
+		}
+	}
+	household.RationSize = math.Round(household.RationSize*100) / 100
+}
+
+func isHouseholdExists(db *sql.DB, id int) bool {
+	query := `SELECT id FROM households WHERE id = $1`
+	err := db.QueryRow(query, id).Scan(&id)
+	return err == nil
+}
+
+func attachHouseholdMembers(db *sql.DB, households []structs.Household) error {
+	if len(households) == 0 {
+		return nil
+	}
+
+	positions := make(map[int]int)
+	ids := make([]int64, 0, len(households))
+	for i, household := range households {
+		positions[household.ID] = i
+		ids = append(ids, int64(household.ID))
+	}
+
+	rows, err := db.Query(`SELECT id, name, age, condition, needs, shelter_id, disaster_id, household_id, COALESCE(relationship, ''), created_at, updated_at
+	                       FROM refugees WHERE household_id = ANY($1) ORDER BY household_id, id`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var refugee structs.Refugee
+		err := rows.Scan(&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.HouseholdID, &refugee.Relationship, &refugee.CreatedAt, &refugee.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		position := positions[*refugee.HouseholdID]
+		households[position].Members = append(households[position].Members, refugee)
+	}
+
+	for i := range households {
+		summarizeHousehold(&households[i])
+	}
+	return nil
+}
+
+func CreateHousehold(db *sql.DB, household *structs.Household) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if household.HeadRefugeeID != nil {
+		var currentHouseholdID *int
+		err := tx.QueryRow(`SELECT shelter_id, household_id FROM refugees WHERE id = $1 FOR UPDATE`, *household.HeadRefugeeID).
+			Scan(&household.ShelterID, &currentHouseholdID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("refugee not found")
+			}
+			return err
+		}
+		if currentHouseholdID != nil {
+			return errors.New("refugee already in household")
+		}
+	}
+
+	sqlQuery := `INSERT INTO households (disaster_id, name, head_refugee_id, shelter_id, created_at, updated_at)
+	             VALUES ($1, $2, $3, $4, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	err = tx.QueryRow(sqlQuery, household.DisasterID, household.Name, household.HeadRefugeeID, household.ShelterID).
+		Scan(&household.ID, &household.CreatedAt, &household.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if household.HeadRefugeeID != nil {
+		_, err = tx.Exec(`UPDATE refugees SET household_id = $1, relationship = 'head', updated_at = NOW() WHERE id = $2`, household.ID, *household.HeadRefugeeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func GetAllHouseholds(db *sql.DB) ([]structs.Household, error) {
+	query := `SELECT id, disaster_id, name, head_refugee_id, shelter_id, created_at, updated_at FROM households ORDER BY id`
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var households []structs.Household
+	for rows.Next() {
+		var household structs.Household
+		err := rows.Scan(&household.ID, &household.DisasterID, &household.Name, &household.HeadRefugeeID, &household.ShelterID, &household.CreatedAt, &household.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		households = append(households, household)
+	}
+
+	if err := attachHouseholdMembers(db, households); err != nil {
+		return nil, err
+	}
+	return households, nil
+}
+
+func GetHouseholdByID(db *sql.DB, id int) (structs.Household, error) {
+	query := `SELECT id, disaster_id, name, head_refugee_id, shelter_id, created_at, updated_at FROM households WHERE id = $1`
+	var household structs.Household
+	err := db.QueryRow(query, id).Scan(&household.ID, &household.DisasterID, &household.Name, &household.HeadRefugeeID, &household.ShelterID, &household.CreatedAt, &household.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return household, errors.New("household not found")
+		}
+		return household, err
+	}
+
+	households := []structs.Household{household}
+	if err := attachHouseholdMembers(db, households); err != nil {
+		return household, err
+	}
+	return households[0], nil
+}
+
+func UpdateHousehold(db *sql.DB, household structs.Household) error {
+	if !isHouseholdExists(db, household.ID) {
+		return errors.New("household not found")
+	}
+
+	var updateFields []string
+	var values []interface{}
+	counter := 1
+
+	if household.Name != "" {
+		updateFields = append(updateFields, "name = $"+strconv.Itoa(counter))
+		values = append(values, household.Name)
+		counter++
+	}
+	if household.DisasterID != nil {
+		updateFields = append(updateFields, "disaster_id = $"+strconv.Itoa(counter))
+		values = append(values, household.DisasterID)
+		counter++
+	}
+	if household.HeadRefugeeID != nil {
+		var memberHouseholdID *int
+		err := db.QueryRow(`SELECT household_id FROM refugees WHERE id = $1`, *household.HeadRefugeeID).Scan(&memberHouseholdID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("refugee not found")
+			}
+			return err
+		}
+		if memberHouseholdID == nil || *memberHouseholdID != household.ID {
+			return errors.New("head is not a household member")
+		}
+		updateFields = append(updateFields, "head_refugee_id = $"+strconv.Itoa(counter))
+		values = append(values, household.HeadRefugeeID)
+		counter++
+	}
+
+	if len(updateFields) == 0 {
+		return errors.New("tidak ada field yang dapat diperbarui")
+	}
+
+	updateFields = append(updateFields, "updated_at = NOW()")
+	query := "UPDATE households SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
+	values = append(values, household.ID)
+
+	_, err := db.Exec(query, values...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteHousehold(db *sql.DB, id int) error {
+	sqlQuery := `DELETE FROM households WHERE id=$1`
+	result, err := db.Exec(sqlQuery, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("household not found")
+	}
+	return nil
+}
+
+func AddHouseholdMember(db *sql.DB, householdID int, input structs.HouseholdMemberInput, authorizedBy int, allowOverCapacity bool) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var householdShelterID *int
+	err = tx.QueryRow(`SELECT shelter_id FROM households WHERE id = $1 FOR UPDATE`, householdID).Scan(&householdShelterID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("household not found")
+		}
+		return err
+	}
+
+	var refugeeShelterID, currentHouseholdID *int
+	err = tx.QueryRow(`SELECT shelter_id, household_id FROM refugees WHERE id = $1 FOR UPDATE`, input.RefugeeID).Scan(&refugeeShelterID, &currentHouseholdID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("refugee not found")
+		}
+		return err
+	}
+	if currentHouseholdID != nil && *currentHouseholdID != householdID {
+		return errors.New("refugee already in household")
+	}
+
+	_, err = tx.Exec(`UPDATE refugees SET household_id = $1, relationship = $2, updated_at = NOW() WHERE id = $3`, householdID, input.Relationship, input.RefugeeID)
+	if err != nil {
+		return err
+	}
+
+	if householdShelterID != nil && (refugeeShelterID == nil || *refugeeShelterID != *householdShelterID) {
+		_, err = tx.Exec(`UPDATE refugees SET shelter_id = $1 WHERE id = $2`, *householdShelterID, input.RefugeeID)
+		if err != nil {
+			return err
+		}
+
+		shelterIDs := []int{*householdShelterID}
+		if refugeeShelterID != nil {
+			shelterIDs = append(shelterIDs, *refugeeShelterID)
+		}
+		if err := syncShelterOccupancy(tx, shelterIDs, householdShelterID, allowOverCapacity); err != nil {
+			return err
+		}
+		if _, err := recordRefugeeMovement(tx, input.RefugeeID, refugeeShelterID, householdShelterID, "Bergabung dengan keluarga", authorizedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func RemoveHouseholdMember(db *sql.DB, householdID, refugeeID int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE refugees SET household_id = NULL, relationship = NULL, updated_at = NOW() WHERE id = $1 AND household_id = $2`, refugeeID, householdID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("household member not found")
+	}
+
+	_, err = tx.Exec(`UPDATE households SET head_refugee_id = NULL, updated_at = NOW() WHERE id = $1 AND head_refugee_id = $2`, householdID, refugeeID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func AssignHouseholdShelter(db *sql.DB, householdID int, input structs.HouseholdShelterInput, authorizedBy int, allowOverCapacity bool) error {
+	if !isShelterExists(db, input.ShelterID) {
+		return errors.New("shelter not found")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var householdShelterID *int
+	err = tx.QueryRow(`SELECT shelter_id FROM households WHERE id = $1 FOR UPDATE`, householdID).Scan(&householdShelterID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("household not found")
+		}
+		return err
+	}
+
+	rows, err := tx.Query(`SELECT id, shelter_id FROM refugees WHERE household_id = $1 ORDER BY id FOR UPDATE`, householdID)
+	if err != nil {
+		return err
+	}
+
+	previousShelters := make(map[int]*int)
+	var memberIDs []int
+	for rows.Next() {
+		var memberID int
+		var shelterID *int
+		if err := rows.Scan(&memberID, &shelterID); err != nil {
+			rows.Close()
+			return err
+		}
+		memberIDs = append(memberIDs, memberID)
+		previousShelters[memberID] = shelterID
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(memberIDs) == 0 {
+		return errors.New("household has no members")
+	}
+
+	_, err = tx.Exec(`UPDATE refugees SET shelter_id = $1, updated_at = NOW() WHERE household_id = $2`, input.ShelterID, householdID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE households SET shelter_id = $1, updated_at = NOW() WHERE id = $2`, input.ShelterID, householdID)
+	if err != nil {
+		return err
+	}
+
+	shelterIDs := []int{input.ShelterID}
+	for _, shelterID := range previousShelters {
+		if shelterID != nil {
+			shelterIDs = append(shelterIDs, *shelterID)
+		}
+	}
+	if err := syncShelterOccupancy(tx, shelterIDs, &input.ShelterID, allowOverCapacity); err != nil {
+		return err
+	}
+
+	reason := input.Reason
+	if reason == "" {
+		reason = "Penempatan keluarga"
+	}
+	for _, memberID := range memberIDs {
+		previous := previousShelters[memberID]
+		if previous != nil && *previous == input.ShelterID {
+			continue
+		}
+		if _, err := recordRefugeeMovement(tx, memberID, previous, &input.ShelterID, reason, authorizedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+func isItemNameExists(db *sql.DB, name string, excludeID int) bool {
+	query := `SELECT EXISTS(SELECT 1 FROM items WHERE LOWER(name) = LOWER($1) AND id <> $2)`
+	var exists bool
+	err := db.QueryRow(query, name, excludeID).Scan(&exists)
+	if err != nil {
+		return false
+	}
+	return exists
+}
+
+func validateItemUnits(baseUnit string, units []structs.ItemUnit) error {
+	seen := make(map[string]bool)
+	for _, unit := range units {
+		key := strings.ToLower(strings.TrimSpace(unit.Unit))
+		if key == "" || unit.Factor <= 0 || key == strings.ToLower(baseUnit) || seen[key] {
+			return errors.New("invalid item unit")
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func replaceItemUnits(tx *sql.Tx, itemID int, units []structs.ItemUnit) error {
+	_, err := tx.Exec(`DELETE FROM item_units WHERE item_id = $1`, itemID)
+	if err != nil {
+		return err
+	}
+
+	for _, unit := range units {
+		_, err := tx.Exec(`INSERT INTO item_units (item_id, unit, factor) VALUES ($1, $2, $3)`, itemID, strings.TrimSpace(unit.Unit), unit.Factor)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func attachItemUnits(db *sql.DB, items []structs.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(items))
+	indexes := make(map[int]int)
+	for i := range items {
+		items[i].Units = []structs.ItemUnit{}
+		ids = append(ids, int64(items[i].ID))
+		indexes[items[i].ID] = i
+	}
+
+	rows, err := db.Query(`SELECT item_id, unit, factor FROM item_units WHERE item_id = ANY($1) ORDER BY factor, unit`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int
+		var unit structs.ItemUnit
+		if err := rows.Scan(&itemID, &unit.Unit, &unit.Factor); err != nil {
+			return err
+		}
+		items[indexes[itemID]].Units = append(items[indexes[itemID]].Units, unit)
+	}
+	return nil
+}
+
+func CreateItem(db *sql.DB, item *structs.Item) error {
+	if strings.TrimSpace(item.Name) == "" || strings.TrimSpace(item.BaseUnit) == "" {
+		return errors.New("invalid item")
+	}
+	if err := validateItemUnits(item.BaseUnit, item.Units); err != nil {
+		return err
+	}
+	if isItemNameExists(db, item.Name, 0) {
+		return errors.New("item already exists")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`INSERT INTO items (name, category, base_unit, description, created_at, updated_at)
+	                   VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), NOW(), NOW()) RETURNING id, created_at, updated_at`,
+		item.Name, item.Category, item.BaseUnit, item.Description).
+		Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceItemUnits(tx, item.ID, item.Units); err != nil {
+		return err
+	}
+	if item.Units == nil {
+		item.Units = []structs.ItemUnit{}
+	}
+
+	return tx.Commit()
+}
+
+func GetAllItems(db *sql.DB) ([]structs.Item, error) {
+	query := `SELECT id, name, COALESCE(category, ''), base_unit, COALESCE(description, ''), created_at, updated_at FROM items ORDER BY name`
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []structs.Item
+	for rows.Next() {
+		var item structs.Item
+		err := rows.Scan(&item.ID, &item.Name, &item.Category, &item.BaseUnit, &item.Description, &item.CreatedAt, &item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := attachItemUnits(db, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func GetItemByID(db *sql.DB, id int) (structs.Item, error) {
+	query := `SELECT id, name, COALESCE(category, ''), base_unit, COALESCE(description, ''), created_at, updated_at FROM items WHERE id = $1`
+	var item structs.Item
+	err := db.QueryRow(query, id).Scan(&item.ID, &item.Name, &item.Category, &item.BaseUnit, &item.Description, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return item, errors.New("item not found")
+		}
+		return item, err
+	}
+
+	items := []structs.Item{item}
+	if err := attachItemUnits(db, items); err != nil {
+		return item, err
+	}
+	return items[0], nil
+}
+
+func UpdateItem(db *sql.DB, id int, input structs.ItemInput) error {
+	item, err := GetItemByID(db, id)
+	if err != nil {
+		return err
+	}
+
+	baseUnit := item.BaseUnit
+	if input.BaseUnit != "" {
+		baseUnit = input.BaseUnit
+	}
+	// Faktor konversi lama mengacu ke satuan dasar lama sehingga harus diisi ulang
+	baseUnitChanged := !strings.EqualFold(baseUnit, item.BaseUnit)
+	if baseUnitChanged && input.Units == nil {
+		return errors.New("item units required")
+	}
+	if input.Units != nil {
+		if err := validateItemUnits(baseUnit, input.Units); err != nil {
+			return err
+		}
+	}
+	if input.Name != "" && isItemNameExists(db, input.Name, id) {
+		return errors.New("item already exists")
+	}
+
+	var updateFields []string
+	var values []interface{}
+	counter := 1
+
+	if input.Name != "" {
+		updateFields = append(updateFields, "name = $"+strconv.Itoa(counter))
+		values = append(values, input.Name)
+		counter++
+	}
+	if input.Category != "" {
+		updateFields = append(updateFields, "category = $"+strconv.Itoa(counter))
+		values = append(values, input.Category)
+		counter++
+	}
+	if input.BaseUnit != "" {
+		updateFields = append(updateFields, "base_unit = $"+strconv.Itoa(counter))
+		values = append(values, input.BaseUnit)
+		counter++
+	}
+	if input.Description != "" {
+		updateFields = append(updateFields, "description = $"+strconv.Itoa(counter))
+		values = append(values, input.Description)
+		counter++
+	}
+
+	if len(updateFields) == 0 && input.Units == nil {
+		return errors.New("tidak ada field yang dapat diperbarui")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Kuantitas stok, permintaan, dan donasi tersimpan dalam satuan dasar lama, jadi satuan dasar hanya bisa diganti sebelum barang dipakai
+	if baseUnitChanged {
+		var inUse bool
+		err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM logistics WHERE item_id = $1)
+		                       OR EXISTS(SELECT 1 FROM need_requests WHERE item_id = $1)
+		                       OR EXISTS(SELECT 1 FROM donations WHERE item_id = $1)`, id).Scan(&inUse)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return errors.New("item in use")
+		}
+	}
+
+	updateFields = append(updateFields, "updated_at = NOW()")
+	query := "UPDATE items SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
+	values = append(values, id)
+	if _, err := tx.Exec(query, values...); err != nil {
+		return err
+	}
+
+	// Satuan stok logistik mengikuti satuan dasar barang
+	if input.BaseUnit != "" {
+		if _, err := tx.Exec(`UPDATE logistics SET unit = $1, updated_at = NOW() WHERE item_id = $2`, input.BaseUnit, id); err != nil {
+			return err
+		}
+	}
+
+	if input.Units != nil {
+		if err := replaceItemUnits(tx, id, input.Units); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func DeleteItem(db *sql.DB, id int) error {
+	result, err := db.Exec(`DELETE FROM items WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("item not found")
+	}
+	return nil
+}
+
+func convertToBaseUnit(tx *sql.Tx, itemID *int, baseUnit, unit string, quantity int) (int, error) {
+	if unit == "" || strings.EqualFold(unit, baseUnit) {
+		return quantity, nil
+	}
+	if itemID == nil {
+		return 0, errors.New("unknown unit")
+	}
+
+	var factor float64
+	err := tx.QueryRow(`SELECT factor FROM item_units WHERE item_id = $1 AND LOWER(unit) = LOWER($2)`, *itemID, unit).Scan(&factor)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("unknown unit")
+		}
+		return 0, err
+	}
+
+	converted := float64(quantity) * factor
+	if math.Abs(converted-math.Round(converted)) > 1e-6 {
+		return 0, errors.New("invalid unit conversion")
+	}
+	return int(math.Round(converted)), nil
+}
+
+func convertLogisticQuantity(tx *sql.Tx, logisticID int, unit string, quantity int) (int, error) {
+	var itemID *int
+	var baseUnit string
+	err := tx.QueryRow(`SELECT item_id, COALESCE(unit, '') FROM logistics WHERE id = $1`, logisticID).Scan(&itemID, &baseUnit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("logistic not found")
+		}
+		return 0, err
+	}
+	return convertToBaseUnit(tx, itemID, baseUnit, unit, quantity)
+}
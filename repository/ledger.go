@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ledgerGenesisHash = strings.Repeat("0", 64)
+
+// Isi yang di-hash memakai urutan field tetap agar bisa dihitung ulang oleh pihak luar
+type ledgerHashInput struct {
+	Sequence     int64  `json:"sequence"`
+	EntryType    string `json:"entry_type"`
+	ReferenceID  int    `json:"reference_id"`
+	DisasterID   *int   `json:"disaster_id"`
+	Amount       string `json:"amount"`
+	Currency     string `json:"currency"`
+	Unit         string `json:"unit"`
+	Description  string `json:"description"`
+	RecordedAt   string `json:"recorded_at"`
+	PreviousHash string `json:"previous_hash"`
+}
+
+func ledgerEntryHash(entry structs.LedgerEntry) string {
+	payload, _ := json.Marshal(ledgerHashInput{
+		Sequence:     entry.Sequence,
+		EntryType:    entry.EntryType,
+		ReferenceID:  entry.ReferenceID,
+		DisasterID:   entry.DisasterID,
+		Amount:       strconv.FormatFloat(entry.Amount, 'f', 2, 64),
+		Currency:     entry.Currency,
+		Unit:         entry.Unit,
+		Description:  entry.Description,
+		RecordedAt:   entry.RecordedAt.UTC().Format(time.RFC3339Nano),
+		PreviousHash: entry.PreviousHash,
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func appendLedgerEntry(tx *sql.Tx, entry *structs.LedgerEntry) error {
+	// Tabel dikunci agar dua transaksi tidak menyambung ke entri terakhir yang sama
+	if _, err := tx.Exec(`LOCK TABLE ledger_entries IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+
+	var exists bool
+	err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM ledger_entries WHERE entry_type = $1 AND reference_id = $2)`, entry.EntryType, entry.ReferenceID).Scan(&exists)
+	if err != nil || exists {
+		return err
+	}
+
+	entry.Sequence = 0
+	entry.PreviousHash = ledgerGenesisHash
+	err = tx.QueryRow(`SELECT sequence, hash FROM ledger_entries ORDER BY sequence DESC LIMIT 1`).Scan(&entry.Sequence, &entry.PreviousHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	entry.Sequence++
+	entry.RecordedAt = time.Now().UTC().Truncate(time.Microsecond)
+	entry.Hash = ledgerEntryHash(*entry)
+
+	_, err = tx.Exec(`INSERT INTO ledger_entries (sequence, entry_type, reference_id, disaster_id, amount, currency, unit, description, recorded_at, previous_hash, hash)
+	                  VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9, $10, $11)`,
+		entry.Sequence, entry.EntryType, entry.ReferenceID, entry.DisasterID, entry.Amount, entry.Currency, entry.Unit, entry.Description,
+		entry.RecordedAt, entry.PreviousHash, entry.Hash)
+	return err
+}
+
+// Donasi yang dikonfirmasi dicatat tanpa data donatur
+func appendDonationLedger(tx *sql.Tx, donationID int) error {
+	var donation structs.Donation
+	err := tx.QueryRow(`SELECT disaster_id, amount, COALESCE(currency, 'IDR'), COALESCE(unit, ''), COALESCE(item_name, ''), item_id FROM donations WHERE id = $1`, donationID).
+		Scan(&donation.DisasterID, &donation.Amount, &donation.Currency, &donation.Unit, &donation.ItemName, &donation.ItemID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("donation not found")
+		}
+		return err
+	}
+
+	entry := structs.LedgerEntry{EntryType: "donation", ReferenceID: donationID, DisasterID: donation.DisasterID, Amount: donation.Amount}
+	if IsInKindDonation(donation) {
+		entry.Unit = donation.Unit
+		entry.Description = "Donasi barang: " + donation.ItemName
+	} else {
+		entry.Currency = donation.Currency
+		entry.Description = "Donasi uang"
+	}
+	return appendLedgerEntry(tx, &entry)
+}
+
+// BootstrapLedger mencatat donasi terkonfirmasi yang sudah ada sebelum buku besar dibuat, hanya saat buku besar masih kosong
+func BootstrapLedger(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM ledger_entries`).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	rows, err := tx.Query(`SELECT id FROM donations WHERE status = 'confirmed' ORDER BY updated_at, id`)
+	if err != nil {
+		return err
+	}
+	var donationIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		donationIDs = append(donationIDs, id)
+	}
+	rows.Close()
+
+	for _, id := range donationIDs {
+		if err := appendDonationLedger(tx, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func CreateDisbursement(db *sql.DB, disbursement *structs.Disbursement) error {
+	disbursement.Currency = NormalizeCurrency(disbursement.Currency)
+	if disbursement.Currency == "" {
+		disbursement.Currency = ReportingCurrency()
+	}
+	if !IsValidCurrency(disbursement.Currency) {
+		return errors.New("invalid currency")
+	}
+	if disbursement.DisasterID != nil && !isDisasterExists(db, *disbursement.DisasterID) {
+		return errors.New("disaster not found")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`INSERT INTO disbursements (disaster_id, amount, currency, recipient, purpose, disbursed_by, disbursed_at, created_at)
+	                   VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, amount, disbursed_at, created_at`,
+		disbursement.DisasterID, disbursement.Amount, disbursement.Currency, disbursement.Recipient, disbursement.Purpose, disbursement.DisbursedBy).
+		Scan(&disbursement.ID, &disbursement.Amount, &disbursement.DisbursedAt, &disbursement.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Penerima tidak ikut dicatat di buku besar publik, hanya tujuan pengeluaran
+	err = appendLedgerEntry(tx, &structs.LedgerEntry{
+		EntryType:   "disbursement",
+		ReferenceID: disbursement.ID,
+		DisasterID:  disbursement.DisasterID,
+		Amount:      disbursement.Amount,
+		Currency:    disbursement.Currency,
+		Description: disbursement.Purpose,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func GetAllDisbursements(db *sql.DB) ([]structs.Disbursement, error) {
+	rows, err := db.Query(`SELECT id, disaster_id, amount, currency, recipient, purpose, disbursed_by, disbursed_at, created_at
+	                       FROM disbursements ORDER BY disbursed_at DESC, id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disbursements := []structs.Disbursement{}
+	for rows.Next() {
+		var disbursement structs.Disbursement
+		err := rows.Scan(&disbursement.ID, &disbursement.DisasterID, &disbursement.Amount, &disbursement.Currency, &disbursement.Recipient,
+			&disbursement.Purpose, &disbursement.DisbursedBy, &disbursement.DisbursedAt, &disbursement.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		disbursements = append(disbursements, disbursement)
+	}
+	return disbursements, nil
+}
+
+func GetLedgerEntries(db *sql.DB, disasterID *int) ([]structs.LedgerEntry, error) {
+	rows, err := db.Query(`SELECT l.sequence, l.entry_type, l.reference_id, l.disaster_id, COALESCE(ds.type || ' - ' || ds.location, ''), l.amount,
+	                              COALESCE(l.currency, ''), COALESCE(l.unit, ''), COALESCE(l.description, ''), l.recorded_at, l.previous_hash, l.hash
+	                       FROM ledger_entries l
+	                       LEFT JOIN disasters ds ON ds.id = l.disaster_id
+	                       WHERE $1::INT IS NULL OR l.disaster_id = $1
+	                       ORDER BY l.sequence`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []structs.LedgerEntry{}
+	for rows.Next() {
+		var entry structs.LedgerEntry
+		err := rows.Scan(&entry.Sequence, &entry.EntryType, &entry.ReferenceID, &entry.DisasterID, &entry.DisasterName, &entry.Amount,
+			&entry.Currency, &entry.Unit, &entry.Description, &entry.RecordedAt, &entry.PreviousHash, &entry.Hash)
+		if err != nil {
+			return nil, err
+		}
+		entry.RecordedAt = entry.RecordedAt.UTC()
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func GetLedgerHead(db *sql.DB) (int64, string, error) {
+	sequence, hash := int64(0), ledgerGenesisHash
+	err := db.QueryRow(`SELECT sequence, hash FROM ledger_entries ORDER BY sequence DESC LIMIT 1`).Scan(&sequence, &hash)
+	if err != nil && err != sql.ErrNoRows {
+		return sequence, hash, err
+	}
+	return sequence, hash, nil
+}
+
+// VerifyLedger menghitung ulang rantai hash dan mencocokkan buku besar dengan data donasi dan pengeluaran
+func VerifyLedger(db *sql.DB) (structs.LedgerVerification, error) {
+	result := structs.LedgerVerification{HeadHash: ledgerGenesisHash, Issues: []structs.LedgerIssue{}, VerifiedAt: time.Now()}
+
+	rows, err := db.Query(`SELECT l.sequence, l.entry_type, l.reference_id, l.disaster_id, l.amount, COALESCE(l.currency, ''), COALESCE(l.unit, ''),
+	                              COALESCE(l.description, ''), l.recorded_at, l.previous_hash, l.hash,
+	                              CASE l.entry_type
+	                                  WHEN 'donation' THEN (SELECT d.amount FROM donations d WHERE d.id = l.reference_id AND d.status = 'confirmed')
+	                                  WHEN 'disbursement' THEN (SELECT b.amount FROM disbursements b WHERE b.id = l.reference_id)
+	                              END
+	                       FROM ledger_entries l ORDER BY l.sequence`)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	previousHash := ledgerGenesisHash
+	expectedSequence := int64(1)
+	for rows.Next() {
+		var entry structs.LedgerEntry
+		var sourceAmount *float64
+		err := rows.Scan(&entry.Sequence, &entry.EntryType, &entry.ReferenceID, &entry.DisasterID, &entry.Amount, &entry.Currency, &entry.Unit,
+			&entry.Description, &entry.RecordedAt, &entry.PreviousHash, &entry.Hash, &sourceAmount)
+		if err != nil {
+			return result, err
+		}
+
+		issue := structs.LedgerIssue{Sequence: entry.Sequence, EntryType: entry.EntryType, ReferenceID: entry.ReferenceID}
+		if entry.Sequence != expectedSequence {
+			issue.Problem = "sequence_gap"
+			result.Issues = append(result.Issues, issue)
+		}
+		if entry.PreviousHash != previousHash {
+			issue.Problem = "broken_link"
+			result.Issues = append(result.Issues, issue)
+		}
+		if ledgerEntryHash(entry) != entry.Hash {
+			issue.Problem = "altered"
+			result.Issues = append(result.Issues, issue)
+		}
+		if sourceAmount == nil || *sourceAmount != entry.Amount {
+			issue.Problem = "source_mismatch"
+			result.Issues = append(result.Issues, issue)
+		}
+
+		previousHash = entry.Hash
+		expectedSequence = entry.Sequence + 1
+		result.Entries++
+	}
+	result.HeadHash = previousHash
+
+	// Donasi terkonfirmasi atau pengeluaran tanpa entri berarti entrinya terhapus
+	missing, err := db.Query(`SELECT 'donation', d.id FROM donations d
+	                          WHERE d.status = 'confirmed' AND NOT EXISTS (SELECT 1 FROM ledger_entries l WHERE l.entry_type = 'donation' AND l.reference_id = d.id)
+	                          UNION ALL
+	                          SELECT 'disbursement', b.id FROM disbursements b
+	                          WHERE NOT EXISTS (SELECT 1 FROM ledger_entries l WHERE l.entry_type = 'disbursement' AND l.reference_id = b.id)
+	                          ORDER BY 1, 2`)
+	if err != nil {
+		return result, err
+	}
+	defer missing.Close()
+
+	for missing.Next() {
+		issue := structs.LedgerIssue{Problem: "missing_entry"}
+		if err := missing.Scan(&issue.EntryType, &issue.ReferenceID); err != nil {
+			return result, err
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+
+	result.Valid = len(result.Issues) == 0
+	return result, nil
+}
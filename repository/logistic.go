@@ -8,35 +8,68 @@ import (
 	"strings"
 )
 
-func isValidLogisticsStatus(status string) bool {
-	validStatuses := []string{"available", "distributed", "out_of_stock"}
-	for _, valid := range validStatuses {
-			if status == valid {
-					return true
+func CreateLogistic(db *sql.DB, logistics *structs.Logistic, batch structs.StockBatchInput, recordedBy int) error {
+	if logistics.Quantity < 0 {
+			return errors.New("invalid stock quantity")
+	}
+	if err := validateLocation(logistics.Latitude, logistics.Longitude, nil); err != nil {
+			return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+			return err
+	}
+	defer tx.Rollback()
+
+	// Kuantitas disimpan dalam satuan dasar barang dari katalog
+	if logistics.ItemID != nil {
+			var itemName, baseUnit string
+			err = tx.QueryRow(`SELECT name, base_unit FROM items WHERE id = $1`, *logistics.ItemID).Scan(&itemName, &baseUnit)
+			if err != nil {
+					if err == sql.ErrNoRows {
+							return errors.New("item not found")
+					}
+					return err
+			}
+
+			logistics.Quantity, err = convertToBaseUnit(tx, logistics.ItemID, baseUnit, logistics.Unit, logistics.Quantity)
+			if err != nil {
+					return err
+			}
+			logistics.Unit = baseUnit
+			if logistics.Type == "" {
+					logistics.Type = itemName
 			}
 	}
-	return false
-}
 
-func CreateLogistic(db *sql.DB, logistics *structs.Logistic) error {
-	if !isValidLogisticsStatus(logistics.Status) {
-			return errors.New("invalid logistics status")
+	sqlQuery := `INSERT INTO logistics (type, quantity, status, disaster_id, item_id, unit, location, latitude, longitude, created_at, updated_at)
+							VALUES ($1, 0, 'out_of_stock', $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, NOW(), NOW()) RETURNING id, created_at`
+	err = tx.QueryRow(sqlQuery, logistics.Type, logistics.DisasterID, logistics.ItemID, logistics.Unit, logistics.Location, logistics.Latitude, logistics.Longitude).
+			Scan(&logistics.ID, &logistics.CreatedAt)
+
+	if err != nil {
+			return err
 	}
 
-	sqlQuery := `INSERT INTO logistics (type, quantity, status, disaster_id, created_at, updated_at)
-							VALUES ($1, $2, $3, $4, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, logistics.Type, logistics.Quantity, logistics.Status, logistics.DisasterID).
-			Scan(&logistics.ID, &logistics.CreatedAt, &logistics.UpdatedAt)
+	if logistics.Quantity > 0 {
+			_, err = receiveStock(tx, logistics.ID, "receipt", logistics.Quantity, batch, nil, "Stok awal", &recordedBy)
+			if err != nil {
+					return err
+			}
+	}
 
+	err = tx.QueryRow(`SELECT quantity, status, updated_at FROM logistics WHERE id = $1`, logistics.ID).
+			Scan(&logistics.Quantity, &logistics.Status, &logistics.UpdatedAt)
 	if err != nil {
 			return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func GetAllLogistics(db *sql.DB) ([]structs.Logistic, error) {
-	query := `SELECT id, type, quantity, status, disaster_id, created_at, updated_at FROM logistics`
+	query := `SELECT id, type, quantity, status, disaster_id, item_id, COALESCE(unit, ''), COALESCE(location, ''), latitude, longitude, created_at, updated_at FROM logistics`
 	rows, err := db.Query(query)
 
 	if err != nil {
@@ -47,7 +80,7 @@ func GetAllLogistics(db *sql.DB) ([]structs.Logistic, error) {
 	var logistics []structs.Logistic
 	for rows.Next() {
 		var logistic structs.Logistic
-		err := rows.Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.CreatedAt, &logistic.UpdatedAt)
+		err := rows.Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.ItemID, &logistic.Unit, &logistic.Location, &logistic.Latitude, &logistic.Longitude, &logistic.CreatedAt, &logistic.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -57,9 +90,9 @@ func GetAllLogistics(db *sql.DB) ([]structs.Logistic, error) {
 }
 
 func GetLogisticByID(db *sql.DB, id int) (structs.Logistic, error) {
-	query := `SELECT id, type, quantity, status, disaster_id, created_at, updated_at FROM logistics WHERE id = $1`
+	query := `SELECT id, type, quantity, status, disaster_id, item_id, COALESCE(unit, ''), COALESCE(location, ''), latitude, longitude, created_at, updated_at FROM logistics WHERE id = $1`
 	var logistic structs.Logistic
-	err := db.QueryRow(query, id).Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.CreatedAt, &logistic.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.ItemID, &logistic.Unit, &logistic.Location, &logistic.Latitude, &logistic.Longitude, &logistic.CreatedAt, &logistic.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -80,13 +113,20 @@ func isLogisticExists(db *sql.DB, id int) bool {
 	return exists
 }
 
-func UpdateLogistic(db *sql.DB, logistics structs.Logistic) error {
+func UpdateLogistic(db *sql.DB, logistics structs.Logistic, recordedBy int) error {
 	if !isLogisticExists(db, logistics.ID) {
 		return errors.New("logistics not found")
 	}
 
-	if logistics.Status != "" && !isValidLogisticsStatus(logistics.Status) {
-		return errors.New("invalid logistics status")
+	// Status logistik dihitung dari saldo buku stok sehingga tidak bisa diisi langsung
+	if logistics.Status != "" {
+		return errors.New("logistics status derived")
+	}
+	if logistics.Quantity < 0 {
+		return errors.New("invalid stock quantity")
+	}
+	if err := validateLocation(logistics.Latitude, logistics.Longitude, nil); err != nil {
+		return err
 	}
 
 	var updateFields []string
@@ -98,41 +138,116 @@ func UpdateLogistic(db *sql.DB, logistics structs.Logistic) error {
 		values = append(values, logistics.Type)
 		counter++
 	}
-	if logistics.Quantity != 0 {
-		updateFields = append(updateFields, "quantity = $"+strconv.Itoa(counter))
-		values = append(values, logistics.Quantity)
+	if logistics.DisasterID != nil {
+		updateFields = append(updateFields, "disaster_id = $"+strconv.Itoa(counter))
+		values = append(values, logistics.DisasterID)
 		counter++
 	}
-	if logistics.Status != "" {
-		updateFields = append(updateFields, "status = $"+strconv.Itoa(counter))
-		values = append(values, logistics.Status)
+	if logistics.ItemID != nil {
+		item, err := GetItemByID(db, *logistics.ItemID)
+		if err != nil {
+			return err
+		}
+		updateFields = append(updateFields, "item_id = $"+strconv.Itoa(counter), "unit = $"+strconv.Itoa(counter+1))
+		values = append(values, item.ID, item.BaseUnit)
+		counter += 2
+	} else if logistics.Unit != "" {
+		updateFields = append(updateFields, "unit = $"+strconv.Itoa(counter))
+		values = append(values, logistics.Unit)
 		counter++
 	}
-	if logistics.DisasterID != nil {
-		updateFields = append(updateFields, "disaster_id = $"+strconv.Itoa(counter))
-		values = append(values, logistics.DisasterID)
+	if logistics.Location != "" {
+		updateFields = append(updateFields, "location = $"+strconv.Itoa(counter))
+		values = append(values, logistics.Location)
 		counter++
 	}
+	if logistics.Latitude != nil && logistics.Longitude != nil {
+		updateFields = append(updateFields, "latitude = $"+strconv.Itoa(counter), "longitude = $"+strconv.Itoa(counter+1))
+		values = append(values, logistics.Latitude, logistics.Longitude)
+		counter += 2
+	}
 
-	if len(updateFields) == 0 {
+	if len(updateFields) == 0 && logistics.Quantity == 0 {
 		return errors.New("tidak ada field yang dapat diperbarui")
 	}
 
-	updateFields = append(updateFields, "updated_at = NOW()")
-	query := "UPDATE logistics SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
-	values = append(values, logistics.ID)
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	_, err := db.Exec(query, values...)
+	// Saldo dan riwayat stok tersimpan dalam satuan lama, jadi barang dan satuan hanya bisa diganti sebelum ada pergerakan stok
+	var currentItemID *int
+	var currentUnit string
+	err = tx.QueryRow(`SELECT item_id, COALESCE(unit, '') FROM logistics WHERE id = $1 FOR UPDATE`, logistics.ID).Scan(&currentItemID, &currentUnit)
 	if err != nil {
 		return err
 	}
-	return nil
+	itemChanged := logistics.ItemID != nil && (currentItemID == nil || *currentItemID != *logistics.ItemID)
+	unitChanged := logistics.ItemID == nil && logistics.Unit != "" && !strings.EqualFold(logistics.Unit, currentUnit)
+	if itemChanged || unitChanged {
+		var hasMovements bool
+		err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM stock_movements WHERE logistic_id = $1)`, logistics.ID).Scan(&hasMovements)
+		if err != nil {
+			return err
+		}
+		if hasMovements {
+			return errors.New("logistics unit locked")
+		}
+	}
+
+	if len(updateFields) > 0 {
+		updateFields = append(updateFields, "updated_at = NOW()")
+		query := "UPDATE logistics SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
+		values = append(values, logistics.ID)
+
+		_, err = tx.Exec(query, values...)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Kuantitas tidak ditimpa langsung, selisihnya dicatat sebagai penyesuaian stok
+	if logistics.Quantity != 0 {
+		if err := lockLogistics(tx, []int{logistics.ID}); err != nil {
+			return err
+		}
+		balance, err := getStockBalance(tx, logistics.ID)
+		if err != nil {
+			return err
+		}
+		note := "Penyesuaian dari pembaruan data logistik"
+		if logistics.Quantity > balance {
+			_, err = receiveStock(tx, logistics.ID, "adjustment", logistics.Quantity-balance, structs.StockBatchInput{}, nil, note, &recordedBy)
+		} else if logistics.Quantity < balance {
+			_, err = consumeStock(tx, logistics.ID, "adjustment", balance-logistics.Quantity, nil, nil, note, &recordedBy)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 
 func DeleteLogistic(db *sql.DB, id int) error {
+	// Buku stok bersifat append-only, logistik yang sudah memiliki riwayat stok tidak boleh dihapus
+	var hasMovements bool
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM stock_movements WHERE logistic_id = l.id) FROM logistics l WHERE l.id = $1`, id).Scan(&hasMovements)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("logistic not found")
+		}
+		return err
+	}
+	if hasMovements {
+		return errors.New("logistic has stock movements")
+	}
+
 	sqlQuery := `DELETE FROM logistics WHERE id=$1`
-	_, err := db.Exec(sqlQuery, id)
+	_, err = db.Exec(sqlQuery, id)
 	if err != nil {
 		return err
 	}
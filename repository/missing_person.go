@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	missingPersonAgeTolerance = 3
+	minimumNameSimilarity     = 0.75
+	minimumMatchScore         = 0.7
+)
+
+func isValidMissingPersonStatus(status string) bool {
+	validStatuses := []string{"missing", "found", "closed"}
+	for _, valid := range validStatuses {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidMatchStatus(status string) bool {
+	validStatuses := []string{"pending", "confirmed", "rejected"}
+	for _, valid := range validStatuses {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAgeRange(ageMin, ageMax *int) error {
+	if (ageMin != nil && *ageMin < 0) || (ageMax != nil && *ageMax < 0) {
+		return errors.New("invalid age range")
+	}
+	if ageMin != nil && ageMax != nil && *ageMin > *ageMax {
+		return errors.New("invalid age range")
+	}
+	return nil
+}
+
+func scoreMissingPersonMatch(person structs.MissingPerson, refugee structs.Refugee) (float64, bool) {
+	nameScore := nameSimilarity(person.Name, refugee.Name)
+	if nameScore < minimumNameSimilarity {
+		return 0, false
+	}
+
+	ageScore := 0.5
+	if person.AgeMin != nil || person.AgeMax != nil {
+		ageMin, ageMax := person.AgeMin, person.AgeMax
+		if ageMin == nil {
+			ageMin = ageMax
+		}
+		if ageMax == nil {
+			ageMax = ageMin
+		}
+
+		difference := 0
+		if refugee.Age < *ageMin {
+			difference = *ageMin - refugee.Age
+		} else if refugee.Age > *ageMax {
+			difference = refugee.Age - *ageMax
+		}
+		if difference > missingPersonAgeTolerance {
+			return 0, false
+		}
+		ageScore = 1 - float64(difference)/float64(missingPersonAgeTolerance+1)
+	}
+
+	disasterScore := 0.5
+	if person.DisasterID != nil && refugee.DisasterID != nil {
+		if *person.DisasterID != *refugee.DisasterID {
+			return 0, false
+		}
+		disasterScore = 1
+	}
+
+	score := math.Round((nameScore*0.7+ageScore*0.2+disasterScore*0.1)*1000) / 1000
+	return score, score >= minimumMatchScore
+}
+
+func saveMissingPersonMatch(db *sql.DB, missingPersonID, refugeeID int, score float64) error {
+	sqlQuery := `INSERT INTO missing_person_matches (missing_person_id, refugee_id, score, status, created_at, updated_at)
+	             VALUES ($1, $2, $3, 'pending', NOW(), NOW())
+	             ON CONFLICT (missing_person_id, refugee_id) DO UPDATE SET score = EXCLUDED.score, updated_at = NOW()`
+	_, err := db.Exec(sqlQuery, missingPersonID, refugeeID, score)
+	return err
+}
+
+func CreateMissingPerson(db *sql.DB, person *structs.MissingPerson) error {
+	if person.Status == "" {
+		person.Status = "missing"
+	}
+	if !isValidMissingPersonStatus(person.Status) {
+		return errors.New("invalid missing person status")
+	}
+	if err := validateAgeRange(person.AgeMin, person.AgeMax); err != nil {
+		return err
+	}
+
+	sqlQuery := `INSERT INTO missing_persons (disaster_id, reported_by, name, age_min, age_max, last_seen_location, last_seen_at, contact_name, contact_phone, contact_email, description, status, created_at, updated_at)
+	             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	err := db.QueryRow(sqlQuery, person.DisasterID, person.ReportedBy, person.Name, person.AgeMin, person.AgeMax, person.LastSeenLocation, person.LastSeenAt,
+		person.ContactName, person.ContactPhone, person.ContactEmail, person.Description, person.Status).
+		Scan(&person.ID, &person.CreatedAt, &person.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func GetAllMissingPersons(db *sql.DB, status string) ([]structs.MissingPerson, error) {
+	query := `SELECT id, disaster_id, reported_by, name, age_min, age_max, COALESCE(last_seen_location, ''), last_seen_at, COALESCE(contact_name, ''),
+	                 COALESCE(contact_phone, ''), COALESCE(contact_email, ''), COALESCE(description, ''), status, found_refugee_id, created_at, updated_at
+	          FROM missing_persons WHERE ($1 = '' OR status::TEXT = $1) ORDER BY created_at DESC`
+	rows, err := db.Query(query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var persons []structs.MissingPerson
+	for rows.Next() {
+		var person structs.MissingPerson
+		err := rows.Scan(&person.ID, &person.DisasterID, &person.ReportedBy, &person.Name, &person.AgeMin, &person.AgeMax, &person.LastSeenLocation, &person.LastSeenAt,
+			&person.ContactName, &person.ContactPhone, &person.ContactEmail, &person.Description, &person.Status, &person.FoundRefugeeID, &person.CreatedAt, &person.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		persons = append(persons, person)
+	}
+	return persons, nil
+}
+
+func GetMissingPersonByID(db *sql.DB, id int) (structs.MissingPerson, error) {
+	query := `SELECT id, disaster_id, reported_by, name, age_min, age_max, COALESCE(last_seen_location, ''), last_seen_at, COALESCE(contact_name, ''),
+	                 COALESCE(contact_phone, ''), COALESCE(contact_email, ''), COALESCE(description, ''), status, found_refugee_id, created_at, updated_at
+	          FROM missing_persons WHERE id = $1`
+	var person structs.MissingPerson
+	err := db.QueryRow(query, id).Scan(&person.ID, &person.DisasterID, &person.ReportedBy, &person.Name, &person.AgeMin, &person.AgeMax, &person.LastSeenLocation, &person.LastSeenAt,
+		&person.ContactName, &person.ContactPhone, &person.ContactEmail, &person.Description, &person.Status, &person.FoundRefugeeID, &person.CreatedAt, &person.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return person, errors.New("missing person not found")
+		}
+		return person, err
+	}
+	return person, nil
+}
+
+func UpdateMissingPerson(db *sql.DB, person structs.MissingPerson) error {
+	current, err := GetMissingPersonByID(db, person.ID)
+	if err != nil {
+		return err
+	}
+
+	if person.Status != "" && !isValidMissingPersonStatus(person.Status) {
+		return errors.New("invalid missing person status")
+	}
+
+	ageMin, ageMax := current.AgeMin, current.AgeMax
+	if person.AgeMin != nil {
+		ageMin = person.AgeMin
+	}
+	if person.AgeMax != nil {
+		ageMax = person.AgeMax
+	}
+	if err := validateAgeRange(ageMin, ageMax); err != nil {
+		return err
+	}
+
+	var updateFields []string
+	var values []interface{}
+	counter := 1
+
+	if person.Name != "" {
+		updateFields = append(updateFields, "name = $"+strconv.Itoa(counter))
+		values = append(values, person.Name)
+		counter++
+	}
+	if person.DisasterID != nil {
+		updateFields = append(updateFields, "disaster_id = $"+strconv.Itoa(counter))
+		values = append(values, person.DisasterID)
+		counter++
+	}
+	if person.AgeMin != nil {
+		updateFields = append(updateFields, "age_min = $"+strconv.Itoa(counter))
+		values = append(values, person.AgeMin)
+		counter++
+	}
+	if person.AgeMax != nil {
+		updateFields = append(updateFields, "age_max = $"+strconv.Itoa(counter))
+		values = append(values, person.AgeMax)
+		counter++
+	}
+	if person.LastSeenLocation != "" {
+		updateFields = append(updateFields, "last_seen_location = $"+strconv.Itoa(counter))
+		values = append(values, person.LastSeenLocation)
+		counter++
+	}
+	if person.LastSeenAt != nil {
+		updateFields = append(updateFields, "last_seen_at = $"+strconv.Itoa(counter))
+		values = append(values, person.LastSeenAt)
+		counter++
+	}
+	if person.ContactName != "" {
+		updateFields = append(updateFields, "contact_name = $"+strconv.Itoa(counter))
+		values = append(values, person.ContactName)
+		counter++
+	}
+	if person.ContactPhone != "" {
+		updateFields = append(updateFields, "contact_phone = $"+strconv.Itoa(counter))
+		values = append(values, person.ContactPhone)
+		counter++
+	}
+	if person.ContactEmail != "" {
+		updateFields = append(updateFields, "contact_email = $"+strconv.Itoa(counter))
+		values = append(values, person.ContactEmail)
+		counter++
+	}
+	if person.Description != "" {
+		updateFields = append(updateFields, "description = $"+strconv.Itoa(counter))
+		values = append(values, person.Description)
+		counter++
+	}
+	if person.Status != "" {
+		updateFields = append(updateFields, "status = $"+strconv.Itoa(counter))
+		values = append(values, person.Status)
+		counter++
+	}
+
+	if len(updateFields) == 0 {
+		return errors.New("tidak ada field yang dapat diperbarui")
+	}
+
+	updateFields = append(updateFields, "updated_at = NOW()")
+	query := "UPDATE missing_persons SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
+	values = append(values, person.ID)
+
+	_, err = db.Exec(query, values...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteMissingPerson(db *sql.DB, id int) error {
+	sqlQuery := `DELETE FROM missing_persons WHERE id=$1`
+	_, err := db.Exec(sqlQuery, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func MatchMissingPerson(db *sql.DB, id int) ([]structs.MissingPersonMatch, error) {
+	person, err := GetMissingPersonByID(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if person.Status != "missing" {
+		return nil, errors.New("missing person already resolved")
+	}
+
+	query := `SELECT id, name, age, condition, needs, shelter_id, disaster_id, household_id, COALESCE(relationship, ''), created_at, updated_at
+	          FROM refugees WHERE $1::INT IS NULL OR disaster_id IS NULL OR disaster_id = $1`
+	rows, err := db.Query(query, person.DisasterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var refugees []structs.Refugee
+	for rows.Next() {
+		var refugee structs.Refugee
+		err := rows.Scan(&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.HouseholdID, &refugee.Relationship, &refugee.CreatedAt, &refugee.UpdatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		refugees = append(refugees, refugee)
+	}
+	rows.Close()
+
+	for _, refugee := range refugees {
+		if score, matched := scoreMissingPersonMatch(person, refugee); matched {
+			if err := saveMissingPersonMatch(db, person.ID, refugee.ID, score); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return GetMissingPersonMatches(db, person.ID)
+}
+
+func MatchRefugeeWithMissingPersons(db *sql.DB, refugee structs.Refugee) (int, error) {
+	persons, err := GetAllMissingPersons(db, "missing")
+	if err != nil {
+		return 0, err
+	}
+
+	matches := 0
+	for _, person := range persons {
+		if score, matched := scoreMissingPersonMatch(person, refugee); matched {
+			if err := saveMissingPersonMatch(db, person.ID, refugee.ID, score); err != nil {
+				return matches, err
+			}
+			matches++
+		}
+	}
+	return matches, nil
+}
+
+func GetMissingPersonMatches(db *sql.DB, missingPersonID int) ([]structs.MissingPersonMatch, error) {
+	query := `SELECT m.id, m.missing_person_id, m.refugee_id, m.score, m.status, m.reviewed_by, m.reviewed_at, m.created_at, m.updated_at,
+	                 r.id, r.name, r.age, r.condition, r.needs, r.shelter_id, r.disaster_id, r.household_id, COALESCE(r.relationship, ''), r.created_at, r.updated_at
+	          FROM missing_person_matches m JOIN refugees r ON r.id = m.refugee_id
+	          WHERE m.missing_person_id = $1 ORDER BY m.score DESC, m.id`
+	rows, err := db.Query(query, missingPersonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := []structs.MissingPersonMatch{}
+	for rows.Next() {
+		var match structs.MissingPersonMatch
+		var refugee structs.Refugee
+		err := rows.Scan(&match.ID, &match.MissingPersonID, &match.RefugeeID, &match.Score, &match.Status, &match.ReviewedBy, &match.ReviewedAt, &match.CreatedAt, &match.UpdatedAt,
+			&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.HouseholdID, &refugee.Relationship, &refugee.CreatedAt, &refugee.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		match.Refugee = &refugee
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+func ReviewMissingPersonMatch(db *sql.DB, missingPersonID, matchID int, status string, reviewerID int) error {
+	if !isValidMatchStatus(status) || status == "pending" {
+		return errors.New("invalid match status")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Kunci laporan agar dua kecocokan tidak dikonfirmasi bersamaan
+	_, err = tx.Exec(`SELECT 1 FROM missing_persons WHERE id = $1 FOR UPDATE`, missingPersonID)
+	if err != nil {
+		return err
+	}
+
+	var refugeeID int
+	var currentStatus string
+	err = tx.QueryRow(`SELECT refugee_id, status FROM missing_person_matches WHERE id = $1 AND missing_person_id = $2 FOR UPDATE`, matchID, missingPersonID).
+		Scan(&refugeeID, &currentStatus)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("match not found")
+		}
+		return err
+	}
+	if currentStatus != "pending" {
+		return errors.New("match already reviewed")
+	}
+
+	_, err = tx.Exec(`UPDATE missing_person_matches SET status = $1, reviewed_by = $2, reviewed_at = NOW(), updated_at = NOW() WHERE id = $3`, status, reviewerID, matchID)
+	if err != nil {
+		return err
+	}
+
+	if status == "confirmed" {
+		_, err = tx.Exec(`UPDATE missing_persons SET status = 'found', found_refugee_id = $1, updated_at = NOW() WHERE id = $2`, refugeeID, missingPersonID)
+		if err != nil {
+			return err
+		}
+
+		// Kandidat lain yang masih menunggu otomatis ditolak karena orang hilang sudah ditemukan
+		_, err = tx.Exec(`UPDATE missing_person_matches SET status = 'rejected', reviewed_by = $1, reviewed_at = NOW(), updated_at = NOW()
+		                  WHERE missing_person_id = $2 AND id <> $3 AND status = 'pending'`, reviewerID, missingPersonID, matchID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+func normalizePersonName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+func levenshteinDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func levenshteinRatio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshteinDistance(ra, rb))/float64(longest)
+}
+
+func sortedTokens(name string) string {
+	tokens := strings.Fields(name)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func nameSimilarity(a, b string) float64 {
+	a, b = normalizePersonName(a), normalizePersonName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+
+	best := max(levenshteinRatio(a, b), levenshteinRatio(sortedTokens(a), sortedTokens(b)))
+
+	shorter, longer := strings.Fields(a), strings.Fields(b)
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	total := 0.0
+	for _, token := range shorter {
+		bestToken := 0.0
+		for _, candidate := range longer {
+			bestToken = max(bestToken, levenshteinRatio(token, candidate))
+		}
+		total += bestToken
+	}
+	partial := total / float64(len(shorter)) * 0.9
+
+	return max(best, partial)
+}
@@ -0,0 +1,485 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const allocationDistanceScaleKm = 25.0
+
+var needPriorityWeights = map[string]float64{
+	"critical": 1,
+	"high":     0.75,
+	"medium":   0.5,
+	"low":      0.25,
+}
+
+func isValidNeedPriority(priority string) bool {
+	_, ok := needPriorityWeights[priority]
+	return ok
+}
+
+func refreshNeedRequestFulfillment(tx *sql.Tx, needRequestID int) error {
+	// Distribusi gagal/retur tidak dihitung, distribusi terkonfirmasi memakai jumlah yang benar-benar diterima
+	var fulfilled int
+	err := tx.QueryRow(`SELECT COALESCE(SUM(CASE
+	                        WHEN status IN ('failed', 'returned') THEN 0
+	                        WHEN status = 'confirmed' THEN COALESCE(quantity_received, quantity_sent)
+	                        ELSE quantity_sent
+	                    END), 0) FROM distribution_logs WHERE need_request_id = $1`, needRequestID).Scan(&fulfilled)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE need_requests SET
+	                  quantity_fulfilled = $2,
+	                  status = CASE
+	                      WHEN status = 'cancelled' THEN status
+	                      WHEN $2 >= quantity THEN 'fulfilled'
+	                      WHEN $2 > 0 THEN 'partially_fulfilled'
+	                      ELSE 'open'
+	                  END::need_request_status,
+	                  updated_at = NOW()
+	                  WHERE id = $1`, needRequestID, fulfilled)
+	return err
+}
+
+func CreateNeedRequest(db *sql.DB, need *structs.NeedRequest) error {
+	if need.Priority == "" {
+		need.Priority = "medium"
+	}
+	if !isValidNeedPriority(need.Priority) {
+		return errors.New("invalid need priority")
+	}
+	if need.Quantity <= 0 {
+		return errors.New("invalid stock quantity")
+	}
+	if !isShelterExists(db, need.ShelterID) {
+		return errors.New("shelter not found")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Kebutuhan yang terhubung ke katalog disimpan dalam satuan dasar barang
+	if need.ItemID != nil {
+		var itemName, baseUnit string
+		err = tx.QueryRow(`SELECT name, base_unit FROM items WHERE id = $1`, *need.ItemID).Scan(&itemName, &baseUnit)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("item not found")
+			}
+			return err
+		}
+
+		need.Quantity, err = convertToBaseUnit(tx, need.ItemID, baseUnit, need.Unit, need.Quantity)
+		if err != nil {
+			return err
+		}
+		need.Unit = baseUnit
+		if need.ItemName == "" {
+			need.ItemName = itemName
+		}
+	}
+	if strings.TrimSpace(need.ItemName) == "" {
+		return errors.New("invalid need request")
+	}
+
+	need.Status = "open"
+	err = tx.QueryRow(`INSERT INTO need_requests (shelter_id, item_id, item_name, quantity, unit, priority, status, note, requested_by, created_at, updated_at)
+	                   VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, NULLIF($8, ''), $9, NOW(), NOW()) RETURNING id, created_at, updated_at`,
+		need.ShelterID, need.ItemID, need.ItemName, need.Quantity, need.Unit, need.Priority, need.Status, need.Note, need.RequestedBy).
+		Scan(&need.ID, &need.CreatedAt, &need.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func scanNeedRequests(rows *sql.Rows) ([]structs.NeedRequest, error) {
+	needs := []structs.NeedRequest{}
+	for rows.Next() {
+		var need structs.NeedRequest
+		err := rows.Scan(&need.ID, &need.ShelterID, &need.ItemID, &need.ItemName, &need.Quantity, &need.QuantityFulfilled, &need.Unit,
+			&need.Priority, &need.Status, &need.Note, &need.RequestedBy, &need.CreatedAt, &need.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		needs = append(needs, need)
+	}
+	return needs, nil
+}
+
+func GetNeedRequestsByShelterID(db *sql.DB, shelterID int) ([]structs.NeedRequest, error) {
+	if !isShelterExists(db, shelterID) {
+		return nil, errors.New("shelter not found")
+	}
+
+	query := `SELECT id, shelter_id, item_id, item_name, quantity, quantity_fulfilled, COALESCE(unit, ''), priority, status, COALESCE(note, ''), requested_by, created_at, updated_at
+	          FROM need_requests WHERE shelter_id = $1
+	          ORDER BY status IN ('fulfilled', 'cancelled'), array_position(ARRAY['critical', 'high', 'medium', 'low']::need_priority[], priority), created_at`
+	rows, err := db.Query(query, shelterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNeedRequests(rows)
+}
+
+func GetNeedRequestByID(db *sql.DB, id int) (structs.NeedRequest, error) {
+	query := `SELECT id, shelter_id, item_id, item_name, quantity, quantity_fulfilled, COALESCE(unit, ''), priority, status, COALESCE(note, ''), requested_by, created_at, updated_at
+	          FROM need_requests WHERE id = $1`
+	rows, err := db.Query(query, id)
+	if err != nil {
+		return structs.NeedRequest{}, err
+	}
+	defer rows.Close()
+
+	needs, err := scanNeedRequests(rows)
+	if err != nil {
+		return structs.NeedRequest{}, err
+	}
+	if len(needs) == 0 {
+		return structs.NeedRequest{}, errors.New("need request not found")
+	}
+	return needs[0], nil
+}
+
+func UpdateNeedRequest(db *sql.DB, id int, input structs.NeedRequestInput) error {
+	if input.Priority != "" && !isValidNeedPriority(input.Priority) {
+		return errors.New("invalid need priority")
+	}
+	if input.Status != "" && input.Status != "open" && input.Status != "cancelled" {
+		return errors.New("invalid need request status")
+	}
+	if input.Quantity < 0 {
+		return errors.New("invalid stock quantity")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Jumlah baru dikonversi ke satuan yang tersimpan, sama seperti saat permintaan dibuat
+	if input.Quantity != 0 {
+		var itemID *int
+		var storedUnit string
+		err = tx.QueryRow(`SELECT n.item_id, COALESCE(i.base_unit, n.unit, '') FROM need_requests n LEFT JOIN items i ON i.id = n.item_id
+		                   WHERE n.id = $1 FOR UPDATE OF n`, id).Scan(&itemID, &storedUnit)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("need request not found")
+			}
+			return err
+		}
+
+		input.Quantity, err = convertToBaseUnit(tx, itemID, storedUnit, input.Unit, input.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	var updateFields []string
+	var values []interface{}
+	counter := 1
+
+	if input.ItemName != "" {
+		updateFields = append(updateFields, "item_name = $"+strconv.Itoa(counter))
+		values = append(values, input.ItemName)
+		counter++
+	}
+	if input.Quantity != 0 {
+		updateFields = append(updateFields, "quantity = $"+strconv.Itoa(counter))
+		values = append(values, input.Quantity)
+		counter++
+	}
+	if input.Priority != "" {
+		updateFields = append(updateFields, "priority = $"+strconv.Itoa(counter))
+		values = append(values, input.Priority)
+		counter++
+	}
+	if input.Status != "" {
+		updateFields = append(updateFields, "status = $"+strconv.Itoa(counter))
+		values = append(values, input.Status)
+		counter++
+	}
+	if input.Note != "" {
+		updateFields = append(updateFields, "note = $"+strconv.Itoa(counter))
+		values = append(values, input.Note)
+		counter++
+	}
+
+	if len(updateFields) == 0 {
+		return errors.New("tidak ada field yang dapat diperbarui")
+	}
+
+	updateFields = append(updateFields, "updated_at = NOW()")
+	query := "UPDATE need_requests SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
+	values = append(values, id)
+
+	result, err := tx.Exec(query, values...)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("need request not found")
+	}
+
+	// Status terpenuhi dihitung ulang dari distribusi yang sudah tercatat
+	if err := refreshNeedRequestFulfillment(tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func DeleteNeedRequest(db *sql.DB, id int) error {
+	result, err := db.Exec(`DELETE FROM need_requests WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("need request not found")
+	}
+	return nil
+}
+
+type allocationNeed struct {
+	structs.NeedRequest
+	shelterName string
+	latitude    *float64
+	longitude   *float64
+}
+
+type allocationStock struct {
+	id        int
+	itemType  string
+	itemID    *int
+	unit      string
+	latitude  *float64
+	longitude *float64
+	available int
+}
+
+func isNeedMatchingStock(need allocationNeed, stock allocationStock) bool {
+	if need.ItemID != nil && stock.itemID != nil {
+		return *need.ItemID == *stock.itemID
+	}
+	if need.Unit != "" && stock.unit != "" && !strings.EqualFold(need.Unit, stock.unit) {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSpace(need.ItemName), strings.TrimSpace(stock.itemType))
+}
+
+func GetAllocationProposals(db *sql.DB, disasterID int) ([]structs.AllocationProposal, error) {
+	if !isDisasterExists(db, disasterID) {
+		return nil, errors.New("disaster not found")
+	}
+
+	needRows, err := db.Query(`SELECT n.id, n.shelter_id, n.item_id, n.item_name, n.quantity, n.quantity_fulfilled, COALESCE(n.unit, ''), n.priority,
+	                                  s.name, s.latitude, s.longitude
+	                           FROM need_requests n JOIN shelters s ON s.id = n.shelter_id
+	                           WHERE s.disaster_id = $1 AND n.status IN ('open', 'partially_fulfilled') AND n.quantity_fulfilled < n.quantity`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer needRows.Close()
+
+	var needs []allocationNeed
+	for needRows.Next() {
+		var need allocationNeed
+		err := needRows.Scan(&need.ID, &need.ShelterID, &need.ItemID, &need.ItemName, &need.Quantity, &need.QuantityFulfilled, &need.Unit, &need.Priority,
+			&need.shelterName, &need.latitude, &need.longitude)
+		if err != nil {
+			return nil, err
+		}
+		needs = append(needs, need)
+	}
+
+	// Stok tanpa koordinat gudang diasumsikan berada di lokasi bencana; batch kedaluwarsa tidak dihitung
+	stockRows, err := db.Query(`SELECT l.id, l.type, l.item_id, COALESCE(l.unit, ''),
+	                                   CASE WHEN l.latitude IS NULL THEN d.latitude ELSE l.latitude END,
+	                                   CASE WHEN l.latitude IS NULL THEN d.longitude ELSE l.longitude END,
+	                                   COALESCE((SELECT SUM(b.quantity_remaining) FROM logistic_batches b
+	                                             WHERE b.logistic_id = l.id AND (b.expiry_date IS NULL OR b.expiry_date >= CURRENT_DATE)), 0)
+	                            FROM logistics l JOIN disasters d ON d.id = l.disaster_id
+	                            WHERE l.disaster_id = $1 AND l.quantity > 0`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer stockRows.Close()
+
+	stocks := make(map[int]*allocationStock)
+	for stockRows.Next() {
+		var stock allocationStock
+		err := stockRows.Scan(&stock.id, &stock.itemType, &stock.itemID, &stock.unit, &stock.latitude, &stock.longitude, &stock.available)
+		if err != nil {
+			return nil, err
+		}
+		if stock.available > 0 {
+			stocks[stock.id] = &stock
+		}
+	}
+
+	var candidates []structs.AllocationProposal
+	for _, need := range needs {
+		remaining := need.Quantity - need.QuantityFulfilled
+		shortageRatio := math.Round(float64(remaining)/float64(need.Quantity)*1000) / 1000
+
+		for _, stock := range stocks {
+			if !isNeedMatchingStock(need, *stock) {
+				continue
+			}
+
+			proposal := structs.AllocationProposal{
+				NeedRequestID:     need.ID,
+				ShelterID:         need.ShelterID,
+				ShelterName:       need.shelterName,
+				ItemName:          need.ItemName,
+				Priority:          need.Priority,
+				QuantityRemaining: remaining,
+				LogisticID:        stock.id,
+				LogisticType:      stock.itemType,
+				Unit:              need.Unit,
+				ShortageRatio:     shortageRatio,
+			}
+
+			distanceScore := 0.5
+			if need.latitude != nil && need.longitude != nil && stock.latitude != nil && stock.longitude != nil {
+				distance := math.Round(HaversineKm(*stock.latitude, *stock.longitude, *need.latitude, *need.longitude)*100) / 100
+				proposal.DistanceKm = &distance
+				distanceScore = 1 / (1 + distance/allocationDistanceScaleKm)
+			}
+
+			proposal.Score = math.Round((needPriorityWeights[need.Priority]*0.5+shortageRatio*0.3+distanceScore*0.2)*1000) / 1000
+			candidates = append(candidates, proposal)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].NeedRequestID != candidates[j].NeedRequestID {
+			return candidates[i].NeedRequestID < candidates[j].NeedRequestID
+		}
+		return candidates[i].LogisticID < candidates[j].LogisticID
+	})
+
+	// Alokasi greedy: kebutuhan dengan skor tertinggi mendapat stok lebih dulu
+	needLeft := make(map[int]int)
+	for _, need := range needs {
+		needLeft[need.ID] = need.Quantity - need.QuantityFulfilled
+	}
+
+	proposals := []structs.AllocationProposal{}
+	for _, candidate := range candidates {
+		stock := stocks[candidate.LogisticID]
+		quantity := min(needLeft[candidate.NeedRequestID], stock.available)
+		if quantity <= 0 {
+			continue
+		}
+
+		candidate.AvailableStock = stock.available
+		candidate.QuantityProposed = quantity
+		proposals = append(proposals, candidate)
+
+		needLeft[candidate.NeedRequestID] -= quantity
+		stock.available -= quantity
+	}
+
+	return proposals, nil
+}
+
+func AcceptAllocation(db *sql.DB, needRequestID int, input structs.AllocationAcceptInput, sentAt time.Time, recordedBy int) (structs.DistributionLog, error) {
+	var log structs.DistributionLog
+	tx, err := db.Begin()
+	if err != nil {
+		return log, err
+	}
+	defer tx.Rollback()
+
+	var need allocationNeed
+	var shelterDisasterID *int
+	err = tx.QueryRow(`SELECT n.item_id, n.item_name, n.quantity, n.quantity_fulfilled, COALESCE(n.unit, ''), n.status, s.name, s.disaster_id, s.latitude, s.longitude
+	                   FROM need_requests n JOIN shelters s ON s.id = n.shelter_id WHERE n.id = $1 FOR UPDATE OF n`, needRequestID).
+		Scan(&need.ItemID, &need.ItemName, &need.Quantity, &need.QuantityFulfilled, &need.Unit, &need.Status, &need.shelterName, &shelterDisasterID, &need.latitude, &need.longitude)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return log, errors.New("need request not found")
+		}
+		return log, err
+	}
+	if need.Status == "fulfilled" || need.Status == "cancelled" {
+		return log, errors.New("need request closed")
+	}
+
+	var stock allocationStock
+	var disasterID *int
+	var location string
+	err = tx.QueryRow(`SELECT id, type, item_id, COALESCE(unit, ''), COALESCE(location, ''), latitude, longitude, disaster_id FROM logistics WHERE id = $1`, input.LogisticID).
+		Scan(&stock.id, &stock.itemType, &stock.itemID, &stock.unit, &location, &stock.latitude, &stock.longitude, &disasterID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return log, errors.New("logistic not found")
+		}
+		return log, err
+	}
+	if disasterID == nil || shelterDisasterID == nil || *disasterID != *shelterDisasterID {
+		return log, errors.New("logistic not in shelter disaster")
+	}
+	if !isNeedMatchingStock(need, stock) {
+		return log, errors.New("logistic does not match need")
+	}
+
+	remaining := need.Quantity - need.QuantityFulfilled
+	quantity := input.Quantity
+	if quantity == 0 {
+		quantity = remaining
+	}
+	if quantity > remaining {
+		return log, errors.New("allocation exceeds need")
+	}
+
+	log = structs.DistributionLog{
+		LogisticID:    &stock.id,
+		Origin:        location,
+		Destination:   need.shelterName,
+		SenderName:    input.SenderName,
+		RecipientName: input.RecipientName,
+		QuantitySent:  quantity,
+		NeedRequestID: &needRequestID,
+		SentAt:        sentAt,
+	}
+	if log.Origin == "" {
+		log.Origin = "Gudang logistik"
+	}
+	if log.RecipientName == "" {
+		log.RecipientName = "Pengelola " + need.shelterName
+	}
+	if stock.latitude != nil && stock.longitude != nil && need.latitude != nil && need.longitude != nil {
+		log.Distance = math.Round(HaversineKm(*stock.latitude, *stock.longitude, *need.latitude, *need.longitude)*100) / 100
+	}
+
+	if err := insertDistributionLog(tx, &log, recordedBy); err != nil {
+		return log, err
+	}
+	if err := refreshNeedRequestFulfillment(tx, needRequestID); err != nil {
+		return log, err
+	}
+
+	return log, tx.Commit()
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+)
+
+func GetPayableDonation(db *sql.DB, donationID int) (structs.Donation, error) {
+	donation, err := GetDonationByID(db, donationID)
+	if err != nil {
+		return donation, err
+	}
+	if IsInKindDonation(donation) {
+		return donation, errors.New("donation not monetary")
+	}
+	if donation.Status != "pending" {
+		return donation, errors.New("donation not pending")
+	}
+	if donation.Amount <= 0 {
+		return donation, errors.New("invalid donation amount")
+	}
+	return donation, nil
+}
+
+func hasPendingPayment(tx *sql.Tx, donationID int) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM payment_intents WHERE donation_id = $1 AND status = 'pending')`, donationID).Scan(&exists)
+	return exists, err
+}
+
+func GetPendingPaymentIntent(db *sql.DB, donationID int, provider string) (structs.PaymentIntent, error) {
+	var intent structs.PaymentIntent
+	err := db.QueryRow(`SELECT id, donation_id, provider, provider_reference, amount, currency, status, COALESCE(redirect_url, ''), paid_at, created_at, updated_at
+	                    FROM payment_intents WHERE donation_id = $1 AND provider = $2 AND status = 'pending' ORDER BY id DESC LIMIT 1`, donationID, provider).
+		Scan(&intent.ID, &intent.DonationID, &intent.Provider, &intent.ProviderReference, &intent.Amount, &intent.Currency, &intent.Status, &intent.RedirectURL, &intent.PaidAt, &intent.CreatedAt, &intent.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return intent, errors.New("payment not found")
+		}
+		return intent, err
+	}
+	return intent, nil
+}
+
+func GetPaymentIntentByReference(db *sql.DB, provider, reference string) (structs.PaymentIntent, error) {
+	var intent structs.PaymentIntent
+	err := db.QueryRow(`SELECT id, donation_id, provider, provider_reference, amount, currency, status, COALESCE(redirect_url, ''), paid_at, created_at, updated_at
+	                    FROM payment_intents WHERE provider = $1 AND provider_reference = $2`, provider, reference).
+		Scan(&intent.ID, &intent.DonationID, &intent.Provider, &intent.ProviderReference, &intent.Amount, &intent.Currency, &intent.Status, &intent.RedirectURL, &intent.PaidAt, &intent.CreatedAt, &intent.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return intent, errors.New("payment not found")
+		}
+		return intent, err
+	}
+	return intent, nil
+}
+
+func CreatePaymentIntent(db *sql.DB, intent *structs.PaymentIntent) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Nominal tagihan diambil dari donasi yang dikunci agar tidak berubah di tengah pembayaran
+	var status string
+	err = tx.QueryRow(`SELECT status, amount, COALESCE(currency, 'IDR') FROM donations WHERE id = $1 FOR UPDATE`, intent.DonationID).Scan(&status, &intent.Amount, &intent.Currency)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("donation not found")
+		}
+		return err
+	}
+	if status != "pending" {
+		return errors.New("donation not pending")
+	}
+
+	err = tx.QueryRow(`INSERT INTO payment_intents (donation_id, provider, provider_reference, amount, currency, status, redirect_url, created_at, updated_at)
+	                   VALUES ($1, $2, $3, $4, $5, 'pending', $6, NOW(), NOW()) RETURNING id, status, created_at, updated_at`,
+		intent.DonationID, intent.Provider, intent.ProviderReference, intent.Amount, intent.Currency, intent.RedirectURL).
+		Scan(&intent.ID, &intent.Status, &intent.CreatedAt, &intent.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func ApplyPaymentWebhook(db *sql.DB, provider string, event structs.PaymentWebhookEvent) error {
+	if event.Status != "paid" && event.Status != "failed" && event.Status != "expired" {
+		return errors.New("invalid webhook payload")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var intentID, donationID int
+	var amount float64
+	var currency, status string
+	err = tx.QueryRow(`SELECT id, donation_id, amount, currency, status FROM payment_intents WHERE provider = $1 AND provider_reference = $2 FOR UPDATE`, provider, event.ProviderReference).
+		Scan(&intentID, &donationID, &amount, &currency, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("payment not found")
+		}
+		return err
+	}
+
+	// Webhook yang dikirim ulang dengan event_id sama cukup diabaikan
+	result, err := tx.Exec(`INSERT INTO payment_webhook_events (provider, event_id, provider_reference, status, payload, received_at)
+	                        VALUES ($1, $2, $3, $4, $5, NOW()) ON CONFLICT (provider, event_id) DO NOTHING`,
+		provider, event.EventID, event.ProviderReference, event.Status, event.Payload)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 || status != "pending" {
+		return tx.Commit()
+	}
+
+	if event.Status == "paid" && (event.Amount != amount || (event.Currency != "" && NormalizeCurrency(event.Currency) != currency)) {
+		return errors.New("payment amount mismatch")
+	}
+
+	_, err = tx.Exec(`UPDATE payment_intents SET status = $1, paid_at = CASE WHEN $2 THEN NOW() END, updated_at = NOW() WHERE id = $3`, event.Status, event.Status == "paid", intentID)
+	if err != nil {
+		return err
+	}
+
+	if event.Status == "paid" {
+		// Donasi hanya dikonfirmasi bila nominal dan mata uangnya masih sama dengan tagihan yang dibayar
+		var donationStatus, donationCurrency string
+		var donationAmount float64
+		err = tx.QueryRow(`SELECT status, amount, COALESCE(currency, 'IDR') FROM donations WHERE id = $1 FOR UPDATE`, donationID).
+			Scan(&donationStatus, &donationAmount, &donationCurrency)
+		if err != nil {
+			return err
+		}
+		if donationStatus == "pending" && (donationAmount != amount || donationCurrency != currency) {
+			return errors.New("payment amount mismatch")
+		}
+
+		result, err := tx.Exec(`UPDATE donations SET status = 'confirmed', updated_at = NOW() WHERE id = $1 AND status = 'pending'`, donationID)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			if err := issueDonationReceipt(tx, donationID); err != nil {
+				return err
+			}
+			if err := appendDonationLedger(tx, donationID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
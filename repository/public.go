@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+)
+
+// Tampilan publik hanya memuat data bencana yang masih aktif, tanpa data pelapor, pengungsi, maupun catatan bebas
+
+func GetPublicDisasters(db *sql.DB) ([]structs.PublicDisaster, error) {
+	rows, err := db.Query(`SELECT id, type, location, status, latitude, longitude, updated_at
+	                       FROM disasters WHERE status = 'active' ORDER BY updated_at DESC, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disasters := []structs.PublicDisaster{}
+	for rows.Next() {
+		var disaster structs.PublicDisaster
+		err := rows.Scan(&disaster.ID, &disaster.Type, &disaster.Location, &disaster.Status, &disaster.Latitude, &disaster.Longitude, &disaster.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		disasters = append(disasters, disaster)
+	}
+	return disasters, nil
+}
+
+func GetPublicShelters(db *sql.DB, disasterID *int) ([]structs.PublicShelter, error) {
+	rows, err := db.Query(`SELECT s.id, s.disaster_id, s.name, s.location, s.capacity_total, s.capacity_remaining,
+	                              (SELECT COUNT(*) FROM refugees r WHERE r.shelter_id = s.id), s.latitude, s.longitude, s.updated_at
+	                       FROM shelters s
+	                       LEFT JOIN disasters d ON d.id = s.disaster_id
+	                       WHERE (s.disaster_id IS NULL OR d.status = 'active') AND ($1::INT IS NULL OR s.disaster_id = $1)
+	                       ORDER BY s.capacity_remaining DESC, s.id`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shelters := []structs.PublicShelter{}
+	for rows.Next() {
+		var shelter structs.Shelter
+		err := rows.Scan(&shelter.ID, &shelter.DisasterID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining,
+			&shelter.Occupancy, &shelter.Latitude, &shelter.Longitude, &shelter.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		setOccupancyPercentage(&shelter)
+		shelters = append(shelters, structs.PublicShelter{
+			ID:                  shelter.ID,
+			DisasterID:          shelter.DisasterID,
+			Name:                shelter.Name,
+			Location:            shelter.Location,
+			CapacityTotal:       shelter.CapacityTotal,
+			CapacityRemaining:   shelter.CapacityRemaining,
+			OccupancyPercentage: shelter.OccupancyPercentage,
+			Full:                shelter.CapacityRemaining <= 0,
+			Latitude:            shelter.Latitude,
+			Longitude:           shelter.Longitude,
+			UpdatedAt:           shelter.UpdatedAt,
+		})
+	}
+	return shelters, nil
+}
+
+func GetPublicEvacuationRoutes(db *sql.DB, disasterID *int) ([]structs.PublicEvacuationRoute, error) {
+	rows, err := db.Query(`SELECT e.id, e.disaster_id, e.origin, e.destination, e.distance, e.status, e.created_at, e.updated_at
+	                       FROM evacuation_routes e
+	                       LEFT JOIN disasters d ON d.id = e.disaster_id
+	                       WHERE (e.disaster_id IS NULL OR d.status = 'active') AND ($1::INT IS NULL OR e.disaster_id = $1)
+	                       ORDER BY e.id`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routes []structs.EvacuationRoute
+	for rows.Next() {
+		var route structs.EvacuationRoute
+		err := rows.Scan(&route.ID, &route.DisasterID, &route.Origin, &route.Destination, &route.Distance, &route.Status, &route.CreatedAt, &route.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	rows.Close()
+
+	if err := attachRouteGeometry(db, routes); err != nil {
+		return nil, err
+	}
+
+	// Catatan segmen tidak ditampilkan karena bisa berisi informasi petugas di lapangan
+	publicRoutes := []structs.PublicEvacuationRoute{}
+	for _, route := range routes {
+		publicRoute := structs.PublicEvacuationRoute{
+			ID:          route.ID,
+			DisasterID:  route.DisasterID,
+			Origin:      route.Origin,
+			Destination: route.Destination,
+			Distance:    route.Distance,
+			Status:      route.Status,
+			Waypoints:   route.Waypoints,
+			UpdatedAt:   route.UpdatedAt,
+		}
+		for _, segment := range route.Segments {
+			publicRoute.Segments = append(publicRoute.Segments, structs.PublicRouteSegment{
+				Sequence: segment.Sequence,
+				Distance: segment.Distance,
+				Status:   segment.Status,
+			})
+			if segment.UpdatedAt.After(publicRoute.UpdatedAt) {
+				publicRoute.UpdatedAt = segment.UpdatedAt
+			}
+		}
+		publicRoutes = append(publicRoutes, publicRoute)
+	}
+	return publicRoutes, nil
+}
@@ -9,22 +9,34 @@ import (
 )
 
 
-func CreateRefugee(db *sql.DB, refugee *structs.Refugee) error {
+func CreateRefugee(db *sql.DB, refugee *structs.Refugee, allowOverCapacity bool) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	sqlQuery := `INSERT INTO refugees (name, age, condition, needs, shelter_id, disaster_id, created_at, updated_at)
 	             VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, refugee.Name, refugee.Age, refugee.Condition, refugee.Needs, refugee.ShelterID, refugee.DisasterID).
+	err = tx.QueryRow(sqlQuery, refugee.Name, refugee.Age, refugee.Condition, refugee.Needs, refugee.ShelterID, refugee.DisasterID).
 		Scan(&refugee.ID, &refugee.CreatedAt, &refugee.UpdatedAt)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if refugee.ShelterID != nil {
+		if err := syncShelterOccupancy(tx, []int{*refugee.ShelterID}, refugee.ShelterID, allowOverCapacity); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 
 func GetAllRefugees(db *sql.DB) ([]structs.Refugee, error) {
-	query := `SELECT id, name, age, condition, needs, shelter_id, disaster_id, created_at, updated_at FROM refugees`
+	query := `SELECT id, name, age, condition, needs, shelter_id, disaster_id, household_id, COALESCE(relationship, ''), COALESCE(triage_category::TEXT, ''), triaged_at, created_at, updated_at FROM refugees`
 	rows, err := db.Query(query)
 
 	if err != nil {
@@ -35,7 +47,7 @@ func GetAllRefugees(db *sql.DB) ([]structs.Refugee, error) {
 	var refugees []structs.Refugee
 	for rows.Next() {
 		var refugee structs.Refugee
-		err := rows.Scan(&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.CreatedAt, &refugee.UpdatedAt)
+		err := rows.Scan(&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.HouseholdID, &refugee.Relationship, &refugee.TriageCategory, &refugee.TriagedAt, &refugee.CreatedAt, &refugee.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -46,9 +58,9 @@ func GetAllRefugees(db *sql.DB) ([]structs.Refugee, error) {
 
 
 func GetRefugeeByID(db *sql.DB, id int) (structs.Refugee, error) {
-	query := `SELECT id, name, age, condition, needs, shelter_id, disaster_id, created_at, updated_at FROM refugees WHERE id = $1`
+	query := `SELECT id, name, age, condition, needs, shelter_id, disaster_id, household_id, COALESCE(relationship, ''), COALESCE(triage_category::TEXT, ''), triaged_at, created_at, updated_at FROM refugees WHERE id = $1`
 	var refugee structs.Refugee
-	err := db.QueryRow(query, id).Scan(&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.CreatedAt, &refugee.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.HouseholdID, &refugee.Relationship, &refugee.TriageCategory, &refugee.TriagedAt, &refugee.CreatedAt, &refugee.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -65,7 +77,7 @@ func isRefugeeExists(db *sql.DB, id int) bool {
 	return err == nil
 }
 
-func UpdateRefugee(db *sql.DB, refugee structs.Refugee) error {
+func UpdateRefugee(db *sql.DB, refugee structs.Refugee, allowOverCapacity bool, authorizedBy int) error {
 	if !isRefugeeExists(db, refugee.ID) {
 		return errors.New("refugee not found")
 	}
@@ -113,25 +125,168 @@ func UpdateRefugee(db *sql.DB, refugee structs.Refugee) error {
 	query := "UPDATE refugees SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
 	values = append(values, refugee.ID)
 
-	_, err := db.Exec(query, values...)
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+
+	var previousShelterID, householdID *int
+	err = tx.QueryRow(`SELECT shelter_id, household_id FROM refugees WHERE id = $1 FOR UPDATE`, refugee.ID).Scan(&previousShelterID, &householdID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("refugee not found")
+		}
+		return err
+	}
+
+	// Anggota keluarga dipindahkan bersama melalui penempatan keluarga agar keluarga tidak terpisah
+	if householdID != nil && refugee.ShelterID != nil && (previousShelterID == nil || *previousShelterID != *refugee.ShelterID) {
+		return errors.New("refugee in household")
+	}
+
+	_, err = tx.Exec(query, values...)
+	if err != nil {
+		return err
+	}
+
+	if refugee.ShelterID != nil && (previousShelterID == nil || *previousShelterID != *refugee.ShelterID) {
+		shelterIDs := []int{*refugee.ShelterID}
+		if previousShelterID != nil {
+			shelterIDs = append(shelterIDs, *previousShelterID)
+		}
+		if err := syncShelterOccupancy(tx, shelterIDs, refugee.ShelterID, allowOverCapacity); err != nil {
+			return err
+		}
+		if _, err := recordRefugeeMovement(tx, refugee.ID, previousShelterID, refugee.ShelterID, "Perubahan data pengungsi", authorizedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func recordRefugeeMovement(tx *sql.Tx, refugeeID int, fromShelterID, toShelterID *int, reason string, authorizedBy int) (structs.RefugeeMovement, error) {
+	movement := structs.RefugeeMovement{
+		RefugeeID:     refugeeID,
+		FromShelterID: fromShelterID,
+		ToShelterID:   toShelterID,
+		Reason:        reason,
+		AuthorizedBy:  &authorizedBy,
+	}
+
+	sqlQuery := `INSERT INTO refugee_movements (refugee_id, from_shelter_id, to_shelter_id, reason, authorized_by, moved_at)
+	             VALUES ($1, $2, $3, $4, $5, NOW()) RETURNING id, moved_at`
+	err := tx.QueryRow(sqlQuery, refugeeID, fromShelterID, toShelterID, reason, authorizedBy).Scan(&movement.ID, &movement.MovedAt)
+	if err != nil {
+		return movement, err
+	}
+	return movement, nil
+}
+
+func TransferRefugee(db *sql.DB, refugeeID, toShelterID int, reason string, authorizedBy int, allowOverCapacity bool) (structs.RefugeeMovement, error) {
+	var movement structs.RefugeeMovement
+
+	if !isShelterExists(db, toShelterID) {
+		return movement, errors.New("shelter not found")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return movement, err
+	}
+	defer tx.Rollback()
+
+	var fromShelterID, householdID *int
+	err = tx.QueryRow(`SELECT shelter_id, household_id FROM refugees WHERE id = $1 FOR UPDATE`, refugeeID).Scan(&fromShelterID, &householdID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return movement, errors.New("refugee not found")
+		}
+		return movement, err
+	}
+
+	if fromShelterID != nil && *fromShelterID == toShelterID {
+		return movement, errors.New("refugee already in shelter")
+	}
+	if householdID != nil {
+		return movement, errors.New("refugee in household")
+	}
+
+	_, err = tx.Exec(`UPDATE refugees SET shelter_id = $1, updated_at = NOW() WHERE id = $2`, toShelterID, refugeeID)
+	if err != nil {
+		return movement, err
+	}
+
+	shelterIDs := []int{toShelterID}
+	if fromShelterID != nil {
+		shelterIDs = append(shelterIDs, *fromShelterID)
+	}
+	if err := syncShelterOccupancy(tx, shelterIDs, &toShelterID, allowOverCapacity); err != nil {
+		return movement, err
+	}
+
+	movement, err = recordRefugeeMovement(tx, refugeeID, fromShelterID, &toShelterID, reason, authorizedBy)
+	if err != nil {
+		return movement, err
+	}
+
+	return movement, tx.Commit()
+}
+
+func GetRefugeeMovements(db *sql.DB, refugeeID int) ([]structs.RefugeeMovement, error) {
+	if !isRefugeeExists(db, refugeeID) {
+		return nil, errors.New("refugee not found")
+	}
+
+	query := `SELECT id, refugee_id, from_shelter_id, to_shelter_id, COALESCE(reason, ''), authorized_by, moved_at
+	          FROM refugee_movements WHERE refugee_id = $1 ORDER BY moved_at, id`
+	rows, err := db.Query(query, refugeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []structs.RefugeeMovement{}
+	for rows.Next() {
+		var movement structs.RefugeeMovement
+		err := rows.Scan(&movement.ID, &movement.RefugeeID, &movement.FromShelterID, &movement.ToShelterID, &movement.Reason, &movement.AuthorizedBy, &movement.MovedAt)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
 }
 
 func DeleteRefugee(db *sql.DB, id int) error {
-	sqlQuery := `DELETE FROM refugees WHERE id=$1`
-	_, err := db.Exec(sqlQuery, id)
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+
+	var shelterID *int
+	err = tx.QueryRow(`DELETE FROM refugees WHERE id = $1 RETURNING shelter_id`, id).Scan(&shelterID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("refugee not found")
+		}
+		return err
+	}
+
+	if shelterID != nil {
+		if err := syncShelterOccupancy(tx, []int{*shelterID}, nil, false); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func GetDistributionLogsByRefugeeID(db *sql.DB, refugeeID int) ([]structs.DistributionLog, error) {
 	var logs []structs.DistributionLog
-	query := `SELECT id, logistic_id, origin, destination, sender_name, recipient_name, quantity_sent, sent_at, created_at, updated_at 
+	query := `SELECT id, logistic_id, origin, destination, sender_name, recipient_name, quantity_sent, status, sent_at, created_at, updated_at 
 	          FROM distribution_logs WHERE recipient_name = (SELECT name FROM refugees WHERE id = $1)`
 	rows, err := db.Query(query, refugeeID)
 	if err != nil {
@@ -141,7 +296,7 @@ func GetDistributionLogsByRefugeeID(db *sql.DB, refugeeID int) ([]structs.Distri
 
 	for rows.Next() {
 		var log structs.DistributionLog
-		err := rows.Scan(&log.ID, &log.LogisticID, &log.Origin, &log.Destination, &log.SenderName, &log.RecipientName, &log.QuantitySent, &log.SentAt, &log.CreatedAt, &log.UpdatedAt)
+		err := rows.Scan(&log.ID, &log.LogisticID, &log.Origin, &log.Destination, &log.SenderName, &log.RecipientName, &log.QuantitySent, &log.Status, &log.SentAt, &log.CreatedAt, &log.UpdatedAt)
 		if err != nil {
 			return logs, err
 		}
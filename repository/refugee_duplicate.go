@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	duplicateAgeTolerance      = 2
+	duplicateProximityKm       = 25.0
+	minimumDuplicateNameScore  = 0.85
+	minimumDuplicateMatchScore = 0.75
+)
+
+func FindDuplicateRefugees(db *sql.DB, refugee structs.Refugee) ([]structs.RefugeeDuplicate, error) {
+	var shelterLatitude, shelterLongitude *float64
+	if refugee.ShelterID != nil {
+		err := db.QueryRow(`SELECT latitude, longitude FROM shelters WHERE id = $1`, *refugee.ShelterID).Scan(&shelterLatitude, &shelterLongitude)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+
+	query := `SELECT r.id, r.name, r.age, r.condition, r.needs, r.shelter_id, r.disaster_id, r.household_id, COALESCE(r.relationship, ''), r.created_at, r.updated_at,
+	                 s.latitude, s.longitude
+	          FROM refugees r LEFT JOIN shelters s ON s.id = r.shelter_id
+	          WHERE r.id <> $1 AND r.age BETWEEN $2 AND $3 AND ($4::INT IS NULL OR r.disaster_id IS NULL OR r.disaster_id = $4)`
+	rows, err := db.Query(query, refugee.ID, refugee.Age-duplicateAgeTolerance, refugee.Age+duplicateAgeTolerance, refugee.DisasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	duplicates := []structs.RefugeeDuplicate{}
+	for rows.Next() {
+		var candidate structs.Refugee
+		var latitude, longitude *float64
+		err := rows.Scan(&candidate.ID, &candidate.Name, &candidate.Age, &candidate.Condition, &candidate.Needs, &candidate.ShelterID, &candidate.DisasterID,
+			&candidate.HouseholdID, &candidate.Relationship, &candidate.CreatedAt, &candidate.UpdatedAt, &latitude, &longitude)
+		if err != nil {
+			return nil, err
+		}
+
+		nameScore := nameSimilarity(refugee.Name, candidate.Name)
+		if nameScore < minimumDuplicateNameScore {
+			continue
+		}
+
+		ageDifference := math.Abs(float64(refugee.Age - candidate.Age))
+		ageScore := 1 - ageDifference/float64(duplicateAgeTolerance+1)
+
+		duplicate := structs.RefugeeDuplicate{Refugee: candidate}
+		proximityScore := 0.5
+		if refugee.ShelterID != nil && candidate.ShelterID != nil && *refugee.ShelterID == *candidate.ShelterID {
+			proximityScore = 1
+		} else if shelterLatitude != nil && shelterLongitude != nil && latitude != nil && longitude != nil {
+			distance := math.Round(HaversineKm(*shelterLatitude, *shelterLongitude, *latitude, *longitude)*100) / 100
+			duplicate.DistanceKm = &distance
+			proximityScore = math.Max(0, 1-distance/duplicateProximityKm)
+		}
+
+		duplicate.Score = math.Round((nameScore*0.6+ageScore*0.2+proximityScore*0.2)*1000) / 1000
+		if duplicate.Score >= minimumDuplicateMatchScore {
+			duplicates = append(duplicates, duplicate)
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool { return duplicates[i].Score > duplicates[j].Score })
+	return duplicates, nil
+}
+
+func mergeNeeds(primary, duplicate string) string {
+	needs := splitNeeds(primary)
+	seen := make(map[string]bool)
+	for _, need := range needs {
+		seen[strings.ToLower(need)] = true
+	}
+	for _, need := range splitNeeds(duplicate) {
+		if !seen[strings.ToLower(need)] {
+			seen[strings.ToLower(need)] = true
+			needs = append(needs, need)
+		}
+	}
+	return strings.Join(needs, ", ")
+}
+
+func MergeRefugees(db *sql.DB, primaryID, duplicateID, mergedBy int) (structs.RefugeeMerge, error) {
+	var merge structs.RefugeeMerge
+	if primaryID == duplicateID {
+		return merge, errors.New("cannot merge refugee with itself")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return merge, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT id, name, age, condition, needs, shelter_id, disaster_id, household_id, COALESCE(relationship, ''),
+	                              COALESCE(triage_category::TEXT, ''), triaged_at, created_at, updated_at
+	                       FROM refugees WHERE id = ANY($1) ORDER BY id FOR UPDATE`, pq.Array([]int64{int64(primaryID), int64(duplicateID)}))
+	if err != nil {
+		return merge, err
+	}
+
+	records := make(map[int]structs.Refugee)
+	for rows.Next() {
+		var refugee structs.Refugee
+		err := rows.Scan(&refugee.ID, &refugee.Name, &refugee.Age, &refugee.Condition, &refugee.Needs, &refugee.ShelterID, &refugee.DisasterID, &refugee.HouseholdID, &refugee.Relationship,
+			&refugee.TriageCategory, &refugee.TriagedAt, &refugee.CreatedAt, &refugee.UpdatedAt)
+		if err != nil {
+			rows.Close()
+			return merge, err
+		}
+		records[refugee.ID] = refugee
+	}
+	rows.Close()
+
+	primary, primaryExists := records[primaryID]
+	duplicate, duplicateExists := records[duplicateID]
+	if !primaryExists || !duplicateExists {
+		return merge, errors.New("refugee not found")
+	}
+
+	if primary.Condition == "" {
+		primary.Condition = duplicate.Condition
+	}
+	primary.Needs = mergeNeeds(primary.Needs, duplicate.Needs)
+	previousShelterID := primary.ShelterID
+	if primary.ShelterID == nil {
+		primary.ShelterID = duplicate.ShelterID
+	}
+	if primary.DisasterID == nil {
+		primary.DisasterID = duplicate.DisasterID
+	}
+	if primary.HouseholdID == nil && duplicate.HouseholdID != nil {
+		primary.HouseholdID, primary.Relationship = duplicate.HouseholdID, duplicate.Relationship
+
+		// Data utama yang masuk ke keluarga ikut menempati shelter keluarga tersebut
+		var householdShelterID *int
+		err = tx.QueryRow(`SELECT shelter_id FROM households WHERE id = $1 FOR UPDATE`, *primary.HouseholdID).Scan(&householdShelterID)
+		if err != nil {
+			return merge, err
+		}
+		if householdShelterID != nil {
+			primary.ShelterID = householdShelterID
+		}
+	}
+	// Kategori triase mengikuti penilaian terbaru dari kedua data
+	if duplicate.TriagedAt != nil && (primary.TriagedAt == nil || duplicate.TriagedAt.After(*primary.TriagedAt)) {
+		primary.TriageCategory, primary.TriagedAt = duplicate.TriageCategory, duplicate.TriagedAt
+	}
+
+	statements := []string{
+		`UPDATE refugee_movements SET refugee_id = $1 WHERE refugee_id = $2`,
+		`UPDATE triage_assessments SET refugee_id = $1 WHERE refugee_id = $2`,
+		`DELETE FROM missing_person_matches WHERE refugee_id = $2 AND missing_person_id IN (SELECT missing_person_id FROM missing_person_matches WHERE refugee_id = $1)`,
+		`UPDATE missing_person_matches SET refugee_id = $1 WHERE refugee_id = $2`,
+		`UPDATE missing_persons SET found_refugee_id = $1 WHERE found_refugee_id = $2`,
+		`UPDATE refugee_merges SET refugee_id = $1 WHERE refugee_id = $2`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement, primaryID, duplicateID); err != nil {
+			return merge, err
+		}
+	}
+
+	// Data utama hanya menggantikan posisi kepala keluarga bila menjadi anggota keluarga tersebut
+	_, err = tx.Exec(`UPDATE households SET head_refugee_id = CASE WHEN id = $3 THEN $1::INT END, updated_at = NOW() WHERE head_refugee_id = $2`,
+		primaryID, duplicateID, primary.HouseholdID)
+	if err != nil {
+		return merge, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM refugees WHERE id = $1`, duplicateID)
+	if err != nil {
+		return merge, err
+	}
+
+	_, err = tx.Exec(`UPDATE refugees SET condition = $1, needs = $2, shelter_id = $3, disaster_id = $4, household_id = $5, relationship = NULLIF($6, ''),
+	                         triage_category = NULLIF($7, '')::triage_category, triaged_at = $8, updated_at = NOW() WHERE id = $9`,
+		primary.Condition, primary.Needs, primary.ShelterID, primary.DisasterID, primary.HouseholdID, primary.Relationship,
+		primary.TriageCategory, primary.TriagedAt, primaryID)
+	if err != nil {
+		return merge, err
+	}
+
+	if previousShelterID != nil && (primary.ShelterID == nil || *previousShelterID != *primary.ShelterID) {
+		if _, err := recordRefugeeMovement(tx, primaryID, previousShelterID, primary.ShelterID, "Penggabungan data ganda", mergedBy); err != nil {
+			return merge, err
+		}
+	}
+
+	var shelterIDs []int
+	for _, shelterID := range []*int{previousShelterID, primary.ShelterID, duplicate.ShelterID} {
+		if shelterID != nil {
+			shelterIDs = append(shelterIDs, *shelterID)
+		}
+	}
+	if err := syncShelterOccupancy(tx, shelterIDs, nil, true); err != nil {
+		return merge, err
+	}
+
+	snapshot, err := json.Marshal(duplicate)
+	if err != nil {
+		return merge, err
+	}
+
+	merge = structs.RefugeeMerge{RefugeeID: primaryID, MergedRefugeeID: duplicateID, MergedData: duplicate, MergedBy: &mergedBy}
+	err = tx.QueryRow(`INSERT INTO refugee_merges (refugee_id, merged_refugee_id, merged_data, merged_by, merged_at)
+	                   VALUES ($1, $2, $3, $4, NOW()) RETURNING id, merged_at`, primaryID, duplicateID, snapshot, mergedBy).
+		Scan(&merge.ID, &merge.MergedAt)
+	if err != nil {
+		return merge, err
+	}
+
+	return merge, tx.Commit()
+}
@@ -9,9 +9,13 @@ import (
 )
 
 func CreateShelter(db *sql.DB, shelter *structs.Shelter) error {
-	sqlQuery := `INSERT INTO shelters (name, location, capacity_total, capacity_remaining, emergency_needs, disaster_id, created_at, updated_at)
-	             VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, shelter.Name, shelter.Location, shelter.CapacityTotal, shelter.CapacityRemaining, shelter.EmergencyNeeds, shelter.DisasterID).
+	if err := validateLocation(shelter.Latitude, shelter.Longitude, shelter.LocationAccuracy); err != nil {
+		return err
+	}
+
+	sqlQuery := `INSERT INTO shelters (name, location, capacity_total, capacity_remaining, emergency_needs, disaster_id, latitude, longitude, location_accuracy, created_at, updated_at)
+	             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	err := db.QueryRow(sqlQuery, shelter.Name, shelter.Location, shelter.CapacityTotal, shelter.CapacityRemaining, shelter.EmergencyNeeds, shelter.DisasterID, shelter.Latitude, shelter.Longitude, shelter.LocationAccuracy).
 		Scan(&shelter.ID, &shelter.CreatedAt, &shelter.UpdatedAt)
 
 	if err != nil {
@@ -22,7 +26,7 @@ func CreateShelter(db *sql.DB, shelter *structs.Shelter) error {
 }
 
 func GetAllShelters(db *sql.DB) ([]structs.Shelter, error) {
-	query := `SELECT id, name, location, capacity_total, capacity_remaining, emergency_needs, disaster_id, created_at, updated_at FROM shelters`
+	query := `SELECT id, name, location, capacity_total, capacity_remaining, (SELECT COUNT(*) FROM refugees r WHERE r.shelter_id = shelters.id) AS occupancy, emergency_needs, disaster_id, latitude, longitude, location_accuracy, created_at, updated_at FROM shelters`
 	rows, err := db.Query(query)
 
 	if err != nil {
@@ -33,19 +37,44 @@ func GetAllShelters(db *sql.DB) ([]structs.Shelter, error) {
 	var shelters []structs.Shelter
 	for rows.Next() {
 		var shelter structs.Shelter
-		err := rows.Scan(&shelter.ID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining, &shelter.EmergencyNeeds, &shelter.DisasterID, &shelter.CreatedAt, &shelter.UpdatedAt)
+		err := rows.Scan(&shelter.ID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining, &shelter.Occupancy, &shelter.EmergencyNeeds, &shelter.DisasterID, &shelter.Latitude, &shelter.Longitude, &shelter.LocationAccuracy, &shelter.CreatedAt, &shelter.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		setOccupancyPercentage(&shelter)
+		shelters = append(shelters, shelter)
+	}
+	return shelters, nil
+}
+
+func GetSheltersByArea(db *sql.DB, filter structs.GeoFilter) ([]structs.Shelter, error) {
+	distanceColumn, where, order, values := buildGeoQuery(filter)
+	query := `SELECT id, name, location, capacity_total, capacity_remaining, (SELECT COUNT(*) FROM refugees r WHERE r.shelter_id = shelters.id) AS occupancy, emergency_needs, disaster_id, latitude, longitude, location_accuracy, ` + distanceColumn + ` AS distance_km, created_at, updated_at
+	          FROM shelters WHERE ` + where + ` ORDER BY ` + order
+	rows, err := db.Query(query, values...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shelters []structs.Shelter
+	for rows.Next() {
+		var shelter structs.Shelter
+		err := rows.Scan(&shelter.ID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining, &shelter.Occupancy, &shelter.EmergencyNeeds, &shelter.DisasterID, &shelter.Latitude, &shelter.Longitude, &shelter.LocationAccuracy, &shelter.DistanceKm, &shelter.CreatedAt, &shelter.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
+		setOccupancyPercentage(&shelter)
 		shelters = append(shelters, shelter)
 	}
 	return shelters, nil
 }
 
 func GetShelterByID(db *sql.DB, id int) (structs.Shelter, error) {
-	query := `SELECT id, name, location, capacity_total, capacity_remaining, emergency_needs, disaster_id, created_at, updated_at FROM shelters WHERE id = $1`
+	query := `SELECT id, name, location, capacity_total, capacity_remaining, (SELECT COUNT(*) FROM refugees r WHERE r.shelter_id = shelters.id) AS occupancy, emergency_needs, disaster_id, latitude, longitude, location_accuracy, created_at, updated_at FROM shelters WHERE id = $1`
 	var shelter structs.Shelter
-	err := db.QueryRow(query, id).Scan(&shelter.ID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining, &shelter.EmergencyNeeds, &shelter.DisasterID, &shelter.CreatedAt, &shelter.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&shelter.ID, &shelter.Name, &shelter.Location, &shelter.CapacityTotal, &shelter.CapacityRemaining, &shelter.Occupancy, &shelter.EmergencyNeeds, &shelter.DisasterID, &shelter.Latitude, &shelter.Longitude, &shelter.LocationAccuracy, &shelter.CreatedAt, &shelter.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -53,6 +82,7 @@ func GetShelterByID(db *sql.DB, id int) (structs.Shelter, error) {
 		}
 		return shelter, err
 	}
+	setOccupancyPercentage(&shelter)
 	return shelter, nil
 }
 
@@ -62,11 +92,15 @@ func isShelterExists(db *sql.DB, id int) bool {
 	return err == nil
 }
 
-func UpdateShelter(db *sql.DB, shelter structs.Shelter) error {
+func UpdateShelter(db *sql.DB, shelter structs.Shelter, allowOverCapacity bool) error {
 	if !isShelterExists(db, shelter.ID) {
 		return errors.New("shelter not found")
 	}
 
+	if err := validateLocation(shelter.Latitude, shelter.Longitude, shelter.LocationAccuracy); err != nil {
+		return err
+	}
+
 	var updateFields []string
 	var values []interface{}
 	counter := 1
@@ -86,11 +120,6 @@ func UpdateShelter(db *sql.DB, shelter structs.Shelter) error {
 		values = append(values, shelter.CapacityTotal)
 		counter++
 	}
-	if shelter.CapacityRemaining != 0 {
-		updateFields = append(updateFields, "capacity_remaining = $"+strconv.Itoa(counter))
-		values = append(values, shelter.CapacityRemaining)
-		counter++
-	}
 	if shelter.EmergencyNeeds != "" {
 		updateFields = append(updateFields, "emergency_needs = $"+strconv.Itoa(counter))
 		values = append(values, shelter.EmergencyNeeds)
@@ -101,6 +130,16 @@ func UpdateShelter(db *sql.DB, shelter structs.Shelter) error {
 		values = append(values, shelter.DisasterID)
 		counter++
 	}
+	if shelter.Latitude != nil && shelter.Longitude != nil {
+		updateFields = append(updateFields, "latitude = $"+strconv.Itoa(counter), "longitude = $"+strconv.Itoa(counter+1))
+		values = append(values, shelter.Latitude, shelter.Longitude)
+		counter += 2
+	}
+	if shelter.LocationAccuracy != nil {
+		updateFields = append(updateFields, "location_accuracy = $"+strconv.Itoa(counter))
+		values = append(values, shelter.LocationAccuracy)
+		counter++
+	}
 
 	if len(updateFields) == 0 {
 		return errors.New("tidak ada field yang dapat diperbarui")
@@ -110,11 +149,25 @@ func UpdateShelter(db *sql.DB, shelter structs.Shelter) error {
 	query := "UPDATE shelters SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
 	values = append(values, shelter.ID)
 
-	_, err := db.Exec(query, values...)
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query, values...)
+	if err != nil {
+		return err
+	}
+
+	// Kapasitas tidak boleh diturunkan di bawah jumlah pengungsi yang sudah menempati shelter
+	if shelter.CapacityTotal != 0 {
+		if err := syncShelterOccupancy(tx, []int{shelter.ID}, &shelter.ID, allowOverCapacity); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func DeleteShelter(db *sql.DB, id int) error {
@@ -149,7 +202,7 @@ func GetRefugeesByShelterID(db *sql.DB, shelterID int) ([]structs.Refugee, error
 
 func GetLogisticsByShelterID(db *sql.DB, shelterID int) ([]structs.Logistic, error) {
 	var logistics []structs.Logistic
-	query := `SELECT id, type, quantity, status, disaster_id, created_at, updated_at 
+	query := `SELECT id, type, quantity, status, disaster_id, item_id, COALESCE(unit, ''), COALESCE(location, ''), latitude, longitude, created_at, updated_at 
 	          FROM logistics WHERE disaster_id IN (SELECT disaster_id FROM shelters WHERE id = $1)`
 	rows, err := db.Query(query, shelterID)
 	if err != nil {
@@ -159,7 +212,7 @@ func GetLogisticsByShelterID(db *sql.DB, shelterID int) ([]structs.Logistic, err
 
 	for rows.Next() {
 		var logistic structs.Logistic
-		err := rows.Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.CreatedAt, &logistic.UpdatedAt)
+		err := rows.Scan(&logistic.ID, &logistic.Type, &logistic.Quantity, &logistic.Status, &logistic.DisasterID, &logistic.ItemID, &logistic.Unit, &logistic.Location, &logistic.Latitude, &logistic.Longitude, &logistic.CreatedAt, &logistic.UpdatedAt)
 		if err != nil {
 			return logistics, err
 		}
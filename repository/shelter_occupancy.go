@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+)
+
+func setOccupancyPercentage(shelter *structs.Shelter) {
+	if shelter.CapacityTotal > 0 {
+		shelter.OccupancyPercentage = math.Round(float64(shelter.Occupancy)/float64(shelter.CapacityTotal)*1000) / 10
+	}
+}
+
+func syncShelterOccupancy(tx *sql.Tx, shelterIDs []int, targetShelterID *int, allowOverCapacity bool) error {
+	sort.Ints(shelterIDs)
+
+	for i, shelterID := range shelterIDs {
+		if i > 0 && shelterIDs[i-1] == shelterID {
+			continue
+		}
+
+		var capacityTotal int
+		err := tx.QueryRow(`SELECT capacity_total FROM shelters WHERE id = $1 FOR UPDATE`, shelterID).Scan(&capacityTotal)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("shelter not found")
+			}
+			return err
+		}
+
+		var occupancy int
+		err = tx.QueryRow(`SELECT COUNT(*) FROM refugees WHERE shelter_id = $1`, shelterID).Scan(&occupancy)
+		if err != nil {
+			return err
+		}
+
+		if targetShelterID != nil && *targetShelterID == shelterID && occupancy > capacityTotal && !allowOverCapacity {
+			return errors.New("shelter over capacity")
+		}
+
+		remaining := capacityTotal - occupancy
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		_, err = tx.Exec(`UPDATE shelters SET capacity_remaining = $1, updated_at = NOW() WHERE id = $2`, remaining, shelterID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,552 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func normalizeSkills(skills []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill != "" && !seen[skill] {
+			seen[skill] = true
+			normalized = append(normalized, skill)
+		}
+	}
+	return normalized
+}
+
+func resolveShiftPlace(db *sql.DB, shift *structs.Shift) error {
+	if shift.ShelterID == nil {
+		return nil
+	}
+
+	var shelterDisasterID *int
+	var name, location string
+	err := db.QueryRow(`SELECT disaster_id, name, location FROM shelters WHERE id = $1`, *shift.ShelterID).Scan(&shelterDisasterID, &name, &location)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("shelter not found")
+		}
+		return err
+	}
+
+	if shift.DisasterID == 0 && shelterDisasterID != nil {
+		shift.DisasterID = *shelterDisasterID
+	}
+	if shelterDisasterID != nil && *shelterDisasterID != shift.DisasterID {
+		return errors.New("shelter not in shift disaster")
+	}
+	if shift.Location == "" {
+		shift.Location = name + " - " + location
+	}
+	return nil
+}
+
+func CreateShift(db *sql.DB, shift *structs.Shift) error {
+	if err := resolveShiftPlace(db, shift); err != nil {
+		return err
+	}
+	if shift.DisasterID == 0 || !isDisasterExists(db, shift.DisasterID) {
+		return errors.New("disaster not found")
+	}
+	if shift.Location == "" {
+		return errors.New("shift location required")
+	}
+	if !shift.EndTime.After(shift.StartTime) {
+		return errors.New("invalid shift time")
+	}
+	if shift.Headcount <= 0 {
+		return errors.New("invalid shift headcount")
+	}
+	requiredSkills, err := resolveSkillCodes(db, shift.RequiredSkills)
+	if err != nil {
+		return err
+	}
+	shift.RequiredSkills = requiredSkills
+	shift.RequiredCertifications, err = resolveRequiredCertifications(db, shift.RequiredCertifications, shift.RequiredSkills)
+	if err != nil {
+		return err
+	}
+
+	err = db.QueryRow(`INSERT INTO volunteer_shifts (disaster_id, shelter_id, location, start_time, end_time, required_skills, required_certifications, headcount, note, created_by, created_at, updated_at)
+	                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, NOW(), NOW()) RETURNING id, created_at, updated_at`,
+		shift.DisasterID, shift.ShelterID, shift.Location, shift.StartTime, shift.EndTime, pq.Array(shift.RequiredSkills), pq.Array(shift.RequiredCertifications),
+		shift.Headcount, shift.Note, shift.CreatedBy).
+		Scan(&shift.ID, &shift.CreatedAt, &shift.UpdatedAt)
+	return err
+}
+
+const shiftSelectQuery = `SELECT s.id, s.disaster_id, s.shelter_id, COALESCE(sh.name, ''), s.location, s.start_time, s.end_time, s.required_skills, s.required_certifications, s.headcount,
+                                 (SELECT COUNT(*) FROM shift_assignments a WHERE a.shift_id = s.id), COALESCE(s.note, ''), s.created_by, s.created_at, s.updated_at
+                          FROM volunteer_shifts s
+                          LEFT JOIN shelters sh ON sh.id = s.shelter_id`
+
+func scanShifts(rows *sql.Rows) ([]structs.Shift, error) {
+	shifts := []structs.Shift{}
+	for rows.Next() {
+		var shift structs.Shift
+		err := rows.Scan(&shift.ID, &shift.DisasterID, &shift.ShelterID, &shift.ShelterName, &shift.Location, &shift.StartTime, &shift.EndTime,
+			pq.Array(&shift.RequiredSkills), pq.Array(&shift.RequiredCertifications), &shift.Headcount, &shift.AssignedCount, &shift.Note, &shift.CreatedBy, &shift.CreatedAt, &shift.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts, nil
+}
+
+func attachShiftAssignments(db *sql.DB, shifts []structs.Shift) error {
+	if len(shifts) == 0 {
+		return nil
+	}
+
+	positions := make(map[int]int)
+	ids := make([]int64, 0, len(shifts))
+	for i, shift := range shifts {
+		positions[shift.ID] = i
+		ids = append(ids, int64(shift.ID))
+	}
+
+	rows, err := db.Query(`SELECT a.id, a.shift_id, a.volunteer_id, COALESCE(u.name, ''), v.skill, a.source, a.assigned_by, a.created_at
+	                       FROM shift_assignments a
+	                       JOIN volunteers v ON v.id = a.volunteer_id
+	                       LEFT JOIN users u ON u.id = v.user_id
+	                       WHERE a.shift_id = ANY($1) ORDER BY a.shift_id, a.created_at, a.id`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var assignment structs.ShiftAssignment
+		err := rows.Scan(&assignment.ID, &assignment.ShiftID, &assignment.VolunteerID, &assignment.VolunteerName, &assignment.Skill,
+			&assignment.Source, &assignment.AssignedBy, &assignment.CreatedAt)
+		if err != nil {
+			return err
+		}
+		position := positions[assignment.ShiftID]
+		shifts[position].Assignments = append(shifts[position].Assignments, assignment)
+	}
+	return nil
+}
+
+func GetShifts(db *sql.DB, disasterID, shelterID *int, from, to time.Time) ([]structs.Shift, error) {
+	rows, err := db.Query(shiftSelectQuery+`
+	                       WHERE s.end_time > $1 AND s.start_time < $2
+	                         AND ($3::INT IS NULL OR s.disaster_id = $3) AND ($4::INT IS NULL OR s.shelter_id = $4)
+	                       ORDER BY s.start_time, s.id`, from, to, disasterID, shelterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanShifts(rows)
+}
+
+func GetShiftByID(db *sql.DB, id int) (structs.Shift, error) {
+	rows, err := db.Query(shiftSelectQuery+` WHERE s.id = $1`, id)
+	if err != nil {
+		return structs.Shift{}, err
+	}
+	defer rows.Close()
+
+	shifts, err := scanShifts(rows)
+	if err != nil {
+		return structs.Shift{}, err
+	}
+	if len(shifts) == 0 {
+		return structs.Shift{}, errors.New("shift not found")
+	}
+	if err := attachShiftAssignments(db, shifts); err != nil {
+		return structs.Shift{}, err
+	}
+	return shifts[0], nil
+}
+
+func findOverlappingShift(tx *sql.Tx, volunteerID, shiftID int, start, end time.Time) (int, error) {
+	var conflictID int
+	err := tx.QueryRow(`SELECT s.id FROM shift_assignments a
+	                    JOIN volunteer_shifts s ON s.id = a.shift_id
+	                    WHERE a.volunteer_id = $1 AND s.id <> $2 AND s.start_time < $4 AND s.end_time > $3
+	                    ORDER BY s.start_time LIMIT 1`, volunteerID, shiftID, start, end).Scan(&conflictID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return conflictID, err
+}
+
+// ShiftOverlapError menyimpan shift lain milik relawan yang waktunya bertabrakan
+type ShiftOverlapError struct {
+	ShiftID int
+}
+
+func (e *ShiftOverlapError) Error() string {
+	return "shift overlap"
+}
+
+func assignVolunteerToShift(tx *sql.Tx, shiftID, volunteerID int, source string, assignedBy *int) (structs.ShiftAssignment, error) {
+	assignment := structs.ShiftAssignment{ShiftID: shiftID, VolunteerID: volunteerID, Source: source, AssignedBy: assignedBy}
+
+	var start, end time.Time
+	var headcount int
+	var requiredSkills, requiredCertifications []string
+	var ended bool
+	err := tx.QueryRow(`SELECT start_time, end_time, headcount, required_skills, required_certifications, end_time <= NOW() FROM volunteer_shifts WHERE id = $1 FOR UPDATE`, shiftID).
+		Scan(&start, &end, &headcount, pq.Array(&requiredSkills), pq.Array(&requiredCertifications), &ended)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return assignment, errors.New("shift not found")
+		}
+		return assignment, err
+	}
+	if ended {
+		return assignment, errors.New("shift already ended")
+	}
+
+	// Baris relawan dikunci agar dua pendaftaran bersamaan tidak lolos pemeriksaan bentrok
+	err = tx.QueryRow(`SELECT v.skill, COALESCE(u.name, '') FROM volunteers v LEFT JOIN users u ON u.id = v.user_id WHERE v.id = $1 FOR UPDATE OF v`, volunteerID).
+		Scan(&assignment.Skill, &assignment.VolunteerName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return assignment, errors.New("volunteer not found")
+		}
+		return assignment, err
+	}
+
+	var assigned bool
+	var assignedCount int
+	err = tx.QueryRow(`SELECT COALESCE(BOOL_OR(volunteer_id = $2), false), COUNT(*) FROM shift_assignments WHERE shift_id = $1`, shiftID, volunteerID).
+		Scan(&assigned, &assignedCount)
+	if err != nil {
+		return assignment, err
+	}
+	if assigned {
+		return assignment, errors.New("volunteer already assigned")
+	}
+	hasSkill, err := hasRequiredSkill(tx, volunteerID, requiredSkills)
+	if err != nil {
+		return assignment, err
+	}
+	if !hasSkill {
+		return assignment, errors.New("volunteer skill mismatch")
+	}
+	// Sertifikasi harus tetap berlaku sampai shift berakhir
+	if err := checkRequiredCertifications(tx, volunteerID, requiredCertifications, end); err != nil {
+		return assignment, err
+	}
+	if assignedCount >= headcount {
+		return assignment, errors.New("shift full")
+	}
+
+	conflictID, err := findOverlappingShift(tx, volunteerID, shiftID, start, end)
+	if err != nil {
+		return assignment, err
+	}
+	if conflictID != 0 {
+		return assignment, &ShiftOverlapError{ShiftID: conflictID}
+	}
+
+	err = tx.QueryRow(`INSERT INTO shift_assignments (shift_id, volunteer_id, source, assigned_by, created_at)
+	                   VALUES ($1, $2, $3, $4, NOW()) RETURNING id, created_at`, shiftID, volunteerID, source, assignedBy).
+		Scan(&assignment.ID, &assignment.CreatedAt)
+	return assignment, err
+}
+
+func AssignVolunteerToShift(db *sql.DB, shiftID, volunteerID int, assignedBy int) (structs.ShiftAssignment, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return structs.ShiftAssignment{}, err
+	}
+	defer tx.Rollback()
+
+	assignment, err := assignVolunteerToShift(tx, shiftID, volunteerID, "assigned", &assignedBy)
+	if err != nil {
+		return assignment, err
+	}
+	return assignment, tx.Commit()
+}
+
+// SignUpForShift memakai data relawan milik user, diutamakan yang terdaftar di bencana shift tersebut
+func SignUpForShift(db *sql.DB, shiftID, userID int) (structs.ShiftAssignment, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return structs.ShiftAssignment{}, err
+	}
+	defer tx.Rollback()
+
+	var volunteerID int
+	err = tx.QueryRow(`SELECT v.id FROM volunteers v
+	                   LEFT JOIN volunteer_shifts s ON s.id = $2
+	                   WHERE v.user_id = $1
+	                   ORDER BY (v.disaster_id = s.disaster_id) DESC NULLS LAST, v.id LIMIT 1`, userID, shiftID).Scan(&volunteerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return structs.ShiftAssignment{}, errors.New("user not volunteer")
+		}
+		return structs.ShiftAssignment{}, err
+	}
+
+	assignment, err := assignVolunteerToShift(tx, shiftID, volunteerID, "signup", &userID)
+	if err != nil {
+		return assignment, err
+	}
+	return assignment, tx.Commit()
+}
+
+func RemoveShiftAssignment(db *sql.DB, shiftID, volunteerID int) error {
+	result, err := db.Exec(`DELETE FROM shift_assignments WHERE shift_id = $1 AND volunteer_id = $2`, shiftID, volunteerID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("shift assignment not found")
+	}
+	return nil
+}
+
+func getShiftVolunteerIDs(tx *sql.Tx, shiftID int) ([]int, error) {
+	rows, err := tx.Query(`SELECT volunteer_id FROM shift_assignments WHERE shift_id = $1`, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var volunteerIDs []int
+	for rows.Next() {
+		var volunteerID int
+		if err := rows.Scan(&volunteerID); err != nil {
+			return nil, err
+		}
+		volunteerIDs = append(volunteerIDs, volunteerID)
+	}
+	return volunteerIDs, rows.Err()
+}
+
+func UpdateShift(db *sql.DB, id int, input structs.ShiftInput, startTime, endTime *time.Time) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current := structs.Shift{ID: id}
+	err = tx.QueryRow(`SELECT disaster_id, start_time, end_time, headcount, required_certifications, (SELECT COUNT(*) FROM shift_assignments WHERE shift_id = $1)
+	                   FROM volunteer_shifts WHERE id = $1 FOR UPDATE`, id).
+		Scan(&current.DisasterID, &current.StartTime, &current.EndTime, &current.Headcount, pq.Array(&current.RequiredCertifications), &current.AssignedCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("shift not found")
+		}
+		return err
+	}
+
+	var updateFields []string
+	var values []interface{}
+	counter := 1
+
+	if input.ShelterID != nil {
+		place := structs.Shift{DisasterID: current.DisasterID, ShelterID: input.ShelterID, Location: input.Location}
+		if err := resolveShiftPlace(db, &place); err != nil {
+			return err
+		}
+		updateFields = append(updateFields, "shelter_id = $"+strconv.Itoa(counter))
+		values = append(values, *input.ShelterID)
+		counter++
+		input.Location = place.Location
+	}
+	if input.Location != "" {
+		updateFields = append(updateFields, "location = $"+strconv.Itoa(counter))
+		values = append(values, input.Location)
+		counter++
+	}
+	if startTime != nil {
+		current.StartTime = *startTime
+		updateFields = append(updateFields, "start_time = $"+strconv.Itoa(counter))
+		values = append(values, *startTime)
+		counter++
+	}
+	if endTime != nil {
+		current.EndTime = *endTime
+		updateFields = append(updateFields, "end_time = $"+strconv.Itoa(counter))
+		values = append(values, *endTime)
+		counter++
+	}
+	// Sertifikasi wajib diturunkan ulang dari keahlian baru bila tidak diisi, sama seperti saat shift dibuat
+	certificationsChanged := input.RequiredSkills != nil || input.RequiredCertifications != nil
+	if input.RequiredSkills != nil {
+		requiredSkills, err := resolveSkillCodes(db, input.RequiredSkills)
+		if err != nil {
+			return err
+		}
+		updateFields = append(updateFields, "required_skills = $"+strconv.Itoa(counter))
+		values = append(values, pq.Array(requiredSkills))
+		counter++
+
+		current.RequiredCertifications, err = resolveRequiredCertifications(db, input.RequiredCertifications, requiredSkills)
+		if err != nil {
+			return err
+		}
+	} else if input.RequiredCertifications != nil {
+		current.RequiredCertifications = normalizeCertificationTypes(input.RequiredCertifications)
+	}
+	if certificationsChanged {
+		updateFields = append(updateFields, "required_certifications = $"+strconv.Itoa(counter))
+		values = append(values, pq.Array(current.RequiredCertifications))
+		counter++
+	}
+	if input.Headcount != 0 {
+		if input.Headcount < 0 {
+			return errors.New("invalid shift headcount")
+		}
+		if input.Headcount < current.AssignedCount {
+			return errors.New("shift headcount below assigned")
+		}
+		updateFields = append(updateFields, "headcount = $"+strconv.Itoa(counter))
+		values = append(values, input.Headcount)
+		counter++
+	}
+	if input.Note != "" {
+		updateFields = append(updateFields, "note = $"+strconv.Itoa(counter))
+		values = append(values, input.Note)
+		counter++
+	}
+
+	if len(updateFields) == 0 {
+		return errors.New("tidak ada field yang dapat diperbarui")
+	}
+	if !current.EndTime.After(current.StartTime) {
+		return errors.New("invalid shift time")
+	}
+
+	// Perubahan jam shift tidak boleh membuat relawan yang sudah terdaftar bentrok dengan shift lain
+	// atau kehilangan sertifikasi wajib yang berlaku sampai shift berakhir
+	if startTime != nil || endTime != nil || certificationsChanged {
+		volunteerIDs, err := getShiftVolunteerIDs(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if startTime != nil || endTime != nil {
+			for _, volunteerID := range volunteerIDs {
+				conflictID, err := findOverlappingShift(tx, volunteerID, id, current.StartTime, current.EndTime)
+				if err != nil {
+					return err
+				}
+				if conflictID != 0 {
+					return &ShiftOverlapError{ShiftID: conflictID}
+				}
+			}
+		}
+		if err := checkAssigneeCertifications(tx, volunteerIDs, current.RequiredCertifications, current.EndTime); err != nil {
+			return err
+		}
+	}
+
+	updateFields = append(updateFields, "updated_at = NOW()")
+	query := "UPDATE volunteer_shifts SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
+	values = append(values, id)
+
+	if _, err := tx.Exec(query, values...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func DeleteShift(db *sql.DB, id int) error {
+	result, err := db.Exec(`DELETE FROM volunteer_shifts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("shift not found")
+	}
+	return nil
+}
+
+func GetShelterRoster(db *sql.DB, shelterID int, from, to time.Time) ([]structs.Shift, error) {
+	if !isShelterExists(db, shelterID) {
+		return nil, errors.New("shelter not found")
+	}
+
+	shifts, err := GetShifts(db, nil, &shelterID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachShiftAssignments(db, shifts); err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+func GetVolunteerRoster(db *sql.DB, volunteerID int, from, to time.Time) ([]structs.Shift, error) {
+	rows, err := db.Query(shiftSelectQuery+`
+	                       JOIN shift_assignments a ON a.shift_id = s.id
+	                       WHERE a.volunteer_id = $1 AND s.end_time > $2 AND s.start_time < $3
+	                       ORDER BY s.start_time, s.id`, volunteerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanShifts(rows)
+}
+
+// GetShiftConflicts mencari relawan dengan shift yang bertabrakan dan shift mendatang yang kekurangan relawan
+func GetShiftConflicts(db *sql.DB, disasterID *int) ([]structs.ShiftConflict, error) {
+	conflicts := []structs.ShiftConflict{}
+
+	rows, err := db.Query(`SELECT s1.id, s2.id, a1.volunteer_id, COALESCE(u.name, ''), GREATEST(s1.start_time, s2.start_time), LEAST(s1.end_time, s2.end_time)
+	                       FROM shift_assignments a1
+	                       JOIN shift_assignments a2 ON a2.volunteer_id = a1.volunteer_id AND a2.shift_id > a1.shift_id
+	                       JOIN volunteer_shifts s1 ON s1.id = a1.shift_id
+	                       JOIN volunteer_shifts s2 ON s2.id = a2.shift_id
+	                       JOIN volunteers v ON v.id = a1.volunteer_id
+	                       LEFT JOIN users u ON u.id = v.user_id
+	                       WHERE s1.start_time < s2.end_time AND s1.end_time > s2.start_time
+	                         AND ($1::INT IS NULL OR s1.disaster_id = $1 OR s2.disaster_id = $1)
+	                       ORDER BY 5, 1`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		conflict := structs.ShiftConflict{Type: "overlap"}
+		var conflictingShiftID, volunteerID int
+		err := rows.Scan(&conflict.ShiftID, &conflictingShiftID, &volunteerID, &conflict.VolunteerName, &conflict.StartTime, &conflict.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		conflict.ConflictingShiftID = &conflictingShiftID
+		conflict.VolunteerID = &volunteerID
+		conflicts = append(conflicts, conflict)
+	}
+
+	understaffed, err := db.Query(`SELECT s.id, s.start_time, s.end_time, s.headcount, COUNT(a.id)
+	                               FROM volunteer_shifts s
+	                               LEFT JOIN shift_assignments a ON a.shift_id = s.id
+	                               WHERE s.end_time > NOW() AND ($1::INT IS NULL OR s.disaster_id = $1)
+	                               GROUP BY s.id HAVING COUNT(a.id) < s.headcount
+	                               ORDER BY s.start_time, s.id`, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer understaffed.Close()
+
+	for understaffed.Next() {
+		conflict := structs.ShiftConflict{Type: "understaffed"}
+		err := understaffed.Scan(&conflict.ShiftID, &conflict.StartTime, &conflict.EndTime, &conflict.Headcount, &conflict.AssignedCount)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,436 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var skillCodePattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Tingkat kemahiran dipakai sebagai bobot saat mencocokkan relawan
+var proficiencyLevels = map[string]int{
+	"beginner":     1,
+	"intermediate": 2,
+	"advanced":     3,
+	"expert":       4,
+}
+
+// Keahlian default untuk laporan darurat berdasarkan kategori triase
+var triageSkills = map[string][]string{
+	"immediate": {"medical", "first_aid"},
+	"delayed":   {"first_aid"},
+	"minor":     {"first_aid"},
+	"deceased":  {"search_and_rescue"},
+}
+
+// Bobot komponen skor pencocokan relawan, totalnya 1
+const (
+	matchSkillWeight    = 0.45
+	matchDistanceWeight = 0.25
+	matchStatusWeight   = 0.15
+	matchWorkloadWeight = 0.15
+)
+
+// Sama dengan fungsi skill_code di database
+func skillCode(value string) string {
+	return strings.Trim(skillCodePattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(value)), "_"), "_")
+}
+
+func isValidProficiency(proficiency string) bool {
+	_, ok := proficiencyLevels[proficiency]
+	return ok
+}
+
+func GetSkills(db *sql.DB) ([]structs.Skill, error) {
+	rows, err := db.Query(`SELECT id, code, name, category, COALESCE(required_certification, ''), created_at FROM skills ORDER BY category, name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []structs.Skill{}
+	for rows.Next() {
+		var skill structs.Skill
+		if err := rows.Scan(&skill.ID, &skill.Code, &skill.Name, &skill.Category, &skill.RequiredCertification, &skill.CreatedAt); err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, rows.Err()
+}
+
+func CreateSkill(db *sql.DB, skill *structs.Skill) error {
+	skill.Name = strings.TrimSpace(skill.Name)
+	if skill.Code == "" {
+		skill.Code = skill.Name
+	}
+	skill.Code = skillCode(skill.Code)
+	if skill.Code == "" || skill.Name == "" {
+		return errors.New("skill code required")
+	}
+	if skill.Category == "" {
+		skill.Category = "lainnya"
+	}
+	skill.RequiredCertification = skillCode(skill.RequiredCertification)
+
+	err := db.QueryRow(`INSERT INTO skills (code, name, category, required_certification, created_at) VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+	                    ON CONFLICT (code) DO NOTHING RETURNING id, created_at`, skill.Code, skill.Name, skill.Category, skill.RequiredCertification).
+		Scan(&skill.ID, &skill.CreatedAt)
+	if err == sql.ErrNoRows {
+		return errors.New("skill already exists")
+	}
+	return err
+}
+
+// Keahlian bisa ditulis sebagai kode atau nama, hasilnya selalu kode taksonomi
+func resolveSkillCodes(db *sql.DB, skills []string) ([]string, error) {
+	codes := []string{}
+	seen := make(map[string]bool)
+	for _, skill := range normalizeSkills(skills) {
+		var code string
+		err := db.QueryRow(`SELECT code FROM skills WHERE code = $1 OR LOWER(name) = $2 ORDER BY code = $1 DESC LIMIT 1`, skillCode(skill), skill).Scan(&code)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("unknown skill")
+			}
+			return nil, err
+		}
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// Relawan cukup memiliki salah satu keahlian yang dibutuhkan
+func hasRequiredSkill(tx *sql.Tx, volunteerID int, requiredSkills []string) (bool, error) {
+	if len(requiredSkills) == 0 {
+		return true, nil
+	}
+	var exists bool
+	err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM volunteer_skills vs JOIN skills k ON k.id = vs.skill_id
+	                    WHERE vs.volunteer_id = $1 AND k.code = ANY($2))`, volunteerID, pq.Array(requiredSkills)).Scan(&exists)
+	return exists, err
+}
+
+// Keahlian teks bebas pada data relawan ikut ditautkan bila ada di taksonomi
+func linkVolunteerSkill(db *sql.DB, volunteerID int, skill string) error {
+	if strings.TrimSpace(skill) == "" {
+		return nil
+	}
+	_, err := db.Exec(`INSERT INTO volunteer_skills (volunteer_id, skill_id, created_at, updated_at)
+	                   SELECT $1, id, NOW(), NOW() FROM skills WHERE code = $2 OR LOWER(name) = LOWER(TRIM($3))
+	                   ON CONFLICT (volunteer_id, skill_id) DO NOTHING`, volunteerID, skillCode(skill), skill)
+	return err
+}
+
+func GetVolunteerSkills(db *sql.DB, volunteerID int) ([]structs.VolunteerSkill, error) {
+	var exists bool
+	if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM volunteers WHERE id = $1)`, volunteerID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("volunteer not found")
+	}
+
+	rows, err := db.Query(`SELECT k.id, k.code, k.name, k.category, vs.proficiency, vs.updated_at
+	                       FROM volunteer_skills vs JOIN skills k ON k.id = vs.skill_id
+	                       WHERE vs.volunteer_id = $1 ORDER BY vs.proficiency DESC, k.name`, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []structs.VolunteerSkill{}
+	for rows.Next() {
+		var skill structs.VolunteerSkill
+		if err := rows.Scan(&skill.SkillID, &skill.Code, &skill.Name, &skill.Category, &skill.Proficiency, &skill.UpdatedAt); err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, rows.Err()
+}
+
+// Mengganti seluruh keahlian relawan, kolom skill lama diisi keahlian pertama
+func SetVolunteerSkills(db *sql.DB, volunteerID int, inputs []structs.VolunteerSkillInput) ([]structs.VolunteerSkill, error) {
+	type resolvedSkill struct {
+		id          int
+		name        string
+		proficiency string
+	}
+
+	resolved := []resolvedSkill{}
+	seen := make(map[int]bool)
+	for _, input := range inputs {
+		if input.Proficiency == "" {
+			input.Proficiency = "intermediate"
+		}
+		if !isValidProficiency(input.Proficiency) {
+			return nil, errors.New("invalid skill proficiency")
+		}
+		codes, err := resolveSkillCodes(db, []string{input.Skill})
+		if err != nil {
+			return nil, err
+		}
+		if len(codes) == 0 {
+			return nil, errors.New("unknown skill")
+		}
+
+		var skill resolvedSkill
+		if err := db.QueryRow(`SELECT id, name FROM skills WHERE code = $1`, codes[0]).Scan(&skill.id, &skill.name); err != nil {
+			return nil, err
+		}
+		if seen[skill.id] {
+			continue
+		}
+		seen[skill.id] = true
+		skill.proficiency = input.Proficiency
+		resolved = append(resolved, skill)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM volunteers WHERE id = $1)`, volunteerID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("volunteer not found")
+	}
+
+	if _, err := tx.Exec(`DELETE FROM volunteer_skills WHERE volunteer_id = $1`, volunteerID); err != nil {
+		return nil, err
+	}
+	for _, skill := range resolved {
+		_, err := tx.Exec(`INSERT INTO volunteer_skills (volunteer_id, skill_id, proficiency, created_at, updated_at)
+		                   VALUES ($1, $2, $3, NOW(), NOW())`, volunteerID, skill.id, skill.proficiency)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(resolved) > 0 {
+		if _, err := tx.Exec(`UPDATE volunteers SET skill = $1, updated_at = NOW() WHERE id = $2`, resolved[0].name, volunteerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return GetVolunteerSkills(db, volunteerID)
+}
+
+type matchTarget struct {
+	disasterID             *int
+	taskID                 int
+	latitude               *float64
+	longitude              *float64
+	requiredSkills         []string
+	requiredCertifications []string
+	validUntil             time.Time
+}
+
+// Koordinat tugas diambil dari laporan darurat, lalu shelter, lalu titik bencana
+func MatchVolunteersForTask(db *sql.DB, taskID int, skills []string, limit int) (structs.VolunteerMatchResult, error) {
+	var status string
+	var requiredSkills []string
+	target := matchTarget{taskID: taskID}
+	var reportLat, reportLon, shelterLat, shelterLon, disasterLat, disasterLon *float64
+	err := db.QueryRow(`SELECT t.status, t.disaster_id, t.required_skills, t.required_certifications, GREATEST(NOW(), COALESCE(t.due_at, NOW())),
+	                           er.latitude, er.longitude, sh.latitude, sh.longitude, d.latitude, d.longitude
+	                    FROM tasks t
+	                    LEFT JOIN emergency_reports er ON er.id = t.emergency_report_id
+	                    LEFT JOIN shelters sh ON sh.id = t.shelter_id
+	                    LEFT JOIN disasters d ON d.id = t.disaster_id
+	                    WHERE t.id = $1`, taskID).
+		Scan(&status, &target.disasterID, pq.Array(&requiredSkills), pq.Array(&target.requiredCertifications), &target.validUntil, &reportLat, &reportLon, &shelterLat, &shelterLon, &disasterLat, &disasterLon)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return structs.VolunteerMatchResult{}, errors.New("task not found")
+		}
+		return structs.VolunteerMatchResult{}, err
+	}
+	if isTaskClosed(status) {
+		return structs.VolunteerMatchResult{}, errors.New("task closed")
+	}
+
+	switch {
+	case reportLat != nil && reportLon != nil:
+		target.latitude, target.longitude = reportLat, reportLon
+	case shelterLat != nil && shelterLon != nil:
+		target.latitude, target.longitude = shelterLat, shelterLon
+	case disasterLat != nil && disasterLon != nil:
+		target.latitude, target.longitude = disasterLat, disasterLon
+	}
+
+	target.requiredSkills = requiredSkills
+	if skills != nil {
+		if target.requiredSkills, err = resolveSkillCodes(db, skills); err != nil {
+			return structs.VolunteerMatchResult{}, err
+		}
+	}
+	return rankVolunteers(db, target, limit)
+}
+
+// Tanpa parameter skills, kebutuhan keahlian laporan mengikuti kategori triase
+func MatchVolunteersForEmergencyReport(db *sql.DB, reportID int, skills []string, limit int) (structs.VolunteerMatchResult, error) {
+	var target matchTarget
+	var triageCategory string
+	var disasterLat, disasterLon *float64
+	err := db.QueryRow(`SELECT er.disaster_id, er.latitude, er.longitude, COALESCE(er.triage_category::TEXT, ''), d.latitude, d.longitude, NOW()
+	                    FROM emergency_reports er
+	                    LEFT JOIN disasters d ON d.id = er.disaster_id
+	                    WHERE er.id = $1`, reportID).
+		Scan(&target.disasterID, &target.latitude, &target.longitude, &triageCategory, &disasterLat, &disasterLon, &target.validUntil)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return structs.VolunteerMatchResult{}, errors.New("emergency report not found")
+		}
+		return structs.VolunteerMatchResult{}, err
+	}
+	if target.latitude == nil || target.longitude == nil {
+		target.latitude, target.longitude = disasterLat, disasterLon
+	}
+
+	if skills == nil {
+		skills = triageSkills[triageCategory]
+	}
+	if target.requiredSkills, err = resolveSkillCodes(db, skills); err != nil {
+		return structs.VolunteerMatchResult{}, err
+	}
+	if target.requiredCertifications, err = resolveRequiredCertifications(db, nil, target.requiredSkills); err != nil {
+		return structs.VolunteerMatchResult{}, err
+	}
+	return rankVolunteers(db, target, limit)
+}
+
+// Relawan yang sudah selesai bertugas, terikat bencana lain, sudah ditugaskan, atau belum memiliki sertifikasi wajib tidak ikut diperingkat
+func rankVolunteers(db *sql.DB, target matchTarget, limit int) (structs.VolunteerMatchResult, error) {
+	result := structs.VolunteerMatchResult{
+		RequiredSkills:         target.requiredSkills,
+		RequiredCertifications: target.requiredCertifications,
+		Latitude:               target.latitude,
+		Longitude:              target.longitude,
+		Matches:                []structs.VolunteerMatch{},
+	}
+
+	rows, err := db.Query(`SELECT v.id, COALESCE(u.name, ''), v.status, v.latitude, v.longitude,
+	                              (SELECT COUNT(*) FROM task_assignees a JOIN tasks t ON t.id = a.task_id
+	                               WHERE a.volunteer_id = v.id AND t.status IN ('assigned', 'in_progress', 'blocked')),
+	                              (SELECT COUNT(*) FROM shift_assignments sa JOIN volunteer_shifts s ON s.id = sa.shift_id
+	                               WHERE sa.volunteer_id = v.id AND s.end_time > NOW() AND s.start_time < NOW() + INTERVAL '24 hours')
+	                       FROM volunteers v
+	                       LEFT JOIN users u ON u.id = v.user_id
+	                       WHERE v.status <> 'completed'
+	                         AND ($1::INT IS NULL OR v.disaster_id IS NULL OR v.disaster_id = $1)
+	                         AND NOT EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = $2 AND a.volunteer_id = v.id)
+	                         AND NOT EXISTS (SELECT 1 FROM UNNEST($3::TEXT[]) r
+	                                         WHERE NOT EXISTS (SELECT 1 FROM volunteer_certifications c
+	                                                           WHERE c.volunteer_id = v.id AND c.certification_type = r AND `+validCertificationCondition(4)+`))`,
+		target.disasterID, target.taskID, pq.Array(target.requiredCertifications), target.validUntil)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	matches := []structs.VolunteerMatch{}
+	index := make(map[int]int)
+	for rows.Next() {
+		var match structs.VolunteerMatch
+		var latitude, longitude *float64
+		if err := rows.Scan(&match.VolunteerID, &match.VolunteerName, &match.Status, &latitude, &longitude, &match.ActiveTasks, &match.UpcomingShifts); err != nil {
+			return result, err
+		}
+		match.MatchedSkills = []structs.VolunteerSkill{}
+
+		match.DistanceScore = 0.5
+		if target.latitude != nil && target.longitude != nil && latitude != nil && longitude != nil {
+			distance := HaversineKm(*target.latitude, *target.longitude, *latitude, *longitude)
+			distance = math.Round(distance*100) / 100
+			match.DistanceKm = &distance
+			match.DistanceScore = 1 / (1 + distance/10)
+		}
+
+		match.StatusScore = 0.3
+		if match.Status == "available" {
+			match.StatusScore = 1
+		}
+		match.WorkloadScore = 1 / float64(1+match.ActiveTasks+match.UpcomingShifts)
+		match.SkillScore = 1
+
+		index[match.VolunteerID] = len(matches)
+		matches = append(matches, match)
+	}
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	if len(target.requiredSkills) > 0 && len(matches) > 0 {
+		for i := range matches {
+			matches[i].SkillScore = 0
+		}
+
+		skillRows, err := db.Query(`SELECT vs.volunteer_id, k.id, k.code, k.name, k.category, vs.proficiency, vs.updated_at
+		                            FROM volunteer_skills vs JOIN skills k ON k.id = vs.skill_id
+		                            WHERE k.code = ANY($1)`, pq.Array(target.requiredSkills))
+		if err != nil {
+			return result, err
+		}
+		defer skillRows.Close()
+
+		for skillRows.Next() {
+			var volunteerID int
+			var skill structs.VolunteerSkill
+			if err := skillRows.Scan(&volunteerID, &skill.SkillID, &skill.Code, &skill.Name, &skill.Category, &skill.Proficiency, &skill.UpdatedAt); err != nil {
+				return result, err
+			}
+			i, ok := index[volunteerID]
+			if !ok {
+				continue
+			}
+			matches[i].MatchedSkills = append(matches[i].MatchedSkills, skill)
+			// Rata-rata kemahiran terhadap semua keahlian yang dibutuhkan, expert bernilai penuh
+			matches[i].SkillScore += float64(proficiencyLevels[skill.Proficiency]) / 4 / float64(len(target.requiredSkills))
+		}
+		if err := skillRows.Err(); err != nil {
+			return result, err
+		}
+	}
+
+	for i := range matches {
+		match := &matches[i]
+		match.Score = matchSkillWeight*match.SkillScore + matchDistanceWeight*match.DistanceScore +
+			matchStatusWeight*match.StatusScore + matchWorkloadWeight*match.WorkloadScore
+		match.SkillScore = math.Round(match.SkillScore*1000) / 1000
+		match.DistanceScore = math.Round(match.DistanceScore*1000) / 1000
+		match.StatusScore = math.Round(match.StatusScore*1000) / 1000
+		match.WorkloadScore = math.Round(match.WorkloadScore*1000) / 1000
+		match.Score = math.Round(match.Score*1000) / 1000
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].VolunteerID < matches[j].VolunteerID
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	result.Matches = matches
+	return result, nil
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func lockLogistics(tx *sql.Tx, logisticIDs []int) error {
+	ids := make([]int64, 0, len(logisticIDs))
+	for _, id := range logisticIDs {
+		ids = append(ids, int64(id))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	_, err := tx.Exec(`SELECT id FROM logistics WHERE id = ANY($1) ORDER BY id FOR UPDATE`, pq.Array(ids))
+	return err
+}
+
+func getStockBalance(tx *sql.Tx, logisticID int) (int, error) {
+	var exists bool
+	err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM logistics WHERE id = $1)`, logisticID).Scan(&exists)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, errors.New("logistic not found")
+	}
+
+	var balance int
+	err = tx.QueryRow(`SELECT COALESCE(SUM(quantity), 0) FROM stock_movements WHERE logistic_id = $1`, logisticID).Scan(&balance)
+	return balance, err
+}
+
+func refreshLogisticStock(tx *sql.Tx, logisticID, balance int) error {
+	_, err := tx.Exec(`UPDATE logistics SET quantity = $1,
+	                   status = CASE
+	                       WHEN $1 <= 0 THEN 'out_of_stock'
+	                       WHEN EXISTS (SELECT 1 FROM stock_movements WHERE logistic_id = $2 AND movement_type = 'dispatch') THEN 'distributed'
+	                       ELSE 'available'
+	                   END::logistics_status,
+	                   updated_at = NOW()
+	                   WHERE id = $2`, balance, logisticID)
+	return err
+}
+
+func applyStockMovement(tx *sql.Tx, logisticID int, movementType string, quantity int, batchID *int, distributionLogID *int, note string, recordedBy *int) (structs.StockMovement, error) {
+	movement := structs.StockMovement{LogisticID: logisticID, MovementType: movementType, Quantity: quantity, BatchID: batchID, DistributionLogID: distributionLogID, Note: note, RecordedBy: recordedBy}
+	if quantity == 0 {
+		return movement, errors.New("invalid stock quantity")
+	}
+
+	if err := lockLogistics(tx, []int{logisticID}); err != nil {
+		return movement, err
+	}
+
+	balance, err := getStockBalance(tx, logisticID)
+	if err != nil {
+		return movement, err
+	}
+	if balance+quantity < 0 {
+		return movement, errors.New("insufficient stock")
+	}
+	movement.BalanceAfter = balance + quantity
+
+	err = tx.QueryRow(`INSERT INTO stock_movements (logistic_id, movement_type, quantity, balance_after, batch_id, distribution_log_id, note, recorded_by, created_at)
+	                   VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, NOW()) RETURNING id, created_at`,
+		logisticID, movementType, quantity, movement.BalanceAfter, batchID, distributionLogID, note, recordedBy).
+		Scan(&movement.ID, &movement.CreatedAt)
+	if err != nil {
+		return movement, err
+	}
+
+	return movement, refreshLogisticStock(tx, logisticID, movement.BalanceAfter)
+}
+
+func receiveStock(tx *sql.Tx, logisticID int, movementType string, quantity int, batch structs.StockBatchInput, distributionLogID *int, note string, recordedBy *int) (structs.StockMovement, error) {
+	if quantity <= 0 {
+		return structs.StockMovement{}, errors.New("invalid stock quantity")
+	}
+	if err := lockLogistics(tx, []int{logisticID}); err != nil {
+		return structs.StockMovement{}, err
+	}
+
+	received := 0
+	if movementType == "receipt" {
+		received = quantity
+	}
+
+	var batchID int
+	if batch.BatchID != nil {
+		result, err := tx.Exec(`UPDATE logistic_batches SET quantity_remaining = quantity_remaining + $1, quantity_received = quantity_received + $2
+		                        WHERE id = $3 AND logistic_id = $4`, quantity, received, *batch.BatchID, logisticID)
+		if err != nil {
+			return structs.StockMovement{}, err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return structs.StockMovement{}, errors.New("batch not found")
+		}
+		batchID = *batch.BatchID
+	} else {
+		batchNumber := strings.TrimSpace(batch.BatchNumber)
+		if batchNumber == "" {
+			batchNumber = "LOT-" + time.Now().Format("20060102")
+		}
+
+		var expiryDate *time.Time
+		err := tx.QueryRow(`SELECT id, expiry_date FROM logistic_batches WHERE logistic_id = $1 AND batch_number = $2 FOR UPDATE`, logisticID, batchNumber).Scan(&batchID, &expiryDate)
+		switch {
+		case err == sql.ErrNoRows:
+			err = tx.QueryRow(`INSERT INTO logistic_batches (logistic_id, batch_number, expiry_date, quantity_received, quantity_remaining, received_at)
+			                   VALUES ($1, $2, $3, $4, $5, NOW()) RETURNING id`, logisticID, batchNumber, batch.ExpiryDate, received, quantity).Scan(&batchID)
+			if err != nil {
+				return structs.StockMovement{}, err
+			}
+		case err != nil:
+			return structs.StockMovement{}, err
+		default:
+			if batch.ExpiryDate != nil && (expiryDate == nil || !expiryDate.Equal(*batch.ExpiryDate)) {
+				return structs.StockMovement{}, errors.New("batch expiry mismatch")
+			}
+			_, err = tx.Exec(`UPDATE logistic_batches SET quantity_remaining = quantity_remaining + $1, quantity_received = quantity_received + $2 WHERE id = $3`,
+				quantity, received, batchID)
+			if err != nil {
+				return structs.StockMovement{}, err
+			}
+		}
+	}
+
+	return applyStockMovement(tx, logisticID, movementType, quantity, &batchID, distributionLogID, note, recordedBy)
+}
+
+func consumeStock(tx *sql.Tx, logisticID int, movementType string, quantity int, batchID *int, distributionLogID *int, note string, recordedBy *int) ([]structs.StockMovement, error) {
+	if quantity <= 0 {
+		return nil, errors.New("invalid stock quantity")
+	}
+	if err := lockLogistics(tx, []int{logisticID}); err != nil {
+		return nil, err
+	}
+
+	// FEFO: batch dengan tanggal kedaluwarsa terdekat dipakai lebih dulu, batch kedaluwarsa tidak didistribusikan
+	rows, err := tx.Query(`SELECT id, quantity_remaining FROM logistic_batches
+	                       WHERE logistic_id = $1 AND quantity_remaining > 0 AND ($2::INT IS NULL OR id = $2)
+	                         AND ($3 <> 'dispatch' OR expiry_date IS NULL OR expiry_date >= CURRENT_DATE)
+	                       ORDER BY expiry_date NULLS LAST, received_at, id FOR UPDATE`, logisticID, batchID, movementType)
+	if err != nil {
+		return nil, err
+	}
+
+	type batchStock struct {
+		id        int
+		remaining int
+	}
+	var batches []batchStock
+	available := 0
+	for rows.Next() {
+		var batch batchStock
+		if err := rows.Scan(&batch.id, &batch.remaining); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		batches = append(batches, batch)
+		available += batch.remaining
+	}
+	rows.Close()
+
+	if available < quantity {
+		return nil, errors.New("insufficient stock")
+	}
+
+	var movements []structs.StockMovement
+	left := quantity
+	for _, batch := range batches {
+		if left == 0 {
+			break
+		}
+		taken := min(batch.remaining, left)
+		_, err := tx.Exec(`UPDATE logistic_batches SET quantity_remaining = quantity_remaining - $1 WHERE id = $2`, taken, batch.id)
+		if err != nil {
+			return nil, err
+		}
+
+		id := batch.id
+		movement, err := applyStockMovement(tx, logisticID, movementType, -taken, &id, distributionLogID, note, recordedBy)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, movement)
+		left -= taken
+	}
+
+	return movements, nil
+}
+
+func restoreDistributionStock(tx *sql.Tx, distributionLogID, logisticID int, note string, recordedBy *int) error {
+	rows, err := tx.Query(`SELECT batch_id, -SUM(quantity) FROM stock_movements
+	                       WHERE distribution_log_id = $1 AND logistic_id = $2
+	                       GROUP BY batch_id HAVING SUM(quantity) < 0`, distributionLogID, logisticID)
+	if err != nil {
+		return err
+	}
+
+	type batchReturn struct {
+		batchID  *int
+		quantity int
+	}
+	var returns []batchReturn
+	for rows.Next() {
+		var item batchReturn
+		if err := rows.Scan(&item.batchID, &item.quantity); err != nil {
+			rows.Close()
+			return err
+		}
+		returns = append(returns, item)
+	}
+	rows.Close()
+
+	for _, item := range returns {
+		batch := structs.StockBatchInput{BatchID: item.batchID}
+		if item.batchID == nil {
+			batch.BatchNumber = "RETUR-" + strconv.Itoa(distributionLogID)
+		}
+		_, err := receiveStock(tx, logisticID, "adjustment", item.quantity, batch, &distributionLogID, note, recordedBy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func RecordStockMovement(db *sql.DB, logisticID int, input structs.StockMovementInput, batch structs.StockBatchInput, recordedBy int) ([]structs.StockMovement, error) {
+	if input.MovementType == "dispatch" {
+		return nil, errors.New("dispatch requires distribution log")
+	}
+	if input.MovementType != "receipt" && input.MovementType != "adjustment" && input.MovementType != "loss" {
+		return nil, errors.New("invalid stock movement type")
+	}
+	if input.MovementType != "adjustment" && input.Quantity <= 0 {
+		return nil, errors.New("invalid stock quantity")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	quantity, err := convertLogisticQuantity(tx, logisticID, input.Unit, input.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	var movements []structs.StockMovement
+	if input.MovementType == "loss" || quantity < 0 {
+		movements, err = consumeStock(tx, logisticID, input.MovementType, abs(quantity), batch.BatchID, nil, input.Note, &recordedBy)
+	} else {
+		var movement structs.StockMovement
+		movement, err = receiveStock(tx, logisticID, input.MovementType, quantity, batch, nil, input.Note, &recordedBy)
+		movements = []structs.StockMovement{movement}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return movements, tx.Commit()
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func GetStockLedger(db *sql.DB, logisticID int) (structs.StockLedger, error) {
+	logistic, err := GetLogisticByID(db, logisticID)
+	if err != nil {
+		return structs.StockLedger{}, err
+	}
+
+	ledger := structs.StockLedger{LogisticID: logistic.ID, Type: logistic.Type, Status: logistic.Status, Movements: []structs.StockMovement{}}
+	rows, err := db.Query(`SELECT id, logistic_id, movement_type, quantity, balance_after, batch_id, distribution_log_id, COALESCE(note, ''), recorded_by, created_at
+	                       FROM stock_movements WHERE logistic_id = $1 ORDER BY id`, logisticID)
+	if err != nil {
+		return ledger, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movement structs.StockMovement
+		err := rows.Scan(&movement.ID, &movement.LogisticID, &movement.MovementType, &movement.Quantity, &movement.BalanceAfter,
+			&movement.BatchID, &movement.DistributionLogID, &movement.Note, &movement.RecordedBy, &movement.CreatedAt)
+		if err != nil {
+			return ledger, err
+		}
+
+		switch movement.MovementType {
+		case "receipt":
+			ledger.Received += movement.Quantity
+		case "dispatch":
+			ledger.Dispatched -= movement.Quantity
+		case "adjustment":
+			ledger.Adjusted += movement.Quantity
+		case "loss":
+			ledger.Lost -= movement.Quantity
+		}
+		ledger.Balance += movement.Quantity
+		ledger.Movements = append(ledger.Movements, movement)
+	}
+
+	return ledger, nil
+}
+
+func GetLogisticBatches(db *sql.DB, logisticID int) ([]structs.LogisticBatch, error) {
+	if !isLogisticExists(db, logisticID) {
+		return nil, errors.New("logistic not found")
+	}
+
+	rows, err := db.Query(`SELECT id, logistic_id, batch_number, expiry_date, quantity_received, quantity_remaining, received_at
+	                       FROM logistic_batches WHERE logistic_id = $1 ORDER BY expiry_date NULLS LAST, received_at, id`, logisticID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := []structs.LogisticBatch{}
+	for rows.Next() {
+		var batch structs.LogisticBatch
+		err := rows.Scan(&batch.ID, &batch.LogisticID, &batch.BatchNumber, &batch.ExpiryDate, &batch.QuantityReceived, &batch.QuantityRemaining, &batch.ReceivedAt)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+func GetExpiringBatches(db *sql.DB, days int, disasterID *int) ([]structs.ExpiringBatch, error) {
+	query := `SELECT b.id, b.logistic_id, b.batch_number, b.expiry_date, b.quantity_received, b.quantity_remaining, b.received_at,
+	                 l.type, COALESCE(l.unit, ''), l.disaster_id, b.expiry_date - CURRENT_DATE
+	          FROM logistic_batches b JOIN logistics l ON l.id = b.logistic_id
+	          WHERE b.quantity_remaining > 0 AND b.expiry_date IS NOT NULL AND b.expiry_date <= CURRENT_DATE + $1::INT
+	            AND ($2::INT IS NULL OR l.disaster_id = $2)
+	          ORDER BY b.expiry_date, b.id`
+	rows, err := db.Query(query, days, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := []structs.ExpiringBatch{}
+	for rows.Next() {
+		var batch structs.ExpiringBatch
+		err := rows.Scan(&batch.ID, &batch.LogisticID, &batch.BatchNumber, &batch.ExpiryDate, &batch.QuantityReceived, &batch.QuantityRemaining, &batch.ReceivedAt,
+			&batch.LogisticType, &batch.Unit, &batch.DisasterID, &batch.DaysUntilExpiry)
+		if err != nil {
+			return nil, err
+		}
+		batch.Expired = batch.DaysUntilExpiry < 0
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
@@ -0,0 +1,547 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var taskStatuses = []string{"open", "assigned", "in_progress", "blocked", "done", "cancelled"}
+
+// Status assigned dan open saat relawan ditambah atau dilepas diatur otomatis
+var taskTransitions = map[string][]string{
+	"open":        {"in_progress", "cancelled"},
+	"assigned":    {"in_progress", "blocked", "cancelled"},
+	"in_progress": {"blocked", "done", "cancelled"},
+	"blocked":     {"in_progress", "cancelled"},
+}
+
+func isValidTaskTransition(from, to string) bool {
+	for _, next := range taskTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func isTaskClosed(status string) bool {
+	return status == "done" || status == "cancelled"
+}
+
+func recordTaskStatus(tx *sql.Tx, taskID int, status, note string, changedBy *int) error {
+	_, err := tx.Exec(`INSERT INTO task_status_history (task_id, status, note, changed_by, changed_at)
+	                   VALUES ($1, $2, NULLIF($3, ''), $4, NOW())`, taskID, status, note, changedBy)
+	return err
+}
+
+// Laporan darurat, shelter, dan distribusi yang ditautkan harus berada di bencana yang sama dengan tugas
+func resolveTaskLinks(db *sql.DB, task *structs.Task) error {
+	links := []struct {
+		id       *int
+		query    string
+		notFound string
+	}{
+		{task.EmergencyReportID, `SELECT disaster_id FROM emergency_reports WHERE id = $1`, "emergency report not found"},
+		{task.ShelterID, `SELECT disaster_id FROM shelters WHERE id = $1`, "shelter not found"},
+		{task.DistributionLogID, `SELECT l.disaster_id FROM distribution_logs d LEFT JOIN logistics l ON l.id = d.logistic_id WHERE d.id = $1`, "distribution log not found"},
+	}
+
+	for _, link := range links {
+		if link.id == nil {
+			continue
+		}
+		var disasterID *int
+		if err := db.QueryRow(link.query, *link.id).Scan(&disasterID); err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New(link.notFound)
+			}
+			return err
+		}
+		if disasterID == nil {
+			continue
+		}
+		if task.DisasterID == 0 {
+			task.DisasterID = *disasterID
+		}
+		if *disasterID != task.DisasterID {
+			return errors.New("task link not in disaster")
+		}
+	}
+	return nil
+}
+
+func CreateTask(db *sql.DB, task *structs.Task) error {
+	if strings.TrimSpace(task.Title) == "" {
+		return errors.New("task title required")
+	}
+	if task.Priority == "" {
+		task.Priority = "medium"
+	}
+	if !isValidNeedPriority(task.Priority) {
+		return errors.New("invalid need priority")
+	}
+	if err := resolveTaskLinks(db, task); err != nil {
+		return err
+	}
+	if task.DisasterID == 0 || !isDisasterExists(db, task.DisasterID) {
+		return errors.New("disaster not found")
+	}
+	requiredSkills, err := resolveSkillCodes(db, task.RequiredSkills)
+	if err != nil {
+		return err
+	}
+	task.RequiredSkills = requiredSkills
+	task.RequiredCertifications, err = resolveRequiredCertifications(db, task.RequiredCertifications, task.RequiredSkills)
+	if err != nil {
+		return err
+	}
+	task.Status = "open"
+	task.Assignees = []structs.TaskAssignee{}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`INSERT INTO tasks (disaster_id, emergency_report_id, shelter_id, distribution_log_id, title, description, priority, status, required_skills, required_certifications, due_at, created_by, created_at, updated_at)
+	                   VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10, $11, $12, NOW(), NOW()) RETURNING id, created_at, updated_at`,
+		task.DisasterID, task.EmergencyReportID, task.ShelterID, task.DistributionLogID, task.Title, task.Description, task.Priority, task.Status,
+		pq.Array(task.RequiredSkills), pq.Array(task.RequiredCertifications), task.DueAt, task.CreatedBy).
+		Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := recordTaskStatus(tx, task.ID, task.Status, "", task.CreatedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const taskSelectQuery = `SELECT t.id, t.disaster_id, t.emergency_report_id, t.shelter_id, t.distribution_log_id, t.title, COALESCE(t.description, ''), t.priority, t.status,
+                                t.required_skills, t.required_certifications, t.due_at, (t.due_at IS NOT NULL AND t.due_at < NOW() AND t.status NOT IN ('done', 'cancelled')),
+                                t.created_by, t.completed_at, t.created_at, t.updated_at
+                         FROM tasks t`
+
+// Urutan enum need_priority sudah dari critical ke low
+const taskOrder = ` ORDER BY t.priority, t.due_at NULLS LAST, t.id`
+
+func scanTasks(db *sql.DB, rows *sql.Rows) ([]structs.Task, error) {
+	tasks := []structs.Task{}
+	for rows.Next() {
+		var task structs.Task
+		err := rows.Scan(&task.ID, &task.DisasterID, &task.EmergencyReportID, &task.ShelterID, &task.DistributionLogID, &task.Title, &task.Description,
+			&task.Priority, &task.Status, pq.Array(&task.RequiredSkills), pq.Array(&task.RequiredCertifications), &task.DueAt, &task.Overdue, &task.CreatedBy, &task.CompletedAt, &task.CreatedAt, &task.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		task.Assignees = []structs.TaskAssignee{}
+		tasks = append(tasks, task)
+	}
+	rows.Close()
+
+	if len(tasks) == 0 {
+		return tasks, nil
+	}
+
+	positions := make(map[int]int)
+	ids := make([]int64, 0, len(tasks))
+	for i, task := range tasks {
+		positions[task.ID] = i
+		ids = append(ids, int64(task.ID))
+	}
+
+	assignees, err := db.Query(`SELECT a.task_id, a.volunteer_id, COALESCE(u.name, ''), a.assigned_by, a.assigned_at
+	                            FROM task_assignees a
+	                            JOIN volunteers v ON v.id = a.volunteer_id
+	                            LEFT JOIN users u ON u.id = v.user_id
+	                            WHERE a.task_id = ANY($1) ORDER BY a.task_id, a.assigned_at, a.id`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer assignees.Close()
+
+	for assignees.Next() {
+		var taskID int
+		var assignee structs.TaskAssignee
+		if err := assignees.Scan(&taskID, &assignee.VolunteerID, &assignee.VolunteerName, &assignee.AssignedBy, &assignee.AssignedAt); err != nil {
+			return nil, err
+		}
+		position := positions[taskID]
+		tasks[position].Assignees = append(tasks[position].Assignees, assignee)
+	}
+	return tasks, nil
+}
+
+func GetTaskByID(db *sql.DB, id int) (structs.Task, error) {
+	rows, err := db.Query(taskSelectQuery+` WHERE t.id = $1`, id)
+	if err != nil {
+		return structs.Task{}, err
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(db, rows)
+	if err != nil {
+		return structs.Task{}, err
+	}
+	if len(tasks) == 0 {
+		return structs.Task{}, errors.New("task not found")
+	}
+	task := tasks[0]
+
+	comments, err := db.Query(`SELECT c.id, c.task_id, c.user_id, COALESCE(u.name, ''), c.body, c.created_at
+	                           FROM task_comments c LEFT JOIN users u ON u.id = c.user_id
+	                           WHERE c.task_id = $1 ORDER BY c.created_at, c.id`, id)
+	if err != nil {
+		return task, err
+	}
+	defer comments.Close()
+
+	task.Comments = []structs.TaskComment{}
+	for comments.Next() {
+		var comment structs.TaskComment
+		if err := comments.Scan(&comment.ID, &comment.TaskID, &comment.UserID, &comment.UserName, &comment.Body, &comment.CreatedAt); err != nil {
+			return task, err
+		}
+		task.Comments = append(task.Comments, comment)
+	}
+
+	history, err := db.Query(`SELECT id, status, COALESCE(note, ''), changed_by, changed_at
+	                          FROM task_status_history WHERE task_id = $1 ORDER BY changed_at, id`, id)
+	if err != nil {
+		return task, err
+	}
+	defer history.Close()
+
+	task.History = []structs.TaskStatusChange{}
+	for history.Next() {
+		var change structs.TaskStatusChange
+		if err := history.Scan(&change.ID, &change.Status, &change.Note, &change.ChangedBy, &change.ChangedAt); err != nil {
+			return task, err
+		}
+		task.History = append(task.History, change)
+	}
+	return task, nil
+}
+
+// GetTasksByUserID mengembalikan tugas yang ditugaskan ke data relawan milik user
+func GetTasksByUserID(db *sql.DB, userID int, includeClosed bool) ([]structs.Task, error) {
+	rows, err := db.Query(taskSelectQuery+`
+	                       WHERE EXISTS (SELECT 1 FROM task_assignees a JOIN volunteers v ON v.id = a.volunteer_id WHERE a.task_id = t.id AND v.user_id = $1)
+	                         AND ($2 OR t.status NOT IN ('done', 'cancelled'))`+taskOrder, userID, includeClosed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTasks(db, rows)
+}
+
+// GetTaskBoard mengelompokkan tugas bencana per status, setiap kolom diurutkan dari prioritas tertinggi dan tenggat terdekat
+func GetTaskBoard(db *sql.DB, disasterID int) (map[string][]structs.Task, error) {
+	if !isDisasterExists(db, disasterID) {
+		return nil, errors.New("disaster not found")
+	}
+
+	rows, err := db.Query(taskSelectQuery+` WHERE t.disaster_id = $1`+taskOrder, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(db, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	board := make(map[string][]structs.Task)
+	for _, status := range taskStatuses {
+		board[status] = []structs.Task{}
+	}
+	for _, task := range tasks {
+		board[task.Status] = append(board[task.Status], task)
+	}
+	return board, nil
+}
+
+func IsTaskAssignee(db *sql.DB, taskID, userID int) (bool, error) {
+	var exists bool
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM task_assignees a JOIN volunteers v ON v.id = a.volunteer_id WHERE a.task_id = $1 AND v.user_id = $2)`, taskID, userID).Scan(&exists)
+	return exists, err
+}
+
+func lockTaskStatus(tx *sql.Tx, id int) (string, error) {
+	var status string
+	err := tx.QueryRow(`SELECT status FROM tasks WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return status, errors.New("task not found")
+		}
+		return status, err
+	}
+	return status, nil
+}
+
+func UpdateTask(db *sql.DB, id int, input structs.TaskInput, dueAt *time.Time) error {
+	if input.Priority != "" && !isValidNeedPriority(input.Priority) {
+		return errors.New("invalid need priority")
+	}
+
+	var updateFields []string
+	var values []interface{}
+	counter := 1
+
+	if strings.TrimSpace(input.Title) != "" {
+		updateFields = append(updateFields, "title = $"+strconv.Itoa(counter))
+		values = append(values, input.Title)
+		counter++
+	}
+	if input.Description != "" {
+		updateFields = append(updateFields, "description = $"+strconv.Itoa(counter))
+		values = append(values, input.Description)
+		counter++
+	}
+	if input.Priority != "" {
+		updateFields = append(updateFields, "priority = $"+strconv.Itoa(counter))
+		values = append(values, input.Priority)
+		counter++
+	}
+	// Sertifikasi wajib diturunkan ulang dari keahlian baru bila tidak diisi, sama seperti saat tugas dibuat
+	certificationsChanged := input.RequiredSkills != nil || input.RequiredCertifications != nil
+	var requiredCertifications []string
+	if input.RequiredSkills != nil {
+		requiredSkills, err := resolveSkillCodes(db, input.RequiredSkills)
+		if err != nil {
+			return err
+		}
+		updateFields = append(updateFields, "required_skills = $"+strconv.Itoa(counter))
+		values = append(values, pq.Array(requiredSkills))
+		counter++
+
+		requiredCertifications, err = resolveRequiredCertifications(db, input.RequiredCertifications, requiredSkills)
+		if err != nil {
+			return err
+		}
+	} else if input.RequiredCertifications != nil {
+		requiredCertifications = normalizeCertificationTypes(input.RequiredCertifications)
+	}
+	if certificationsChanged {
+		updateFields = append(updateFields, "required_certifications = $"+strconv.Itoa(counter))
+		values = append(values, pq.Array(requiredCertifications))
+		counter++
+	}
+	if dueAt != nil {
+		updateFields = append(updateFields, "due_at = $"+strconv.Itoa(counter))
+		values = append(values, *dueAt)
+		counter++
+	}
+
+	if len(updateFields) == 0 {
+		return errors.New("tidak ada field yang dapat diperbarui")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	status, err := lockTaskStatus(tx, id)
+	if err != nil {
+		return err
+	}
+	if isTaskClosed(status) {
+		return errors.New("task closed")
+	}
+
+	updateFields = append(updateFields, "updated_at = NOW()")
+	query := "UPDATE tasks SET " + strings.Join(updateFields, ", ") + " WHERE id = $" + strconv.Itoa(counter)
+	values = append(values, id)
+
+	if _, err := tx.Exec(query, values...); err != nil {
+		return err
+	}
+
+	// Relawan yang sudah ditugaskan diperiksa ulang terhadap sertifikasi dan tenggat yang baru
+	if certificationsChanged || dueAt != nil {
+		var validUntil time.Time
+		err = tx.QueryRow(`SELECT required_certifications, GREATEST(NOW(), COALESCE(due_at, NOW())) FROM tasks WHERE id = $1`, id).
+			Scan(pq.Array(&requiredCertifications), &validUntil)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(`SELECT volunteer_id FROM task_assignees WHERE task_id = $1`, id)
+		if err != nil {
+			return err
+		}
+		var volunteerIDs []int
+		for rows.Next() {
+			var volunteerID int
+			if err := rows.Scan(&volunteerID); err != nil {
+				rows.Close()
+				return err
+			}
+			volunteerIDs = append(volunteerIDs, volunteerID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if err := checkAssigneeCertifications(tx, volunteerIDs, requiredCertifications, validUntil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func UpdateTaskStatus(db *sql.DB, id int, status, note string, changedBy int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := lockTaskStatus(tx, id)
+	if err != nil {
+		return err
+	}
+	if !isValidTaskTransition(current, status) {
+		return errors.New("invalid status transition")
+	}
+
+	_, err = tx.Exec(`UPDATE tasks SET status = $1, completed_at = CASE WHEN $2 THEN NOW() END, updated_at = NOW() WHERE id = $3`, status, status == "done", id)
+	if err != nil {
+		return err
+	}
+
+	if err := recordTaskStatus(tx, id, status, note, &changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func DeleteTask(db *sql.DB, id int) error {
+	result, err := db.Exec(`DELETE FROM tasks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("task not found")
+	}
+	return nil
+}
+
+func AssignTask(db *sql.DB, taskID, volunteerID, assignedBy int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	status, err := lockTaskStatus(tx, taskID)
+	if err != nil {
+		return err
+	}
+	if isTaskClosed(status) {
+		return errors.New("task closed")
+	}
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM volunteers WHERE id = $1)`, volunteerID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("volunteer not found")
+	}
+
+	// Sertifikasi harus tetap berlaku sampai tenggat tugas
+	var requiredCertifications []string
+	var validUntil time.Time
+	err = tx.QueryRow(`SELECT required_certifications, GREATEST(NOW(), COALESCE(due_at, NOW())) FROM tasks WHERE id = $1`, taskID).
+		Scan(pq.Array(&requiredCertifications), &validUntil)
+	if err != nil {
+		return err
+	}
+	if err := checkRequiredCertifications(tx, volunteerID, requiredCertifications, validUntil); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`INSERT INTO task_assignees (task_id, volunteer_id, assigned_by, assigned_at)
+	                        VALUES ($1, $2, $3, NOW()) ON CONFLICT (task_id, volunteer_id) DO NOTHING`, taskID, volunteerID, assignedBy)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("volunteer already assigned")
+	}
+
+	if status == "open" {
+		if _, err := tx.Exec(`UPDATE tasks SET status = 'assigned', updated_at = NOW() WHERE id = $1`, taskID); err != nil {
+			return err
+		}
+		if err := recordTaskStatus(tx, taskID, "assigned", "", &assignedBy); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func UnassignTask(db *sql.DB, taskID, volunteerID, changedBy int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	status, err := lockTaskStatus(tx, taskID)
+	if err != nil {
+		return err
+	}
+	if isTaskClosed(status) {
+		return errors.New("task closed")
+	}
+
+	result, err := tx.Exec(`DELETE FROM task_assignees WHERE task_id = $1 AND volunteer_id = $2`, taskID, volunteerID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("task assignee not found")
+	}
+
+	// Tugas yang belum dikerjakan kembali terbuka bila tidak ada relawan tersisa
+	var remaining int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM task_assignees WHERE task_id = $1`, taskID).Scan(&remaining); err != nil {
+		return err
+	}
+	if remaining == 0 && status == "assigned" {
+		if _, err := tx.Exec(`UPDATE tasks SET status = 'open', updated_at = NOW() WHERE id = $1`, taskID); err != nil {
+			return err
+		}
+		if err := recordTaskStatus(tx, taskID, "open", "Semua relawan dilepas dari tugas", &changedBy); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func AddTaskComment(db *sql.DB, comment *structs.TaskComment) error {
+	err := db.QueryRow(`INSERT INTO task_comments (task_id, user_id, body, created_at)
+	                    SELECT id, $2, $3, NOW() FROM tasks WHERE id = $1 RETURNING id, created_at`, comment.TaskID, comment.UserID, comment.Body).
+		Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("task not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"RescueHub/structs"
+	"database/sql"
+	"errors"
+	"sort"
+)
+
+func isValidTriageCategory(category string) bool {
+	validCategories := []string{"immediate", "delayed", "minor", "deceased"}
+	for _, valid := range validCategories {
+		if category == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func addTriageCount(counts *structs.TriageCounts, category string, total int) {
+	switch category {
+	case "immediate":
+		counts.Immediate += total
+	case "delayed":
+		counts.Delayed += total
+	case "minor":
+		counts.Minor += total
+	case "deceased":
+		counts.Deceased += total
+	default:
+		counts.Untriaged += total
+	}
+	counts.Total += total
+}
+
+func recordTriage(db *sql.DB, table, column string, targetID int, input structs.TriageInput, assessedBy int) (structs.TriageAssessment, error) {
+	assessment := structs.TriageAssessment{Category: input.Category, Note: input.Note, AssessedBy: &assessedBy}
+	if !isValidTriageCategory(input.Category) {
+		return assessment, errors.New("invalid triage category")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return assessment, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE `+table+` SET triage_category = $1, triaged_at = NOW(), updated_at = NOW() WHERE id = $2`, input.Category, targetID)
+	if err != nil {
+		return assessment, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return assessment, errors.New("triage target not found")
+	}
+
+	err = tx.QueryRow(`INSERT INTO triage_assessments (`+column+`, category, note, assessed_by, assessed_at)
+	                   VALUES ($1, $2, $3, $4, NOW()) RETURNING id, refugee_id, emergency_report_id, assessed_at`, targetID, input.Category, input.Note, assessedBy).
+		Scan(&assessment.ID, &assessment.RefugeeID, &assessment.EmergencyReportID, &assessment.AssessedAt)
+	if err != nil {
+		return assessment, err
+	}
+
+	return assessment, tx.Commit()
+}
+
+func TriageRefugee(db *sql.DB, refugeeID int, input structs.TriageInput, assessedBy int) (structs.TriageAssessment, error) {
+	return recordTriage(db, "refugees", "refugee_id", refugeeID, input, assessedBy)
+}
+
+func TriageEmergencyReport(db *sql.DB, reportID int, input structs.TriageInput, assessedBy int) (structs.TriageAssessment, error) {
+	return recordTriage(db, "emergency_reports", "emergency_report_id", reportID, input, assessedBy)
+}
+
+func getTriageHistory(db *sql.DB, column string, targetID int) ([]structs.TriageAssessment, error) {
+	query := `SELECT id, refugee_id, emergency_report_id, category, COALESCE(note, ''), assessed_by, assessed_at
+	          FROM triage_assessments WHERE ` + column + ` = $1 ORDER BY assessed_at, id`
+	rows, err := db.Query(query, targetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assessments := []structs.TriageAssessment{}
+	for rows.Next() {
+		var assessment structs.TriageAssessment
+		err := rows.Scan(&assessment.ID, &assessment.RefugeeID, &assessment.EmergencyReportID, &assessment.Category, &assessment.Note, &assessment.AssessedBy, &assessment.AssessedAt)
+		if err != nil {
+			return nil, err
+		}
+		assessments = append(assessments, assessment)
+	}
+	return assessments, nil
+}
+
+func GetRefugeeTriageHistory(db *sql.DB, refugeeID int) ([]structs.TriageAssessment, error) {
+	if !isRefugeeExists(db, refugeeID) {
+		return nil, errors.New("refugee not found")
+	}
+	return getTriageHistory(db, "refugee_id", refugeeID)
+}
+
+func GetEmergencyReportTriageHistory(db *sql.DB, reportID int) ([]structs.TriageAssessment, error) {
+	if !isEmergencyReportExists(db, reportID) {
+		return nil, errors.New("emergency report not found")
+	}
+	return getTriageHistory(db, "emergency_report_id", reportID)
+}
+
+func GetShelterTriageSummary(db *sql.DB, shelterID int) (structs.ShelterTriageSummary, error) {
+	shelter, err := GetShelterByID(db, shelterID)
+	if err != nil {
+		return structs.ShelterTriageSummary{}, err
+	}
+
+	summary := structs.ShelterTriageSummary{ShelterID: shelter.ID, ShelterName: shelter.Name}
+	rows, err := db.Query(`SELECT COALESCE(triage_category::TEXT, ''), COUNT(*) FROM refugees WHERE shelter_id = $1 GROUP BY triage_category`, shelterID)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category string
+		var total int
+		if err := rows.Scan(&category, &total); err != nil {
+			return summary, err
+		}
+		addTriageCount(&summary.Refugees, category, total)
+	}
+	return summary, nil
+}
+
+func GetDisasterTriageSummary(db *sql.DB, disasterID int) (structs.DisasterTriageSummary, error) {
+	summary := structs.DisasterTriageSummary{DisasterID: disasterID, Shelters: []structs.ShelterTriageSummary{}}
+	if !isDisasterExists(db, disasterID) {
+		return summary, errors.New("disaster not found")
+	}
+
+	rows, err := db.Query(`SELECT r.shelter_id, COALESCE(s.name, ''), COALESCE(r.triage_category::TEXT, ''), COUNT(*)
+	                       FROM refugees r LEFT JOIN shelters s ON s.id = r.shelter_id
+	                       WHERE r.disaster_id = $1 GROUP BY r.shelter_id, s.name, r.triage_category`, disasterID)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+
+	shelters := make(map[int]*structs.ShelterTriageSummary)
+	for rows.Next() {
+		var shelterID *int
+		var shelterName, category string
+		var total int
+		if err := rows.Scan(&shelterID, &shelterName, &category, &total); err != nil {
+			return summary, err
+		}
+		addTriageCount(&summary.Refugees, category, total)
+
+		if shelterID == nil {
+			continue
+		}
+		shelter, exists := shelters[*shelterID]
+		if !exists {
+			shelter = &structs.ShelterTriageSummary{ShelterID: *shelterID, ShelterName: shelterName}
+			shelters[*shelterID] = shelter
+		}
+		addTriageCount(&shelter.Refugees, category, total)
+	}
+
+	reportRows, err := db.Query(`SELECT COALESCE(triage_category::TEXT, ''), COUNT(*) FROM emergency_reports WHERE disaster_id = $1 GROUP BY triage_category`, disasterID)
+	if err != nil {
+		return summary, err
+	}
+	defer reportRows.Close()
+
+	for reportRows.Next() {
+		var category string
+		var total int
+		if err := reportRows.Scan(&category, &total); err != nil {
+			return summary, err
+		}
+		addTriageCount(&summary.EmergencyReports, category, total)
+	}
+
+	for _, shelter := range shelters {
+		summary.Shelters = append(summary.Shelters, *shelter)
+	}
+	sort.Slice(summary.Shelters, func(i, j int) bool {
+		a, b := summary.Shelters[i].Refugees, summary.Shelters[j].Refugees
+		if a.Immediate != b.Immediate {
+			return a.Immediate > b.Immediate
+		}
+		if a.Delayed != b.Delayed {
+			return a.Delayed > b.Delayed
+		}
+		return summary.Shelters[i].ShelterID < summary.Shelters[j].ShelterID
+	})
+
+	return summary, nil
+}
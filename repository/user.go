@@ -232,29 +232,18 @@ func HashPassword(password string) (string, error) {
 }
 
 func GetDonationsByUserID(db *sql.DB, userID int) ([]structs.Donation, error) {
-	var donations []structs.Donation
-	query := `SELECT id, donor_id, disaster_id, amount, item_name, status, created_at, updated_at 
-	          FROM donations WHERE donor_id = $1`
-	rows, err := db.Query(query, userID)
+	rows, err := db.Query(donationSelectQuery+` WHERE donor_id = $1`, userID)
 	if err != nil {
-		return donations, err
+		return nil, err
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var donation structs.Donation
-		err := rows.Scan(&donation.ID, &donation.DonorID, &donation.DisasterID, &donation.Amount, &donation.ItemName, &donation.Status, &donation.CreatedAt, &donation.UpdatedAt)
-		if err != nil {
-			return donations, err
-		}
-		donations = append(donations, donation)
-	}
-	return donations, nil
+	return scanDonations(rows)
 }
 
 func GetEmergencyReportsByUserID(db *sql.DB, userID int) ([]structs.EmergencyReport, error) {
 	var reports []structs.EmergencyReport
-	query := `SELECT id, user_id, disaster_id, description, location, created_at, updated_at 
+	query := `SELECT id, user_id, disaster_id, description, location, latitude, longitude, location_accuracy, COALESCE(triage_category::TEXT, ''), triaged_at, created_at, updated_at 
 	          FROM emergency_reports WHERE user_id = $1`
 	rows, err := db.Query(query, userID)
 	if err != nil {
@@ -264,7 +253,7 @@ func GetEmergencyReportsByUserID(db *sql.DB, userID int) ([]structs.EmergencyRep
 
 	for rows.Next() {
 		var report structs.EmergencyReport
-		err := rows.Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.CreatedAt, &report.UpdatedAt)
+		err := rows.Scan(&report.ID, &report.UserID, &report.DisasterID, &report.Description, &report.Location, &report.Latitude, &report.Longitude, &report.LocationAccuracy, &report.TriageCategory, &report.TriagedAt, &report.CreatedAt, &report.UpdatedAt)
 		if err != nil {
 			return reports, err
 		}
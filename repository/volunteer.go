@@ -13,20 +13,24 @@ func CreateVolunteer(db *sql.DB, volunteer *structs.Volunteer) error {
 			return errors.New("invalid volunteer status")
 	}
 
-	sqlQuery := `INSERT INTO volunteers (user_id, disaster_id, skill, location, status, created_at, updated_at)
-							 VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id, created_at, updated_at`
-	err := db.QueryRow(sqlQuery, volunteer.UserID, volunteer.DisasterID, volunteer.Skill, volunteer.Location, volunteer.Status).
+	if err := validateLocation(volunteer.Latitude, volunteer.Longitude, volunteer.LocationAccuracy); err != nil {
+		return err
+	}
+
+	sqlQuery := `INSERT INTO volunteers (user_id, disaster_id, skill, location, status, latitude, longitude, location_accuracy, created_at, updated_at)
+							 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	err := db.QueryRow(sqlQuery, volunteer.UserID, volunteer.DisasterID, volunteer.Skill, volunteer.Location, volunteer.Status, volunteer.Latitude, volunteer.Longitude, volunteer.LocationAccuracy).
 			Scan(&volunteer.ID, &volunteer.CreatedAt, &volunteer.UpdatedAt)
 
 	if err != nil {
 			return err
 	}
 
-	return nil
+	return linkVolunteerSkill(db, volunteer.ID, volunteer.Skill)
 }
 
 func GetAllVolunteers(db *sql.DB) ([]structs.Volunteer, error) {
-	query := `SELECT id, user_id, disaster_id, skill, location, status, created_at, updated_at FROM volunteers`
+	query := `SELECT id, user_id, disaster_id, skill, location, status, latitude, longitude, location_accuracy, created_at, updated_at FROM volunteers`
 	rows, err := db.Query(query)
 
 	if err != nil {
@@ -37,7 +41,7 @@ func GetAllVolunteers(db *sql.DB) ([]structs.Volunteer, error) {
 	var volunteers []structs.Volunteer
 	for rows.Next() {
 		var volunteer structs.Volunteer
-		err := rows.Scan(&volunteer.ID, &volunteer.UserID, &volunteer.DisasterID, &volunteer.Skill, &volunteer.Location, &volunteer.Status, &volunteer.CreatedAt, &volunteer.UpdatedAt)
+		err := rows.Scan(&volunteer.ID, &volunteer.UserID, &volunteer.DisasterID, &volunteer.Skill, &volunteer.Location, &volunteer.Status, &volunteer.Latitude, &volunteer.Longitude, &volunteer.LocationAccuracy, &volunteer.CreatedAt, &volunteer.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -52,8 +56,31 @@ func GetAllVolunteers(db *sql.DB) ([]structs.Volunteer, error) {
 	return volunteers, nil
 }
 
+func GetVolunteersByArea(db *sql.DB, filter structs.GeoFilter) ([]structs.Volunteer, error) {
+	distanceColumn, where, order, values := buildGeoQuery(filter)
+	query := `SELECT id, user_id, disaster_id, skill, location, status, latitude, longitude, location_accuracy, ` + distanceColumn + ` AS distance_km, created_at, updated_at
+	          FROM volunteers WHERE ` + where + ` ORDER BY ` + order
+	rows, err := db.Query(query, values...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var volunteers []structs.Volunteer
+	for rows.Next() {
+		var volunteer structs.Volunteer
+		err := rows.Scan(&volunteer.ID, &volunteer.UserID, &volunteer.DisasterID, &volunteer.Skill, &volunteer.Location, &volunteer.Status, &volunteer.Latitude, &volunteer.Longitude, &volunteer.LocationAccuracy, &volunteer.DistanceKm, &volunteer.CreatedAt, &volunteer.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		volunteers = append(volunteers, volunteer)
+	}
+	return volunteers, nil
+}
+
 func GetVolunteerByID(db *sql.DB, id int) (structs.Volunteer, error) {
-	query := `SELECT id, user_id, disaster_id, skill, location, status, created_at, updated_at FROM volunteers WHERE id = $1`
+	query := `SELECT id, user_id, disaster_id, skill, location, status, latitude, longitude, location_accuracy, created_at, updated_at FROM volunteers WHERE id = $1`
 	var volunteer structs.Volunteer
 
 	err := db.QueryRow(query, id).Scan(
@@ -63,6 +90,9 @@ func GetVolunteerByID(db *sql.DB, id int) (structs.Volunteer, error) {
 		&volunteer.Skill,
 		&volunteer.Location,
 		&volunteer.Status,
+		&volunteer.Latitude,
+		&volunteer.Longitude,
+		&volunteer.LocationAccuracy,
 		&volunteer.CreatedAt,
 		&volunteer.UpdatedAt,
 	)
@@ -73,10 +103,19 @@ func GetVolunteerByID(db *sql.DB, id int) (structs.Volunteer, error) {
 		}
 		return volunteer, err
 	}
+
+	volunteer.Skills, err = GetVolunteerSkills(db, volunteer.ID)
+	if err != nil {
+		return volunteer, err
+	}
 	return volunteer, nil
 }
 
 func UpdateVolunteer(db *sql.DB, volunteer structs.Volunteer) error {
+	if err := validateLocation(volunteer.Latitude, volunteer.Longitude, volunteer.LocationAccuracy); err != nil {
+		return err
+	}
+
 	var updateFields []string
 	var values []interface{}
 	counter := 1
@@ -101,6 +140,16 @@ func UpdateVolunteer(db *sql.DB, volunteer structs.Volunteer) error {
 		values = append(values, volunteer.Location)
 		counter++
 	}
+	if volunteer.Latitude != nil && volunteer.Longitude != nil {
+		updateFields = append(updateFields, "latitude = $"+strconv.Itoa(counter), "longitude = $"+strconv.Itoa(counter+1))
+		values = append(values, volunteer.Latitude, volunteer.Longitude)
+		counter += 2
+	}
+	if volunteer.LocationAccuracy != nil {
+		updateFields = append(updateFields, "location_accuracy = $"+strconv.Itoa(counter))
+		values = append(values, volunteer.LocationAccuracy)
+		counter++
+	}
 	if volunteer.Status != "" {
 		if !isValidVolunteerStatus(volunteer.Status) {
 			return errors.New("invalid volunteer status")
@@ -122,7 +171,7 @@ func UpdateVolunteer(db *sql.DB, volunteer structs.Volunteer) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return linkVolunteerSkill(db, volunteer.ID, volunteer.Skill)
 }
 
 
@@ -23,6 +23,10 @@ type Disaster struct {
 	Description string 		`json:"description"`
 	Status      string 		`json:"status"`
 	ReportedBy  int    		`json:"reported_by"`
+	Latitude          *float64  `json:"latitude,omitempty"`
+	Longitude         *float64  `json:"longitude,omitempty"`
+	LocationAccuracy  *float64  `json:"location_accuracy,omitempty"`
+	DistanceKm        *float64  `json:"distance_km,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -34,7 +38,13 @@ type Shelter struct {
 	Location          string 		`json:"location"`
 	CapacityTotal     int    		`json:"capacity_total"`
 	CapacityRemaining int    		`json:"capacity_remaining"`
+	Occupancy         int       `json:"occupancy"`
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
 	EmergencyNeeds    string 		`json:"emergency_needs"`
+	Latitude          *float64  `json:"latitude,omitempty"`
+	Longitude         *float64  `json:"longitude,omitempty"`
+	LocationAccuracy  *float64  `json:"location_accuracy,omitempty"`
+	DistanceKm        *float64  `json:"distance_km,omitempty"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
@@ -47,20 +57,111 @@ type Refugee struct {
 	Age        int    `json:"age"`
 	Condition  string `json:"condition"`
 	Needs      string `json:"needs"`
+	HouseholdID  *int   `json:"household_id,omitempty"`
+	Relationship string `json:"relationship,omitempty"`
+	TriageCategory string     `json:"triage_category,omitempty"`
+	TriagedAt      *time.Time `json:"triaged_at,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type Household struct {
+	ID            int       `json:"id"`
+	DisasterID    *int      `json:"disaster_id,omitempty"`
+	Name          string    `json:"name"`
+	HeadRefugeeID *int      `json:"head_refugee_id,omitempty"`
+	ShelterID     *int      `json:"shelter_id,omitempty"`
+	MemberCount   int       `json:"member_count"`
+	Members       []Refugee `json:"members,omitempty"`
+	Needs         []string  `json:"needs,omitempty"`
+	RationSize    float64   `json:"ration_size"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
 type Logistic struct {
 	ID         int    `json:"id"`
 	Type       string `json:"type"`
 	Quantity   int    `json:"quantity"`
 	Status     string `json:"status"`
 	DisasterID *int   `json:"disaster_id,omitempty"`
+	ItemID     *int   `json:"item_id,omitempty"`
+	Unit       string `json:"unit,omitempty"`
+	Location   string    `json:"location,omitempty"`
+	Latitude   *float64  `json:"latitude,omitempty"`
+	Longitude  *float64  `json:"longitude,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type NeedRequest struct {
+	ID                int       `json:"id"`
+	ShelterID         int       `json:"shelter_id"`
+	ItemID            *int      `json:"item_id,omitempty"`
+	ItemName          string    `json:"item_name"`
+	Quantity          int       `json:"quantity"`
+	QuantityFulfilled int       `json:"quantity_fulfilled"`
+	Unit              string    `json:"unit,omitempty"`
+	Priority          string    `json:"priority"`
+	Status            string    `json:"status"`
+	Note              string    `json:"note,omitempty"`
+	RequestedBy       *int      `json:"requested_by,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type AllocationProposal struct {
+	NeedRequestID     int      `json:"need_request_id"`
+	ShelterID         int      `json:"shelter_id"`
+	ShelterName       string   `json:"shelter_name"`
+	ItemName          string   `json:"item_name"`
+	Priority          string   `json:"priority"`
+	QuantityRemaining int      `json:"quantity_remaining"`
+	LogisticID        int      `json:"logistic_id"`
+	LogisticType      string   `json:"logistic_type"`
+	AvailableStock    int      `json:"available_stock"`
+	QuantityProposed  int      `json:"quantity_proposed"`
+	Unit              string   `json:"unit,omitempty"`
+	ShortageRatio     float64  `json:"shortage_ratio"`
+	DistanceKm        *float64 `json:"distance_km,omitempty"`
+	Score             float64  `json:"score"`
+}
+
+type Item struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Category    string     `json:"category,omitempty"`
+	BaseUnit    string     `json:"base_unit"`
+	Description string     `json:"description,omitempty"`
+	Units       []ItemUnit `json:"units"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type ItemUnit struct {
+	Unit   string  `json:"unit"`
+	Factor float64 `json:"factor"`
+}
+
+type LogisticBatch struct {
+	ID                int        `json:"id"`
+	LogisticID        int        `json:"logistic_id"`
+	BatchNumber       string     `json:"batch_number"`
+	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
+	QuantityReceived  int        `json:"quantity_received"`
+	QuantityRemaining int        `json:"quantity_remaining"`
+	ReceivedAt        time.Time  `json:"received_at"`
+}
+
+type ExpiringBatch struct {
+	LogisticBatch
+	LogisticType    string `json:"logistic_type"`
+	Unit            string `json:"unit,omitempty"`
+	DisasterID      *int   `json:"disaster_id,omitempty"`
+	DaysUntilExpiry int    `json:"days_until_expiry"`
+	Expired         bool   `json:"expired"`
+}
+
 type DistributionLog struct {
 	ID            int    		`json:"id"`
 	LogisticID    *int   		`json:"logistic_id,omitempty"`
@@ -70,11 +171,67 @@ type DistributionLog struct {
 	SenderName    string 		`json:"sender_name"`
 	RecipientName string 		`json:"recipient_name"`
 	QuantitySent  int    		`json:"quantity_sent"`
+	NeedRequestID *int      `json:"need_request_id,omitempty"`
+	Status        string     `json:"status"`
+	QuantityReceived  *int       `json:"quantity_received,omitempty"`
+	DiscrepancyReason string     `json:"discrepancy_reason,omitempty"`
+	ConfirmationCode  string     `json:"-"`
 	SentAt        time.Time 		`json:"sent_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+type DistributionStatusChange struct {
+	ID        int       `json:"id"`
+	Status    string    `json:"status"`
+	Note      string    `json:"note,omitempty"`
+	ChangedBy *int      `json:"changed_by,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type DistributionProof struct {
+	ID          int       `json:"id"`
+	Kind        string    `json:"kind"`
+	FileName    string    `json:"file_name,omitempty"`
+	ContentType string    `json:"content_type"`
+	Data        []byte    `json:"-"`
+	UploadedBy  *int      `json:"uploaded_by,omitempty"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+type DistributionDelivery struct {
+	DistributionLog
+	History []DistributionStatusChange `json:"history"`
+	Proofs  []DistributionProof        `json:"proofs"`
+}
+
+type StockMovement struct {
+	ID                int       `json:"id"`
+	LogisticID        int       `json:"logistic_id"`
+	MovementType      string    `json:"movement_type"`
+	Quantity          int       `json:"quantity"`
+	BalanceAfter      int       `json:"balance_after"`
+	BatchID           *int      `json:"batch_id,omitempty"`
+	DistributionLogID *int      `json:"distribution_log_id,omitempty"`
+	Note              string    `json:"note,omitempty"`
+	RecordedBy        *int      `json:"recorded_by,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type StockLedger struct {
+	LogisticID int             `json:"logistic_id"`
+	Type       string          `json:"type"`
+	Status     string          `json:"status"`
+	Balance    int             `json:"balance"`
+	Received   int             `json:"received"`
+	Dispatched int             `json:"dispatched"`
+	Adjusted   int             `json:"adjusted"`
+	Lost       int             `json:"lost"`
+	Movements  []StockMovement `json:"movements"`
+}
+
 type EvacuationRoute struct {
 	ID            int    		`json:"id"`
 	DisasterID    *int   		`json:"disaster_id,omitempty"`
@@ -83,16 +240,41 @@ type EvacuationRoute struct {
 	Distance      float64 	`json:"distance"`
 	Route         string 		`json:"route"`
 	Status        string 		`json:"status"`
+	Waypoints     []RouteWaypoint `json:"waypoints,omitempty"`
+	Segments      []RouteSegment  `json:"segments,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+type RouteWaypoint struct {
+	Sequence  int     `json:"sequence"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type RouteSegment struct {
+	ID        int       `json:"id"`
+	RouteID   int       `json:"route_id"`
+	Sequence  int       `json:"sequence"`
+	Distance  float64   `json:"distance"`
+	Status    string    `json:"status"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type EmergencyReport struct {
 	ID          int    `json:"id"`
 	UserID      *int   `json:"user_id,omitempty"`
 	DisasterID  *int   `json:"disaster_id,omitempty"`
 	Description string `json:"description"`
 	Location    string `json:"location"`
+	Latitude          *float64  `json:"latitude,omitempty"`
+	Longitude         *float64  `json:"longitude,omitempty"`
+	LocationAccuracy  *float64  `json:"location_accuracy,omitempty"`
+	DistanceKm        *float64  `json:"distance_km,omitempty"`
+	TriageCategory    string     `json:"triage_category,omitempty"`
+	TriagedAt         *time.Time `json:"triaged_at,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -102,12 +284,143 @@ type Donation struct {
 	DonorID		 *int    `json:"donor_id,omitempty"`
 	DisasterID *int    `json:"disaster_id,omitempty"`
 	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency,omitempty"`
+	ExchangeRate      *float64 `json:"exchange_rate,omitempty"`
+	ReportingCurrency string   `json:"reporting_currency,omitempty"`
+	AmountReporting   *float64 `json:"amount_reporting,omitempty"`
 	ItemName	 string  `json:"item_name"`
+	ItemID     *int       `json:"item_id,omitempty"`
+	Unit       string     `json:"unit,omitempty"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty"`
 	Status     string  `json:"status"`
+	LogisticID *int       `json:"logistic_id,omitempty"`
+	ReceivedAt *time.Time `json:"received_at,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type DonationReceipt struct {
+	ID               int        `json:"id"`
+	DonationID       int        `json:"donation_id"`
+	ReceiptNumber    string     `json:"receipt_number"`
+	VerificationCode string     `json:"verification_code"`
+	DonorName        string     `json:"donor_name"`
+	DonorEmail       string     `json:"donor_email,omitempty"`
+	DisasterName     string     `json:"disaster_name,omitempty"`
+	Amount           float64    `json:"amount"`
+	Currency         string     `json:"currency,omitempty"`
+	ItemName         string     `json:"item_name,omitempty"`
+	Unit             string     `json:"unit,omitempty"`
+	Status           string     `json:"status"`
+	DonatedAt        time.Time  `json:"donated_at"`
+	IssuedAt         time.Time  `json:"issued_at"`
+}
+
+type PaymentIntent struct {
+	ID                int        `json:"id"`
+	DonationID        int        `json:"donation_id"`
+	Provider          string     `json:"provider"`
+	ProviderReference string     `json:"provider_reference"`
+	Amount            float64    `json:"amount"`
+	Currency          string     `json:"currency"`
+	Status            string     `json:"status"`
+	RedirectURL       string     `json:"redirect_url"`
+	PaidAt            *time.Time `json:"paid_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type PaymentWebhookEvent struct {
+	EventID           string  `json:"event_id"`
+	ProviderReference string  `json:"reference"`
+	Status            string  `json:"status"`
+	Amount            float64 `json:"amount"`
+	Currency          string  `json:"currency,omitempty"`
+	Payload           string  `json:"-"`
+}
+
+type ExchangeRate struct {
+	BaseCurrency string    `json:"base_currency"`
+	Currency     string    `json:"currency"`
+	Rate         float64   `json:"rate"`
+	Source       string    `json:"source,omitempty"`
+	UpdatedBy    *int      `json:"updated_by,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type CurrencyTotal struct {
+	Currency        string  `json:"currency"`
+	Count           int     `json:"count"`
+	Amount          float64 `json:"amount"`
+	AmountReporting float64 `json:"amount_reporting"`
+}
+
+type DonationTotals struct {
+	ReportingCurrency string          `json:"reporting_currency"`
+	TotalReporting    float64         `json:"total_reporting"`
+	ByCurrency        []CurrencyTotal `json:"by_currency"`
+}
+
+type Disbursement struct {
+	ID          int       `json:"id"`
+	DisasterID  *int      `json:"disaster_id,omitempty"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	Recipient   string    `json:"recipient"`
+	Purpose     string    `json:"purpose"`
+	DisbursedBy *int      `json:"disbursed_by,omitempty"`
+	DisbursedAt time.Time `json:"disbursed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type LedgerEntry struct {
+	Sequence     int64     `json:"sequence"`
+	EntryType    string    `json:"entry_type"`
+	ReferenceID  int       `json:"reference_id"`
+	DisasterID   *int      `json:"disaster_id,omitempty"`
+	DisasterName string    `json:"disaster_name,omitempty"`
+	Amount       float64   `json:"amount"`
+	Currency     string    `json:"currency,omitempty"`
+	Unit         string    `json:"unit,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	RecordedAt   time.Time `json:"recorded_at"`
+	PreviousHash string    `json:"previous_hash"`
+	Hash         string    `json:"hash"`
+}
+
+type LedgerIssue struct {
+	Sequence    int64  `json:"sequence,omitempty"`
+	EntryType   string `json:"entry_type"`
+	ReferenceID int    `json:"reference_id"`
+	Problem     string `json:"problem"`
+}
+
+type LedgerVerification struct {
+	Valid      bool          `json:"valid"`
+	Entries    int           `json:"entries"`
+	HeadHash   string        `json:"head_hash"`
+	Issues     []LedgerIssue `json:"issues"`
+	VerifiedAt time.Time     `json:"verified_at"`
+}
+
+type DonationDistribution struct {
+	DistributionLogID int       `json:"distribution_log_id"`
+	Destination       string    `json:"destination"`
+	RecipientName     string    `json:"recipient_name"`
+	Status            string    `json:"status"`
+	Quantity          int       `json:"quantity"`
+	SentAt            time.Time `json:"sent_at"`
+}
+
+type DonationTrace struct {
+	DonationID        int                    `json:"donation_id"`
+	LogisticID        int                    `json:"logistic_id"`
+	QuantityReceived  int                    `json:"quantity_received"`
+	QuantityRemaining int                    `json:"quantity_remaining"`
+	QuantityDispatched int                   `json:"quantity_dispatched"`
+	Distributions     []DonationDistribution `json:"distributions"`
+}
+
 type Volunteer struct {
 	ID         int    		`json:"id"`
 	UserID     *int    		`json:"user_id,omitempty"`
@@ -115,6 +428,11 @@ type Volunteer struct {
 	Skill      string 		`json:"skill"`
 	Location   string 		`json:"location"`
 	Status     string 		`json:"status"`
+	Latitude          *float64  `json:"latitude,omitempty"`
+	Longitude         *float64  `json:"longitude,omitempty"`
+	LocationAccuracy  *float64  `json:"location_accuracy,omitempty"`
+	DistanceKm        *float64  `json:"distance_km,omitempty"`
+	Skills            []VolunteerSkill `json:"skills,omitempty"`
 	CreatedAt  time.Time 	`json:"created_at"`
 	UpdatedAt  time.Time 	`json:"updated_at"`
 }
@@ -156,15 +474,160 @@ type DisasterInput struct {
 	Description string `json:"description,omitempty"`
 	Status      string `json:"status,omitempty"`
 	ReportedBy  int    `json:"reported_by,omitempty"`
+	Latitude         *float64 `json:"latitude,omitempty"`
+	Longitude        *float64 `json:"longitude,omitempty"`
+	LocationAccuracy *float64 `json:"location_accuracy,omitempty"`
 }
 
 type ShelterInput struct {
 	Name              string `json:"name,omitempty"`
 	Location          string `json:"location,omitempty"`
 	CapacityTotal     int    `json:"capacity_total,omitempty"`
-	CapacityRemaining int    `json:"capacity_remaining,omitempty"`
 	EmergencyNeeds    string `json:"emergency_needs,omitempty"`
 	DisasterID        *int   `json:"disaster_id,omitempty"`
+	Latitude         *float64 `json:"latitude,omitempty"`
+	Longitude        *float64 `json:"longitude,omitempty"`
+	LocationAccuracy *float64 `json:"location_accuracy,omitempty"`
+	OverrideCapacity bool     `json:"override_capacity,omitempty"`
+}
+
+type MissingPerson struct {
+	ID               int        `json:"id"`
+	DisasterID       *int       `json:"disaster_id,omitempty"`
+	ReportedBy       *int       `json:"reported_by,omitempty"`
+	Name             string     `json:"name"`
+	AgeMin           *int       `json:"age_min,omitempty"`
+	AgeMax           *int       `json:"age_max,omitempty"`
+	LastSeenLocation string     `json:"last_seen_location"`
+	LastSeenAt       *time.Time `json:"last_seen_at,omitempty"`
+	ContactName      string     `json:"contact_name"`
+	ContactPhone     string     `json:"contact_phone"`
+	ContactEmail     string     `json:"contact_email"`
+	Description      string     `json:"description"`
+	Status           string     `json:"status"`
+	FoundRefugeeID   *int       `json:"found_refugee_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type MissingPersonMatch struct {
+	ID              int        `json:"id"`
+	MissingPersonID int        `json:"missing_person_id"`
+	RefugeeID       int        `json:"refugee_id"`
+	Score           float64    `json:"score"`
+	Status          string     `json:"status"`
+	ReviewedBy      *int       `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	Refugee         *Refugee   `json:"refugee,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type MissingPersonInput struct {
+	Name             string `json:"name,omitempty"`
+	DisasterID       *int   `json:"disaster_id,omitempty"`
+	AgeMin           *int   `json:"age_min,omitempty"`
+	AgeMax           *int   `json:"age_max,omitempty"`
+	LastSeenLocation string `json:"last_seen_location,omitempty"`
+	LastSeenAt       string `json:"last_seen_at,omitempty"`
+	ContactName      string `json:"contact_name,omitempty"`
+	ContactPhone     string `json:"contact_phone,omitempty"`
+	ContactEmail     string `json:"contact_email,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Status           string `json:"status,omitempty"`
+}
+
+type MissingPersonMatchInput struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type HouseholdInput struct {
+	Name          string `json:"name"`
+	DisasterID    *int   `json:"disaster_id,omitempty"`
+	HeadRefugeeID *int   `json:"head_refugee_id,omitempty"`
+}
+
+type HouseholdMemberInput struct {
+	RefugeeID    int    `json:"refugee_id" binding:"required"`
+	Relationship string `json:"relationship,omitempty"`
+}
+
+type HouseholdShelterInput struct {
+	ShelterID        int    `json:"shelter_id" binding:"required"`
+	Reason           string `json:"reason,omitempty"`
+	OverrideCapacity bool   `json:"override_capacity,omitempty"`
+}
+
+type TriageAssessment struct {
+	ID                int       `json:"id"`
+	RefugeeID         *int      `json:"refugee_id,omitempty"`
+	EmergencyReportID *int      `json:"emergency_report_id,omitempty"`
+	Category          string    `json:"category"`
+	Note              string    `json:"note"`
+	AssessedBy        *int      `json:"assessed_by,omitempty"`
+	AssessedAt        time.Time `json:"assessed_at"`
+}
+
+type TriageInput struct {
+	Category string `json:"category" binding:"required"`
+	Note     string `json:"note,omitempty"`
+}
+
+type TriageCounts struct {
+	Immediate int `json:"immediate"`
+	Delayed   int `json:"delayed"`
+	Minor     int `json:"minor"`
+	Deceased  int `json:"deceased"`
+	Untriaged int `json:"untriaged"`
+	Total     int `json:"total"`
+}
+
+type ShelterTriageSummary struct {
+	ShelterID   int          `json:"shelter_id"`
+	ShelterName string       `json:"shelter_name"`
+	Refugees    TriageCounts `json:"refugees"`
+}
+
+type DisasterTriageSummary struct {
+	DisasterID       int                    `json:"disaster_id"`
+	Refugees         TriageCounts           `json:"refugees"`
+	EmergencyReports TriageCounts           `json:"emergency_reports"`
+	Shelters         []ShelterTriageSummary `json:"shelters"`
+}
+
+type RefugeeDuplicate struct {
+	Refugee    Refugee  `json:"refugee"`
+	Score      float64  `json:"score"`
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+}
+
+type RefugeeMerge struct {
+	ID              int       `json:"id"`
+	RefugeeID       int       `json:"refugee_id"`
+	MergedRefugeeID int       `json:"merged_refugee_id"`
+	MergedData      Refugee   `json:"merged_data"`
+	MergedBy        *int      `json:"merged_by,omitempty"`
+	MergedAt        time.Time `json:"merged_at"`
+}
+
+type RefugeeMergeInput struct {
+	DuplicateID int `json:"duplicate_id" binding:"required"`
+}
+
+type RefugeeMovement struct {
+	ID            int       `json:"id"`
+	RefugeeID     int       `json:"refugee_id"`
+	FromShelterID *int      `json:"from_shelter_id"`
+	ToShelterID   *int      `json:"to_shelter_id"`
+	Reason        string    `json:"reason"`
+	AuthorizedBy  *int      `json:"authorized_by"`
+	MovedAt       time.Time `json:"moved_at"`
+}
+
+type RefugeeTransferInput struct {
+	ShelterID        int    `json:"shelter_id" binding:"required"`
+	Reason           string `json:"reason" binding:"required"`
+	OverrideCapacity bool   `json:"override_capacity,omitempty"`
 }
 
 type RefugeeInput struct {
@@ -174,13 +637,46 @@ type RefugeeInput struct {
 	Needs      string `json:"needs,omitempty"`
 	ShelterID  *int   `json:"shelter_id,omitempty"`
 	DisasterID *int   `json:"disaster_id,omitempty"`
+	OverrideCapacity bool `json:"override_capacity,omitempty"`
 }
 
 type LogisticInput struct {
 	Type       string `json:"type,omitempty"`
 	Quantity   int    `json:"quantity,omitempty"`
-	Status     string `json:"status,omitempty"`
 	DisasterID *int   `json:"disaster_id,omitempty"`
+	ItemID     *int   `json:"item_id,omitempty"`
+	Unit       string `json:"unit,omitempty"`
+	BatchNumber string `json:"batch_number,omitempty"`
+	ExpiryDate string `json:"expiry_date,omitempty"`
+	Location   string   `json:"location,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+}
+
+type NeedRequestInput struct {
+	ItemID   *int   `json:"item_id,omitempty"`
+	ItemName string `json:"item_name,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+	Unit     string `json:"unit,omitempty"`
+	Priority string `json:"priority,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+type AllocationAcceptInput struct {
+	LogisticID    int    `json:"logistic_id" binding:"required"`
+	Quantity      int    `json:"quantity,omitempty"`
+	SenderName    string `json:"sender_name,omitempty"`
+	RecipientName string `json:"recipient_name,omitempty"`
+	SentAt        string `json:"sent_at,omitempty"`
+}
+
+type ItemInput struct {
+	Name        string     `json:"name,omitempty"`
+	Category    string     `json:"category,omitempty"`
+	BaseUnit    string     `json:"base_unit,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Units       []ItemUnit `json:"units,omitempty"`
 }
 
 type DistributionLogInput struct {
@@ -191,9 +687,37 @@ type DistributionLogInput struct {
 	SenderName    string `json:"sender_name,omitempty"`
 	RecipientName string `json:"recipient_name,omitempty"`
 	QuantitySent  int    `json:"quantity_sent,omitempty"`
+	Status        string `json:"status,omitempty"`
 	SentAt        string `json:"sent_at,omitempty"`
 }
 
+type DistributionStatusInput struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note,omitempty"`
+}
+
+type DeliveryConfirmationInput struct {
+	ConfirmationCode  string `json:"confirmation_code" form:"confirmation_code" binding:"required"`
+	QuantityReceived  *int   `json:"quantity_received" form:"quantity_received" binding:"required"`
+	DiscrepancyReason string `json:"discrepancy_reason,omitempty" form:"discrepancy_reason"`
+}
+
+type StockMovementInput struct {
+	MovementType string `json:"movement_type" binding:"required"`
+	Quantity     int    `json:"quantity" binding:"required"`
+	Unit         string `json:"unit,omitempty"`
+	BatchID      *int   `json:"batch_id,omitempty"`
+	BatchNumber  string `json:"batch_number,omitempty"`
+	ExpiryDate   string `json:"expiry_date,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
+
+type StockBatchInput struct {
+	BatchID     *int
+	BatchNumber string
+	ExpiryDate  *time.Time
+}
+
 type EvacuationRouteInput struct {
 	DisasterID    *int   `json:"disaster_id,omitempty"`
 	Origin        string `json:"origin,omitempty"`
@@ -201,6 +725,18 @@ type EvacuationRouteInput struct {
 	Distance      float64 `json:"distance,omitempty"`
 	Route         string `json:"route,omitempty"`
 	Status        string `json:"status,omitempty"`
+	Geometry      *LineStringInput `json:"geometry,omitempty"`
+	Polyline      string `json:"polyline,omitempty"`
+}
+
+type LineStringInput struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+type RouteSegmentInput struct {
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
 }
 
 type EmergencyReportInput struct {
@@ -208,20 +744,427 @@ type EmergencyReportInput struct {
 	DisasterID  *int   `json:"disaster_id,omitempty"`
 	Description string `json:"description,omitempty"`
 	Location    string `json:"location,omitempty"`
+	Latitude         *float64 `json:"latitude,omitempty"`
+	Longitude        *float64 `json:"longitude,omitempty"`
+	LocationAccuracy *float64 `json:"location_accuracy,omitempty"`
 }
 
 type DonationInput struct {
 	DonorID    *int    `json:"user_id,omitempty"`
 	DisasterID *int    `json:"disaster_id,omitempty"`
 	Amount     float64 `json:"amount,omitempty"`
+	Currency   string  `json:"currency,omitempty"`
 	ItemName   string  `json:"item_name,omitempty"`
+	ItemID     *int    `json:"item_id,omitempty"`
+	Unit       string  `json:"unit,omitempty"`
+	ExpiryDate string  `json:"expiry_date,omitempty"`
 	Status     string  `json:"status,omitempty"`
 }
 
+type ExchangeRateInput struct {
+	BaseCurrency string             `json:"base_currency,omitempty"`
+	Rates        map[string]float64 `json:"rates" binding:"required"`
+}
+
+type DisbursementInput struct {
+	DisasterID *int    `json:"disaster_id"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Currency   string  `json:"currency"`
+	Recipient  string  `json:"recipient" binding:"required"`
+	Purpose    string  `json:"purpose" binding:"required"`
+}
+
+type MockPaymentInput struct {
+	Status string `json:"status" binding:"required"`
+}
+
 type VolunteerInput struct {
 	UserID    	*int   `json:"user_id,omitempty"`
 	DisasterID 	*int   `json:"disaster_id,omitempty"`
 	Skill      	string `json:"skill,omitempty"`
 	Location   	string `json:"location,omitempty"`
 	Status     	string `json:"status,omitempty"`
-}
\ No newline at end of file
+	Latitude         *float64 `json:"latitude,omitempty"`
+	Longitude        *float64 `json:"longitude,omitempty"`
+	LocationAccuracy *float64 `json:"location_accuracy,omitempty"`
+}
+
+type BoundingBox struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+type GeoFilter struct {
+	Latitude    *float64     `json:"latitude,omitempty"`
+	Longitude   *float64     `json:"longitude,omitempty"`
+	RadiusKm    float64      `json:"radius_km,omitempty"`
+	BoundingBox *BoundingBox `json:"bbox,omitempty"`
+}
+
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Geometry   *GeoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Name     string           `json:"name,omitempty"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+type EvacuationPlanLeg struct {
+	RouteID  int     `json:"route_id"`
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Distance float64 `json:"distance"`
+	Status   string  `json:"status"`
+	Cost     float64 `json:"cost"`
+}
+
+type EvacuationPlan struct {
+	DisasterID     int                 `json:"disaster_id"`
+	From           string              `json:"from"`
+	StartNode      string              `json:"start_node"`
+	AccessDistance float64             `json:"access_distance"`
+	Shelter        Shelter             `json:"shelter"`
+	Legs           []EvacuationPlanLeg `json:"legs"`
+	TotalDistance  float64             `json:"total_distance"`
+	TotalCost      float64             `json:"total_cost"`
+}
+type PublicDisaster struct {
+	ID        int       `json:"id"`
+	Type      string    `json:"type"`
+	Location  string    `json:"location"`
+	Status    string    `json:"status"`
+	Latitude  *float64  `json:"latitude,omitempty"`
+	Longitude *float64  `json:"longitude,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PublicShelter struct {
+	ID                  int       `json:"id"`
+	DisasterID          *int      `json:"disaster_id,omitempty"`
+	Name                string    `json:"name"`
+	Location            string    `json:"location"`
+	CapacityTotal       int       `json:"capacity_total"`
+	CapacityRemaining   int       `json:"capacity_remaining"`
+	OccupancyPercentage float64   `json:"occupancy_percentage"`
+	Full                bool      `json:"full"`
+	Latitude            *float64  `json:"latitude,omitempty"`
+	Longitude           *float64  `json:"longitude,omitempty"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type PublicRouteSegment struct {
+	Sequence int     `json:"sequence"`
+	Distance float64 `json:"distance"`
+	Status   string  `json:"status"`
+}
+
+type PublicEvacuationRoute struct {
+	ID          int                  `json:"id"`
+	DisasterID  *int                 `json:"disaster_id,omitempty"`
+	Origin      string               `json:"origin"`
+	Destination string               `json:"destination"`
+	Distance    float64              `json:"distance"`
+	Status      string               `json:"status"`
+	Waypoints   []RouteWaypoint      `json:"waypoints,omitempty"`
+	Segments    []PublicRouteSegment `json:"segments,omitempty"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+type Shift struct {
+	ID                     int               `json:"id"`
+	DisasterID             int               `json:"disaster_id"`
+	ShelterID              *int              `json:"shelter_id,omitempty"`
+	ShelterName            string            `json:"shelter_name,omitempty"`
+	Location               string            `json:"location"`
+	StartTime              time.Time         `json:"start_time"`
+	EndTime                time.Time         `json:"end_time"`
+	RequiredSkills         []string          `json:"required_skills"`
+	RequiredCertifications []string          `json:"required_certifications"`
+	Headcount              int               `json:"headcount"`
+	AssignedCount          int               `json:"assigned_count"`
+	Note                   string            `json:"note,omitempty"`
+	CreatedBy              *int              `json:"created_by,omitempty"`
+	Assignments            []ShiftAssignment `json:"assignments,omitempty"`
+	CreatedAt              time.Time         `json:"created_at"`
+	UpdatedAt              time.Time         `json:"updated_at"`
+}
+
+type ShiftAssignment struct {
+	ID            int       `json:"id"`
+	ShiftID       int       `json:"shift_id"`
+	VolunteerID   int       `json:"volunteer_id"`
+	VolunteerName string    `json:"volunteer_name"`
+	Skill         string    `json:"skill"`
+	Source        string    `json:"source"`
+	AssignedBy    *int      `json:"assigned_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ShiftConflict struct {
+	Type               string    `json:"type"`
+	ShiftID            int       `json:"shift_id"`
+	ConflictingShiftID *int      `json:"conflicting_shift_id,omitempty"`
+	VolunteerID        *int      `json:"volunteer_id,omitempty"`
+	VolunteerName      string    `json:"volunteer_name,omitempty"`
+	StartTime          time.Time `json:"start_time"`
+	EndTime            time.Time `json:"end_time"`
+	Headcount          int       `json:"headcount,omitempty"`
+	AssignedCount      int       `json:"assigned_count,omitempty"`
+}
+
+type ShiftInput struct {
+	DisasterID             *int     `json:"disaster_id,omitempty"`
+	ShelterID              *int     `json:"shelter_id,omitempty"`
+	Location               string   `json:"location,omitempty"`
+	StartTime              string   `json:"start_time,omitempty"`
+	EndTime                string   `json:"end_time,omitempty"`
+	RequiredSkills         []string `json:"required_skills,omitempty"`
+	RequiredCertifications []string `json:"required_certifications,omitempty"`
+	Headcount              int      `json:"headcount,omitempty"`
+	Note                   string   `json:"note,omitempty"`
+}
+
+type ShiftAssignInput struct {
+	VolunteerID int `json:"volunteer_id" binding:"required"`
+}
+
+type Task struct {
+	ID                     int                `json:"id"`
+	DisasterID             int                `json:"disaster_id"`
+	EmergencyReportID      *int               `json:"emergency_report_id,omitempty"`
+	ShelterID              *int               `json:"shelter_id,omitempty"`
+	DistributionLogID      *int               `json:"distribution_log_id,omitempty"`
+	Title                  string             `json:"title"`
+	Description            string             `json:"description,omitempty"`
+	Priority               string             `json:"priority"`
+	Status                 string             `json:"status"`
+	RequiredSkills         []string           `json:"required_skills"`
+	RequiredCertifications []string           `json:"required_certifications"`
+	DueAt                  *time.Time         `json:"due_at,omitempty"`
+	Overdue                bool               `json:"overdue"`
+	CreatedBy              *int               `json:"created_by,omitempty"`
+	CompletedAt            *time.Time         `json:"completed_at,omitempty"`
+	Assignees              []TaskAssignee     `json:"assignees"`
+	Comments               []TaskComment      `json:"comments,omitempty"`
+	History                []TaskStatusChange `json:"history,omitempty"`
+	CreatedAt              time.Time          `json:"created_at"`
+	UpdatedAt              time.Time          `json:"updated_at"`
+}
+
+type TaskAssignee struct {
+	VolunteerID   int       `json:"volunteer_id"`
+	VolunteerName string    `json:"volunteer_name"`
+	AssignedBy    *int      `json:"assigned_by,omitempty"`
+	AssignedAt    time.Time `json:"assigned_at"`
+}
+
+type TaskComment struct {
+	ID        int       `json:"id"`
+	TaskID    int       `json:"task_id"`
+	UserID    *int      `json:"user_id,omitempty"`
+	UserName  string    `json:"user_name,omitempty"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TaskStatusChange struct {
+	ID        int       `json:"id"`
+	Status    string    `json:"status"`
+	Note      string    `json:"note,omitempty"`
+	ChangedBy *int      `json:"changed_by,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type TaskInput struct {
+	DisasterID             *int     `json:"disaster_id,omitempty"`
+	EmergencyReportID      *int     `json:"emergency_report_id,omitempty"`
+	ShelterID              *int     `json:"shelter_id,omitempty"`
+	DistributionLogID      *int     `json:"distribution_log_id,omitempty"`
+	Title                  string   `json:"title,omitempty"`
+	Description            string   `json:"description,omitempty"`
+	Priority               string   `json:"priority,omitempty"`
+	RequiredSkills         []string `json:"required_skills,omitempty"`
+	RequiredCertifications []string `json:"required_certifications,omitempty"`
+	DueAt                  string   `json:"due_at,omitempty"`
+}
+
+type TaskStatusInput struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note,omitempty"`
+}
+
+type TaskAssignInput struct {
+	VolunteerID int `json:"volunteer_id" binding:"required"`
+}
+
+type TaskCommentInput struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type Skill struct {
+	ID                    int       `json:"id"`
+	Code                  string    `json:"code"`
+	Name                  string    `json:"name"`
+	Category              string    `json:"category"`
+	RequiredCertification string    `json:"required_certification,omitempty"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+type VolunteerSkill struct {
+	SkillID     int       `json:"skill_id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Category    string    `json:"category"`
+	Proficiency string    `json:"proficiency"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type VolunteerMatch struct {
+	VolunteerID    int              `json:"volunteer_id"`
+	VolunteerName  string           `json:"volunteer_name"`
+	Status         string           `json:"status"`
+	MatchedSkills  []VolunteerSkill `json:"matched_skills"`
+	DistanceKm     *float64         `json:"distance_km,omitempty"`
+	ActiveTasks    int              `json:"active_tasks"`
+	UpcomingShifts int              `json:"upcoming_shifts"`
+	SkillScore     float64          `json:"skill_score"`
+	DistanceScore  float64          `json:"distance_score"`
+	StatusScore    float64          `json:"status_score"`
+	WorkloadScore  float64          `json:"workload_score"`
+	Score          float64          `json:"score"`
+}
+
+type VolunteerMatchResult struct {
+	RequiredSkills         []string         `json:"required_skills"`
+	RequiredCertifications []string         `json:"required_certifications"`
+	Latitude               *float64         `json:"latitude,omitempty"`
+	Longitude              *float64         `json:"longitude,omitempty"`
+	Matches                []VolunteerMatch `json:"matches"`
+}
+
+type SkillInput struct {
+	Code                  string `json:"code,omitempty"`
+	Name                  string `json:"name" binding:"required"`
+	Category              string `json:"category,omitempty"`
+	RequiredCertification string `json:"required_certification,omitempty"`
+}
+
+type VolunteerSkillInput struct {
+	Skill       string `json:"skill" binding:"required"`
+	Proficiency string `json:"proficiency,omitempty"`
+}
+
+type VolunteerAttendance struct {
+	ID                int        `json:"id"`
+	VolunteerID       int        `json:"volunteer_id"`
+	VolunteerName     string     `json:"volunteer_name"`
+	DisasterID        *int       `json:"disaster_id,omitempty"`
+	ShiftID           *int       `json:"shift_id,omitempty"`
+	CheckInAt         time.Time  `json:"check_in_at"`
+	CheckInLocation   string     `json:"check_in_location"`
+	CheckInLatitude   *float64   `json:"check_in_latitude,omitempty"`
+	CheckInLongitude  *float64   `json:"check_in_longitude,omitempty"`
+	CheckOutAt        *time.Time `json:"check_out_at,omitempty"`
+	CheckOutLocation  string     `json:"check_out_location,omitempty"`
+	CheckOutLatitude  *float64   `json:"check_out_latitude,omitempty"`
+	CheckOutLongitude *float64   `json:"check_out_longitude,omitempty"`
+	Hours             float64    `json:"hours"`
+	Note              string     `json:"note,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type ServiceHours struct {
+	VolunteerID   int        `json:"volunteer_id,omitempty"`
+	VolunteerName string     `json:"volunteer_name,omitempty"`
+	DisasterID    *int       `json:"disaster_id,omitempty"`
+	DisasterName  string     `json:"disaster_name,omitempty"`
+	Sessions      int        `json:"sessions"`
+	Hours         float64    `json:"hours"`
+	FirstCheckIn  *time.Time `json:"first_check_in,omitempty"`
+	LastCheckOut  *time.Time `json:"last_check_out,omitempty"`
+}
+
+type VolunteerServiceSummary struct {
+	VolunteerID   int            `json:"volunteer_id"`
+	VolunteerName string         `json:"volunteer_name"`
+	OnSite        bool           `json:"on_site"`
+	Sessions      int            `json:"sessions"`
+	TotalHours    float64        `json:"total_hours"`
+	Disasters     []ServiceHours `json:"disasters"`
+}
+
+type DisasterServiceSummary struct {
+	DisasterID   int            `json:"disaster_id"`
+	DisasterName string         `json:"disaster_name"`
+	OnSite       int            `json:"on_site"`
+	Sessions     int            `json:"sessions"`
+	TotalHours   float64        `json:"total_hours"`
+	Volunteers   []ServiceHours `json:"volunteers"`
+}
+
+type CheckInInput struct {
+	ShiftID    *int     `json:"shift_id,omitempty"`
+	DisasterID *int     `json:"disaster_id,omitempty"`
+	Location   string   `json:"location,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	Note       string   `json:"note,omitempty"`
+}
+
+type CheckOutInput struct {
+	Location     string   `json:"location,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	Note         string   `json:"note,omitempty"`
+	CheckedOutAt string   `json:"checked_out_at,omitempty"`
+}
+
+type Certification struct {
+	ID                  int        `json:"id"`
+	VolunteerID         int        `json:"volunteer_id"`
+	VolunteerName       string     `json:"volunteer_name"`
+	CertificationType   string     `json:"certification_type"`
+	Issuer              string     `json:"issuer"`
+	CertificateNumber   string     `json:"certificate_number"`
+	IssuedAt            *time.Time `json:"issued_at,omitempty"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty"`
+	DocumentName        string     `json:"document_name,omitempty"`
+	DocumentContentType string     `json:"document_content_type,omitempty"`
+	Document            []byte     `json:"-"`
+	HasDocument         bool       `json:"has_document"`
+	Status              string     `json:"status"`
+	VerifiedBy          *int       `json:"verified_by,omitempty"`
+	VerifiedAt          *time.Time `json:"verified_at,omitempty"`
+	ReviewNote          string     `json:"review_note,omitempty"`
+	DaysUntilExpiry     *int       `json:"days_until_expiry,omitempty"`
+	Expired             bool       `json:"expired"`
+	ExpiringSoon        bool       `json:"expiring_soon"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type CertificationInput struct {
+	CertificationType string `json:"certification_type" form:"certification_type" binding:"required"`
+	Issuer            string `json:"issuer" form:"issuer" binding:"required"`
+	CertificateNumber string `json:"certificate_number" form:"certificate_number" binding:"required"`
+	IssuedAt          string `json:"issued_at,omitempty" form:"issued_at"`
+	ExpiresAt         string `json:"expires_at,omitempty" form:"expires_at"`
+}
+
+type CertificationReviewInput struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note,omitempty"`
+}